@@ -0,0 +1,205 @@
+package easings
+
+import (
+	"math"
+)
+
+// Cubic bezier easing functions
+
+// Cubic bezier solver constants
+const (
+	// Newton iterations tried before falling back to bisection
+	bezierNewtonIterations = 8
+	// Bisection iterations, enough for float32 precision
+	bezierBisectionIterations = 32
+	// Precision of the curve parameter found for a time
+	bezierEpsilon = 1e-7
+)
+
+// CubicBezier easing, like the CSS cubic-bezier(x1, y1, x2, y2) timing function. The curve goes from (0, 0) to
+// (1, 1) with control points (x1, y1) and (x2, y2), x1 and x2 are clamped to [0, 1] so every time has a single value
+func CubicBezier(x1, y1, x2, y2 float32) EaseFunc {
+	// Polynomial coefficients of both coordinates, x(s) = ((ax*s + bx)*s + cx)*s
+	cx := 3 * float64(min(max(x1, 0), 1))
+	bx := 3*float64(min(max(x2, 0), 1)) - 2*cx
+	ax := 1 - cx - bx
+	cy := 3 * float64(y1)
+	by := 3*float64(y2) - 2*cy
+	ay := 1 - cy - by
+
+	return func(t, b, c, d float32) float32 {
+		if t <= 0 {
+			return b
+		}
+		if t >= d {
+			return c + b
+		}
+
+		x := float64(t / d)
+		s := solveBezier(x, ax, bx, cx)
+		return c*float32(((ay*s+by)*s+cy)*s) + b
+	}
+}
+
+// solveBezier - Returns the curve parameter where x(s) = ((ax*s + bx)*s + cx)*s equals x, with Newton's method
+// falling back to bisection when the slope is too flat
+func solveBezier(x, ax, bx, cx float64) float64 {
+	s := x
+	for i := 0; i < bezierNewtonIterations; i++ {
+		err := ((ax*s+bx)*s+cx)*s - x
+		if math.Abs(err) < bezierEpsilon {
+			return s
+		}
+
+		slope := (3*ax*s+2*bx)*s + cx
+		if math.Abs(slope) < 1e-6 {
+			break
+		}
+		s -= err / slope
+	}
+
+	// x(s) grows from 0 to 1 in [0, 1]
+	lower, upper := 0.0, 1.0
+	s = x
+	for i := 0; i < bezierBisectionIterations; i++ {
+		err := ((ax*s+bx)*s+cx)*s - x
+		if math.Abs(err) < bezierEpsilon {
+			break
+		}
+
+		if err > 0 {
+			upper = s
+		} else {
+			lower = s
+		}
+		s = (lower + upper) / 2
+	}
+	return s
+}
+
+// Steps easing functions
+
+// StepPosition type
+type StepPosition int
+
+// Step positions, like the CSS steps() jump terms
+const (
+	// Jumps at the end of every step, the value is the start one during the first step (jump-end)
+	JumpEnd StepPosition = iota
+	// Jumps at the start of every step, the value is the first step one right away (jump-start)
+	JumpStart
+	// Jumps at the start and the end, n + 1 jumps in total (jump-both)
+	JumpBoth
+	// Jumps between steps only, the first and last steps hold the start and end values (jump-none)
+	JumpNone
+)
+
+// Steps easing, like the CSS steps(n, position) timing function. Moves the value in n equal jumps, jump-none
+// steps need at least 2 steps
+func Steps(n int, position StepPosition) EaseFunc {
+	n = max(n, 1)
+	if position == JumpNone {
+		n = max(n, 2)
+	}
+
+	jumps := n
+	switch position {
+	case JumpBoth:
+		jumps = n + 1
+	case JumpNone:
+		jumps = n - 1
+	}
+
+	return func(t, b, c, d float32) float32 {
+		if t >= d {
+			return c + b
+		}
+
+		step := int(math.Floor(float64(t / d * float32(n))))
+		if position == JumpStart || position == JumpBoth {
+			step++
+		}
+		step = min(max(step, 0), jumps)
+		return c*float32(step)/float32(jumps) + b
+	}
+}
+
+// Spring easing functions
+
+// Spring constants
+const (
+	// Distance to the change in value, relative to it, where springs are settled
+	springRestThreshold = 0.001
+	// Time step used to find when springs settle, in seconds
+	springRestStep = 1.0 / 240
+	// Longest spring settling time, in seconds
+	maxSpringDuration = 60
+)
+
+// Spring easing, a damped spring pulling the value towards the change in value. It moves in real time (t and d in
+// seconds), so the curve keeps its shape for any duration and jumps to the end value when the duration ends. Use
+// SpringDuration as duration to end it once it settles.
+// mass: attached mass, stiffness: spring stiffness, damping: damping coefficient (critically damped at
+// 2*sqrt(stiffness*mass)), velocity: initial velocity towards the end value, in changes in value per second
+func Spring(mass, stiffness, damping, velocity float32) EaseFunc {
+	offset := springOffset(mass, stiffness, damping, velocity)
+
+	return func(t, b, c, d float32) float32 {
+		if t <= 0 {
+			return b
+		}
+		if t >= d {
+			return c + b
+		}
+		return c*(1-float32(offset(float64(t)))) + b
+	}
+}
+
+// SpringDuration - Returns the time in seconds a spring easing takes to settle close to the end value
+func SpringDuration(mass, stiffness, damping, velocity float32) float32 {
+	offset := springOffset(mass, stiffness, damping, velocity)
+
+	// Last time the spring is away from the end value, checking it stays close for a second
+	var settled float64
+	for t := 0.0; t < maxSpringDuration && t-settled < 1; t += springRestStep {
+		if math.Abs(offset(t)) > springRestThreshold {
+			settled = t + springRestStep
+		}
+	}
+	return float32(min(settled, maxSpringDuration))
+}
+
+// springOffset - Returns the spring distance to the end value over time, relative to the change in value. Starts at
+// 1 and solves mass*acceleration + damping*velocity + stiffness*offset = 0, moving towards the end value at the
+// initial velocity
+func springOffset(mass, stiffness, damping, velocity float32) func(t float64) float64 {
+	m := math.Max(float64(mass), 1e-6)
+	k := math.Max(float64(stiffness), 1e-6)
+	v := float64(velocity)
+
+	omega := math.Sqrt(k / m)
+	zeta := math.Max(float64(damping), 0) / (2 * math.Sqrt(k*m))
+
+	switch {
+	case zeta < 1-1e-6:
+		// Underdamped, oscillates around the end value
+		omegaD := omega * math.Sqrt(1-zeta*zeta)
+		sine := (zeta*omega - v) / omegaD
+		return func(t float64) float64 {
+			return math.Exp(-zeta*omega*t) * (math.Cos(omegaD*t) + sine*math.Sin(omegaD*t))
+		}
+	case zeta > 1+1e-6:
+		// Overdamped, creeps towards the end value
+		root := omega * math.Sqrt(zeta*zeta-1)
+		r1, r2 := -zeta*omega+root, -zeta*omega-root
+		a := (-v - r2) / (r1 - r2)
+		return func(t float64) float64 {
+			return a*math.Exp(r1*t) + (1-a)*math.Exp(r2*t)
+		}
+	default:
+		// Critically damped, fastest without overshooting
+		return func(t float64) float64 {
+			return math.Exp(-omega*t) * (1 + (omega-v)*t)
+		}
+	}
+}
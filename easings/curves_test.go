@@ -0,0 +1,121 @@
+package easings
+
+import (
+	"testing"
+)
+
+func TestCubicBezier(t *testing.T) {
+	// Reference values of the CSS named curves and an overshooting curve
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 float32
+		values         [5]float32
+	}{
+		{"ease", 0.25, 0.1, 0.25, 1, [5]float32{0.094796, 0.408511, 0.802403, 0.960459, 0.994316}},
+		{"ease-in", 0.42, 0, 1, 1, [5]float32{0.017027, 0.093465, 0.315357, 0.621862, 0.839428}},
+		{"ease-out", 0, 0, 0.58, 1, [5]float32{0.160572, 0.378138, 0.684643, 0.906535, 0.982973}},
+		{"back", 0.68, -0.55, 0.265, 1.55, [5]float32{-0.066291, -0.082807, 0.60668, 1.089166, 1.062373}},
+		{"linear", 0, 0, 1, 1, [5]float32{0.1, 0.25, 0.5, 0.75, 0.9}},
+	}
+
+	for _, test := range tests {
+		ease := CubicBezier(test.x1, test.y1, test.x2, test.y2)
+		for i, time := range []float32{0.1, 0.25, 0.5, 0.75, 0.9} {
+			// Scaled to a 2 seconds curve from 10 to 30
+			if value := ease(time*2, 10, 20, 2); !nearlyEqualTolerance(value, 10+20*test.values[i], 1e-3) {
+				t.Errorf("%s value is %v at %v, expected %v", test.name, value, time, 10+20*test.values[i])
+			}
+		}
+
+		if ease(0, 10, 20, 2) != 10 || ease(2, 10, 20, 2) != 30 {
+			t.Errorf("%s does not start and end at the curve ends", test.name)
+		}
+	}
+
+	// Flat slopes use the bisection fallback
+	ease := CubicBezier(1, 0, 0, 1)
+	previous := float32(0)
+	for time := float32(0); time <= 1; time += 0.01 {
+		value := ease(time, 0, 1, 1)
+		if value < previous-1e-6 {
+			t.Fatalf("curve goes back from %v to %v at %v", previous, value, time)
+		}
+		previous = value
+	}
+	if !nearlyEqual(ease(0.5, 0, 1, 1), 0.5) {
+		t.Errorf("symmetric curve value is %v half way", ease(0.5, 0, 1, 1))
+	}
+}
+
+func TestSteps(t *testing.T) {
+	times := []float32{0, 0.2, 0.25, 0.5, 0.99, 1}
+	tests := []struct {
+		name     string
+		n        int
+		position StepPosition
+		values   []float32
+	}{
+		{"jump-end", 4, JumpEnd, []float32{0, 0, 0.25, 0.5, 0.75, 1}},
+		{"jump-start", 4, JumpStart, []float32{0.25, 0.25, 0.5, 0.75, 1, 1}},
+		{"jump-both", 4, JumpBoth, []float32{0.2, 0.2, 0.4, 0.6, 0.8, 1}},
+		{"jump-none", 5, JumpNone, []float32{0, 0.25, 0.25, 0.5, 1, 1}},
+		{"jump-none single step", 1, JumpNone, []float32{0, 0, 0, 1, 1, 1}},
+	}
+
+	for _, test := range tests {
+		ease := Steps(test.n, test.position)
+		for i, time := range times {
+			if value := ease(time, 0, 1, 1); !nearlyEqual(value, test.values[i]) {
+				t.Errorf("%s value is %v at %v, expected %v", test.name, value, time, test.values[i])
+			}
+		}
+	}
+}
+
+func TestSpring(t *testing.T) {
+	// Reference values of an underdamped spring, in real time
+	ease := Spring(1, 100, 10, 0)
+	for i, value := range []float32{0.3403, 0.849426, 1.124355, 1.074591} {
+		time := []float32{0.1, 0.2, 0.3, 0.5}[i]
+		if got := ease(time, 0, 1, 10); !nearlyEqualTolerance(got, value, 1e-4) {
+			t.Errorf("spring value is %v at %v, expected %v", got, time, value)
+		}
+	}
+
+	// Every damping matches a numerical integration of the spring
+	for _, damping := range []float32{5, 20, 40} {
+		ease := Spring(1, 100, damping, 2)
+		position, velocity := float64(0), float64(2)
+		step := 1e-5
+		for time := step; time <= 1; time += step {
+			velocity += (100*(1-position) - float64(damping)*velocity) * step
+			position += velocity * step
+			if i := int(time/step + 0.5); i%10000 == 0 && !nearlyEqualTolerance(ease(float32(time), 0, 1, 10), float32(position), 1e-3) {
+				t.Errorf("spring with damping %v value is %v at %v, integrated %v", damping, ease(float32(time), 0, 1, 10), time, position)
+			}
+		}
+	}
+
+	// Critically and overdamped springs do not overshoot, springs settle within their duration
+	for _, damping := range []float32{20, 40} {
+		ease := Spring(1, 100, damping, 0)
+		duration := SpringDuration(1, 100, damping, 0)
+		for time := float32(0); time <= duration; time += 0.01 {
+			if value := ease(time, 0, 1, duration); value > 1 {
+				t.Errorf("spring with damping %v overshoots to %v", damping, value)
+			}
+		}
+	}
+
+	duration := SpringDuration(1, 100, 10, 0)
+	if duration <= 0.5 || duration >= 2 || !nearlyEqualTolerance(ease(duration*0.999, 0, 1, duration), 1, 2e-3) {
+		t.Errorf("spring settles in %v at %v", duration, ease(duration*0.999, 0, 1, duration))
+	}
+	if SpringDuration(1, 100, 0, 0) != maxSpringDuration {
+		t.Error("undamped spring settles")
+	}
+}
+
+func nearlyEqualTolerance(a, b, tolerance float32) bool {
+	return a-b <= tolerance && b-a <= tolerance
+}
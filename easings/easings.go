@@ -181,26 +181,59 @@ func ExpoInOut(t, b, c, d float32) float32 {
 
 // Back Easing functions
 
+// Default overshoot of the back easing functions, about 10% past the change in value
+const backOvershoot = 1.70158
+
 // BackIn easing
 // t: current time, b: begInnIng value, c: change In value, d: duration
 func BackIn(t, b, c, d float32) float32 {
-	s := float32(1.70158)
-	t = t / d
-	return c*t*t*((s+1)*t-s) + b
+	return backIn(t, b, c, d, backOvershoot)
 }
 
 // BackOut easing
 // t: current time, b: begInnIng value, c: change In value, d: duration
 func BackOut(t, b, c, d float32) float32 {
-	s := float32(1.70158)
-	t = t/d - 1
-	return c*(t*t*((s+1)*t+s)+1) + b
+	return backOut(t, b, c, d, backOvershoot)
 }
 
 // BackInOut easing
 // t: current time, b: begInnIng value, c: change In value, d: duration
 func BackInOut(t, b, c, d float32) float32 {
-	s := float32(1.70158)
+	return backInOut(t, b, c, d, backOvershoot)
+}
+
+// BackInWith - BackIn easing with an overshoot amount (1.70158 in BackIn, 0 does not overshoot)
+func BackInWith(overshoot float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return backIn(t, b, c, d, overshoot)
+	}
+}
+
+// BackOutWith - BackOut easing with an overshoot amount (1.70158 in BackOut, 0 does not overshoot)
+func BackOutWith(overshoot float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return backOut(t, b, c, d, overshoot)
+	}
+}
+
+// BackInOutWith - BackInOut easing with an overshoot amount (1.70158 in BackInOut, 0 does not overshoot)
+func BackInOutWith(overshoot float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return backInOut(t, b, c, d, overshoot)
+	}
+}
+
+func backIn(t, b, c, d, s float32) float32 {
+	t = t / d
+	return c*t*t*((s+1)*t-s) + b
+}
+
+func backOut(t, b, c, d, s float32) float32 {
+	t = t/d - 1
+	return c*(t*t*((s+1)*t+s)+1) + b
+}
+
+func backInOut(t, b, c, d, s float32) float32 {
 	s = s * 1.525
 	t = t / d * 2
 
@@ -250,9 +283,65 @@ func BounceInOut(t, b, c, d float32) float32 {
 
 // Elastic Easing functions
 
+// Default elastic easing functions period, relative to the duration (ElasticInOut uses 1.5 times longer periods)
+const elasticPeriod = 0.3
+
 // ElasticIn easing
 // t: current time, b: begInnIng value, c: change In value, d: duration
 func ElasticIn(t, b, c, d float32) float32 {
+	return elasticIn(t, b, c, d, 1, elasticPeriod)
+}
+
+// ElasticOut easing
+// t: current time, b: begInnIng value, c: change In value, d: duration
+func ElasticOut(t, b, c, d float32) float32 {
+	return elasticOut(t, b, c, d, 1, elasticPeriod)
+}
+
+// ElasticInOut easing
+// t: current time, b: begInnIng value, c: change In value, d: duration
+func ElasticInOut(t, b, c, d float32) float32 {
+	return elasticInOut(t, b, c, d, 1, elasticPeriod*1.5)
+}
+
+// ElasticInWith - ElasticIn easing with an amplitude relative to the change in value (at least 1) and a period
+// relative to the duration (1 and 0.3 in ElasticIn)
+func ElasticInWith(amplitude, period float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return elasticIn(t, b, c, d, amplitude, period)
+	}
+}
+
+// ElasticOutWith - ElasticOut easing with an amplitude relative to the change in value (at least 1) and a period
+// relative to the duration (1 and 0.3 in ElasticOut)
+func ElasticOutWith(amplitude, period float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return elasticOut(t, b, c, d, amplitude, period)
+	}
+}
+
+// ElasticInOutWith - ElasticInOut easing with an amplitude relative to the change in value (at least 1) and a
+// period relative to the duration (1 and 0.45 in ElasticInOut)
+func ElasticInOutWith(amplitude, period float32) EaseFunc {
+	return func(t, b, c, d float32) float32 {
+		return elasticInOut(t, b, c, d, amplitude, period)
+	}
+}
+
+// elasticParams - Returns the elastic period, amplitude and phase shift in time units. Amplitudes lower than the
+// change in value are raised to it
+func elasticParams(c, d, amplitude, period float32) (float32, float32, float32) {
+	p := d * period
+	if amplitude <= 1 {
+		return p, c, p / 4
+	}
+
+	a := c * amplitude
+	s := p / (2 * math.Pi) * float32(math.Asin(float64(1/amplitude)))
+	return p, a, s
+}
+
+func elasticIn(t, b, c, d, amplitude, period float32) float32 {
 	if t == 0 {
 		return b
 	}
@@ -263,17 +352,14 @@ func ElasticIn(t, b, c, d float32) float32 {
 		return b + c
 	}
 
-	p := d * 0.3
-	a := c
-	s := p / 4
-	postFix := a * float32(math.Pow(2, 10*float64(t-1)))
+	p, a, s := elasticParams(c, d, amplitude, period)
+	t = t - 1
+	postFix := a * float32(math.Pow(2, 10*float64(t)))
 
 	return -(postFix * float32(math.Sin(float64(t*d-s)*(2*math.Pi)/float64(p)))) + b
 }
 
-// ElasticOut easing
-// t: current time, b: begInnIng value, c: change In value, d: duration
-func ElasticOut(t, b, c, d float32) float32 {
+func elasticOut(t, b, c, d, amplitude, period float32) float32 {
 	if t == 0 {
 		return b
 	}
@@ -284,16 +370,12 @@ func ElasticOut(t, b, c, d float32) float32 {
 		return b + c
 	}
 
-	p := d * 0.3
-	a := c
-	s := p / 4
+	p, a, s := elasticParams(c, d, amplitude, period)
 
 	return a*float32(math.Pow(2, -10*float64(t)))*float32(math.Sin(float64(t*d-s)*(2*math.Pi)/float64(p))) + c + b
 }
 
-// ElasticInOut easing
-// t: current time, b: begInnIng value, c: change In value, d: duration
-func ElasticInOut(t, b, c, d float32) float32 {
+func elasticInOut(t, b, c, d, amplitude, period float32) float32 {
 	if t == 0 {
 		return b
 	}
@@ -304,9 +386,7 @@ func ElasticInOut(t, b, c, d float32) float32 {
 		return b + c
 	}
 
-	p := d * (0.3 * 1.5)
-	a := c
-	s := p / 4
+	p, a, s := elasticParams(c, d, amplitude, period)
 
 	if t < 1 {
 		t = t - 1
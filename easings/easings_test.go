@@ -0,0 +1,21 @@
+package easings
+
+import (
+	"testing"
+)
+
+func TestElasticIn(t *testing.T) {
+	// Elastic in mirrors elastic out
+	for time := float32(0); time <= 1; time += 0.05 {
+		in := ElasticIn(time, 0, 1, 1)
+		out := ElasticOut(1-time, 0, 1, 1)
+		if !nearlyEqualTolerance(in, 1-out, 1e-4) {
+			t.Errorf("elastic in is %v at %v, mirrored elastic out %v", in, time, 1-out)
+		}
+	}
+
+	// The last oscillation swings below the start value before reaching the end value
+	if value := ElasticIn(0.9, 0, 1, 1); !nearlyEqualTolerance(value, -0.25, 1e-3) {
+		t.Errorf("elastic in value is %v at 0.9, expected -0.25", value)
+	}
+}
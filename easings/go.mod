@@ -1,3 +1,11 @@
 module github.com/gen2brain/raylib-go/easings
 
 go 1.21
+
+require github.com/gen2brain/raylib-go/raylib v0.56.0-dev.0.20260513185948-c427d7332954
+
+require (
+	github.com/ebitengine/purego v0.10.0 // indirect
+	github.com/jupiterrider/ffi v0.7.0 // indirect
+	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
+)
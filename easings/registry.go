@@ -0,0 +1,344 @@
+package easings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EaseFactory - Creates a parametric easing function from the arguments of an easing spec, as written in it
+type EaseFactory func(args []string) (EaseFunc, error)
+
+// Easing - Easing function read from an easing spec, a registered name like "backOut" optionally followed by
+// arguments like "backOut(2.5)" or "cubicBezier(0.25, 0.1, 0.25, 1)". Easings are read from and written to JSON and
+// other text formats as their spec
+type Easing struct {
+	// Spec the easing function was read from
+	Spec string
+	// Easing function (nil eases linearly)
+	Func EaseFunc
+}
+
+// registryEntry - Easing functions registered with a name
+type registryEntry struct {
+	// Name as registered
+	name string
+	// Easing function used when the spec has no arguments
+	ease EaseFunc
+	// Easing function factory used when the spec has arguments
+	factory EaseFactory
+}
+
+// Easing functions by lowercase name
+var (
+	registry     = map[string]*registryEntry{}
+	registryLock sync.RWMutex
+)
+
+func init() {
+	for name, ease := range map[string]EaseFunc{
+		"linearNone":   LinearNone,
+		"linearIn":     LinearIn,
+		"linearOut":    LinearOut,
+		"linearInOut":  LinearInOut,
+		"sineIn":       SineIn,
+		"sineOut":      SineOut,
+		"sineInOut":    SineInOut,
+		"circIn":       CircIn,
+		"circOut":      CircOut,
+		"circInOut":    CircInOut,
+		"cubicIn":      CubicIn,
+		"cubicOut":     CubicOut,
+		"cubicInOut":   CubicInOut,
+		"quadIn":       QuadIn,
+		"quadOut":      QuadOut,
+		"quadInOut":    QuadInOut,
+		"expoIn":       ExpoIn,
+		"expoOut":      ExpoOut,
+		"expoInOut":    ExpoInOut,
+		"backIn":       BackIn,
+		"backOut":      BackOut,
+		"backInOut":    BackInOut,
+		"bounceIn":     BounceIn,
+		"bounceOut":    BounceOut,
+		"bounceInOut":  BounceInOut,
+		"elasticIn":    ElasticIn,
+		"elasticOut":   ElasticOut,
+		"elasticInOut": ElasticInOut,
+	} {
+		Register(name, ease)
+	}
+
+	// backIn(overshoot)
+	for name, with := range map[string]func(float32) EaseFunc{
+		"backIn":    BackInWith,
+		"backOut":   BackOutWith,
+		"backInOut": BackInOutWith,
+	} {
+		with := with
+		RegisterFactory(name, func(args []string) (EaseFunc, error) {
+			values, err := parseArgs(args, 0, 1)
+			if err != nil {
+				return nil, err
+			}
+			return with(argOr(values, 0, backOvershoot)), nil
+		})
+	}
+
+	// elasticIn(amplitude, period)
+	for name, with := range map[string]func(float32, float32) EaseFunc{
+		"elasticIn":    ElasticInWith,
+		"elasticOut":   ElasticOutWith,
+		"elasticInOut": ElasticInOutWith,
+	} {
+		with := with
+		period := float32(elasticPeriod)
+		if name == "elasticInOut" {
+			period *= 1.5
+		}
+		RegisterFactory(name, func(args []string) (EaseFunc, error) {
+			values, err := parseArgs(args, 0, 2)
+			if err != nil {
+				return nil, err
+			}
+			return with(argOr(values, 0, 1), argOr(values, 1, period)), nil
+		})
+	}
+
+	// cubicBezier(x1, y1, x2, y2)
+	RegisterFactory("cubicBezier", func(args []string) (EaseFunc, error) {
+		values, err := parseArgs(args, 4, 4)
+		if err != nil {
+			return nil, err
+		}
+		return CubicBezier(values[0], values[1], values[2], values[3]), nil
+	})
+
+	// steps(n, position), position is a CSS jump term and jump-end by default
+	RegisterFactory("steps", func(args []string) (EaseFunc, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("easings: steps takes 1 or 2 arguments, got %d", len(args))
+		}
+
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("easings: invalid steps count %q", args[0])
+		}
+
+		position := JumpEnd
+		if len(args) == 2 {
+			switch args[1] {
+			case "jump-end", "end":
+				position = JumpEnd
+			case "jump-start", "start":
+				position = JumpStart
+			case "jump-both":
+				position = JumpBoth
+			case "jump-none":
+				position = JumpNone
+			default:
+				return nil, fmt.Errorf("easings: invalid steps position %q", args[1])
+			}
+		}
+		return Steps(n, position), nil
+	})
+
+	// spring(mass, stiffness, damping, velocity)
+	RegisterFactory("spring", func(args []string) (EaseFunc, error) {
+		values, err := parseArgs(args, 3, 4)
+		if err != nil {
+			return nil, err
+		}
+		return Spring(values[0], values[1], values[2], argOr(values, 3, 0)), nil
+	})
+}
+
+// Register - Registers an easing function with a name, replacing the one registered with the same name. Names are
+// matched ignoring case
+func Register(name string, ease EaseFunc) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	entry := registryEntryFor(name)
+	entry.ease = ease
+}
+
+// RegisterFactory - Registers a parametric easing function factory with a name, used by specs with arguments like
+// "name(1, 2)". Names are matched ignoring case
+func RegisterFactory(name string, factory EaseFactory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	entry := registryEntryFor(name)
+	entry.factory = factory
+}
+
+// Get - Returns the easing function registered with a name
+func Get(name string) (EaseFunc, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	entry, ok := registry[strings.ToLower(name)]
+	if !ok || entry.ease == nil {
+		return nil, false
+	}
+	return entry.ease, true
+}
+
+// Names - Returns the sorted names of the registered easing functions and factories
+func Names() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for _, entry := range registry {
+		names = append(names, entry.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse - Returns the easing function of a spec, a registered name optionally followed by arguments in parentheses
+// like "backOut(2.5)"
+func Parse(spec string) (EaseFunc, error) {
+	name, args, hasArgs, err := splitSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries change when registering, factories are called without holding the lock
+	registryLock.RLock()
+	var ease EaseFunc
+	var factory EaseFactory
+	entry, ok := registry[strings.ToLower(name)]
+	if ok {
+		ease, factory = entry.ease, entry.factory
+	}
+	registryLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("easings: unknown easing %q", name)
+	}
+
+	if !hasArgs {
+		if ease == nil {
+			return nil, fmt.Errorf("easings: easing %q needs arguments", name)
+		}
+		return ease, nil
+	}
+
+	if factory == nil {
+		return nil, fmt.Errorf("easings: easing %q takes no arguments", name)
+	}
+	return factory(args)
+}
+
+// ParseEasing - Returns the easing of a spec
+func ParseEasing(spec string) (Easing, error) {
+	ease, err := Parse(spec)
+	if err != nil {
+		return Easing{}, err
+	}
+	return Easing{Spec: strings.TrimSpace(spec), Func: ease}, nil
+}
+
+// Ease - Eases a value with the easing function, linearly for easings without function
+// t: current time, b: beginning value, c: change in value, d: duration
+func (e Easing) Ease(t, b, c, d float32) float32 {
+	if e.Func == nil {
+		return LinearNone(t, b, c, d)
+	}
+	return e.Func(t, b, c, d)
+}
+
+// String - Returns the easing spec
+func (e Easing) String() string {
+	return e.Spec
+}
+
+// MarshalText - Encodes the easing as its spec
+func (e Easing) MarshalText() ([]byte, error) {
+	return []byte(e.Spec), nil
+}
+
+// UnmarshalText - Decodes the easing from its spec, empty specs decode to an easing without function
+func (e *Easing) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*e = Easing{}
+		return nil
+	}
+
+	easing, err := ParseEasing(string(text))
+	if err != nil {
+		return err
+	}
+	*e = easing
+	return nil
+}
+
+// registryEntryFor - Returns the registry entry of a name, creating it when missing. The registry must be locked
+func registryEntryFor(name string) *registryEntry {
+	key := strings.ToLower(name)
+	entry, ok := registry[key]
+	if !ok {
+		entry = &registryEntry{name: name}
+		registry[key] = entry
+	}
+	return entry
+}
+
+// splitSpec - Splits an easing spec in its name and trimmed arguments
+func splitSpec(spec string) (string, []string, bool, error) {
+	spec = strings.TrimSpace(spec)
+
+	open := strings.IndexByte(spec, '(')
+	if open < 0 {
+		if spec == "" {
+			return "", nil, false, errors.New("easings: empty easing spec")
+		}
+		return spec, nil, false, nil
+	}
+
+	name := strings.TrimSpace(spec[:open])
+	if name == "" || !strings.HasSuffix(spec, ")") {
+		return "", nil, false, fmt.Errorf("easings: invalid easing spec %q", spec)
+	}
+
+	var args []string
+	if inner := strings.TrimSpace(spec[open+1 : len(spec)-1]); inner != "" {
+		for _, arg := range strings.Split(inner, ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	return name, args, true, nil
+}
+
+// parseArgs - Parses between minimum and maximum numeric easing spec arguments
+func parseArgs(args []string, minimum, maximum int) ([]float32, error) {
+	if len(args) < minimum || len(args) > maximum {
+		if minimum == maximum {
+			return nil, fmt.Errorf("easings: expected %d arguments, got %d", minimum, len(args))
+		}
+		return nil, fmt.Errorf("easings: expected %d to %d arguments, got %d", minimum, maximum, len(args))
+	}
+
+	values := make([]float32, len(args))
+	for i, arg := range args {
+		value, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return nil, fmt.Errorf("easings: invalid easing argument %q", arg)
+		}
+		values[i] = float32(value)
+	}
+	return values, nil
+}
+
+// argOr - Returns a parsed argument or a default value when it is missing
+func argOr(values []float32, index int, value float32) float32 {
+	if index < len(values) {
+		return values[index]
+	}
+	return value
+}
@@ -0,0 +1,155 @@
+package easings
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	// Every easing function is registered with its name in lower camel case
+	for name, ease := range map[string]EaseFunc{"linearNone": LinearNone, "quadInOut": QuadInOut, "backOut": BackOut, "elasticInOut": ElasticInOut} {
+		registered, ok := Get(name)
+		if !ok || !sameCurve(registered, ease) {
+			t.Errorf("%s is not registered", name)
+		}
+	}
+	names := strings.Join(Names(), " ")
+	for _, name := range []string{"linearNone", "quadIn", "backOut", "elasticInOut", "cubicBezier", "steps", "spring"} {
+		if !strings.Contains(" "+names+" ", " "+name+" ") {
+			t.Errorf("%s missing from the registry names %v", name, names)
+		}
+	}
+	if _, ok := Get("BACKOUT"); !ok {
+		t.Error("names are case sensitive")
+	}
+	if _, ok := Get("cubicBezier"); ok {
+		t.Error("parametric easing without arguments is registered")
+	}
+
+	// Custom easings and factories
+	t.Cleanup(func() {
+		registryLock.Lock()
+		defer registryLock.Unlock()
+		delete(registry, "half")
+	})
+	Register("half", func(t, b, c, d float32) float32 { return c/2 + b })
+	RegisterFactory("half", func(args []string) (EaseFunc, error) {
+		values, err := parseArgs(args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(t, b, c, d float32) float32 { return c*values[0] + b }, nil
+	})
+	if half, err := Parse("half"); err != nil || half(0, 0, 10, 1) != 5 {
+		t.Errorf("custom easing not parsed: %v", err)
+	}
+	if quarter, err := Parse("half(0.25)"); err != nil || quarter(0, 0, 10, 1) != 2.5 {
+		t.Errorf("custom factory not parsed: %v", err)
+	}
+
+	// Easings are parsed while others are registered
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			Register("half", LinearNone)
+			RegisterFactory("half", func(args []string) (EaseFunc, error) { return LinearNone, nil })
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := Parse("half(1)"); err != nil {
+			t.Errorf("easing not parsed while registering: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec string
+		ease EaseFunc
+	}{
+		{"backOut", BackOut},
+		{" BackOut ", BackOut},
+		{"backOut()", BackOut},
+		{"backIn(1.70158)", BackIn},
+		{"backInOut(0)", CubicInOut},
+		{"backIn(3)", BackInWith(3)},
+		{"elasticOut", ElasticOut},
+		{"elasticIn(1, 0.3)", ElasticIn},
+		{"elasticInOut()", ElasticInOut},
+		{"elasticOut(2, 0.5)", ElasticOutWith(2, 0.5)},
+		{"cubicBezier(0.25, 0.1, 0.25, 1)", CubicBezier(0.25, 0.1, 0.25, 1)},
+		{"steps(4)", Steps(4, JumpEnd)},
+		{"steps(4, jump-start)", Steps(4, JumpStart)},
+		{"steps(3,jump-none)", Steps(3, JumpNone)},
+		{"spring(1, 100, 10)", Spring(1, 100, 10, 0)},
+	}
+	for _, test := range tests {
+		ease, err := Parse(test.spec)
+		if err != nil {
+			t.Errorf("%q: %v", test.spec, err)
+			continue
+		}
+		if !sameCurve(ease, test.ease) {
+			t.Errorf("%q parsed to a different curve", test.spec)
+		}
+	}
+
+	for _, spec := range []string{"", "unknown", "cubicBezier", "quadIn(2)", "backOut(2", "backOut(a)", "cubicBezier(1, 2)", "steps(0)", "steps(2, middle)", "(1)"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%q parsed", spec)
+		}
+	}
+}
+
+func TestEasingJSON(t *testing.T) {
+	var animation struct {
+		Ease  Easing
+		Other Easing
+	}
+	if err := json.Unmarshal([]byte(`{"ease": "cubicBezier(0.42, 0, 0.58, 1)"}`), &animation); err != nil {
+		t.Fatal(err)
+	}
+	if !sameCurve(animation.Ease.Ease, CubicBezier(0.42, 0, 0.58, 1)) || animation.Other.Ease(0.5, 0, 1, 1) != 0.5 {
+		t.Error("easings decoded to different curves")
+	}
+
+	data, err := json.Marshal(animation)
+	if err != nil || string(data) != `{"Ease":"cubicBezier(0.42, 0, 0.58, 1)","Other":""}` {
+		t.Errorf("easings encoded to %s: %v", data, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"ease": "bounceSideways"}`), &animation)
+	if err == nil || !strings.Contains(err.Error(), "bounceSideways") {
+		t.Errorf("unknown easing decoded: %v", err)
+	}
+}
+
+func TestParametricEasings(t *testing.T) {
+	// Default parameters match the fixed easing functions
+	if !sameCurve(BackInWith(backOvershoot), BackIn) || !sameCurve(BackOutWith(0), CubicOut) {
+		t.Error("back easings parameters do not match")
+	}
+	if !sameCurve(ElasticOutWith(1, 0.3), ElasticOut) || !sameCurve(ElasticInOutWith(0.5, 0.45), ElasticInOut) {
+		t.Error("elastic easings parameters do not match")
+	}
+
+	// Larger amplitudes overshoot further
+	if ElasticOutWith(2, 0.3)(0.1, 0, 1, 1) <= ElasticOut(0.1, 0, 1, 1) {
+		t.Error("larger amplitude does not overshoot further")
+	}
+}
+
+// sameCurve - Checks if two easing functions give the same values
+func sameCurve(a, b EaseFunc) bool {
+	for time := float32(0); time <= 2; time += 0.05 {
+		if !nearlyEqualTolerance(a(time, 10, 20, 2), b(time, 10, 20, 2), 1e-4) {
+			return false
+		}
+	}
+	return true
+}
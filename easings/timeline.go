@@ -0,0 +1,130 @@
+package easings
+
+// Timeline - Plays tweens and other timelines at given times, one after another (sequences) or together (parallel
+// groups). Animations added to a timeline are driven by it and must not be updated on their own
+type Timeline struct {
+	player
+	// Animations and the timeline times where they start
+	entries []timelineEntry
+	// Time inside the iteration set by the last render
+	last float32
+}
+
+// timelineEntry - Animation played by a timeline
+type timelineEntry struct {
+	animation Animation
+	offset    float32
+}
+
+// NewTimeline - Creates an empty timeline
+func NewTimeline() *Timeline {
+	tl := &Timeline{}
+	tl.animator = tl
+	return tl
+}
+
+// NewSequence - Creates a timeline playing animations one after another
+func NewSequence(animations ...Animation) *Timeline {
+	return NewTimeline().Append(animations...)
+}
+
+// NewParallel - Creates a timeline playing animations together
+func NewParallel(animations ...Animation) *Timeline {
+	return NewTimeline().Insert(0, animations...)
+}
+
+// Append - Adds animations played one after another after the end of the timeline
+func (tl *Timeline) Append(animations ...Animation) *Timeline {
+	for _, animation := range animations {
+		tl.entries = append(tl.entries, timelineEntry{animation: animation, offset: tl.span()})
+	}
+	return tl
+}
+
+// Insert - Adds animations played together from a time of the timeline
+func (tl *Timeline) Insert(time float32, animations ...Animation) *Timeline {
+	for _, animation := range animations {
+		tl.entries = append(tl.entries, timelineEntry{animation: animation, offset: max(time, 0)})
+	}
+	return tl
+}
+
+// SetDelay - Sets the time waited before the timeline first iteration
+func (tl *Timeline) SetDelay(seconds float32) *Timeline {
+	tl.delay = max(seconds, 0)
+	return tl
+}
+
+// SetRepeat - Sets the iterations played after the first one (-1 repeats forever)
+func (tl *Timeline) SetRepeat(count int) *Timeline {
+	tl.repeat = max(count, -1)
+	return tl
+}
+
+// SetYoyo - Sets if odd iterations play backwards
+func (tl *Timeline) SetYoyo(yoyo bool) *Timeline {
+	tl.yoyo = yoyo
+	return tl
+}
+
+// OnStart - Sets the callback called when the timeline starts, after its delay
+func (tl *Timeline) OnStart(fn func()) *Timeline {
+	tl.onStart = fn
+	return tl
+}
+
+// OnUpdate - Sets the callback called every time the timeline moves its animations
+func (tl *Timeline) OnUpdate(fn func()) *Timeline {
+	tl.onUpdate = fn
+	return tl
+}
+
+// OnComplete - Sets the callback called when the timeline reaches its end in its playing direction
+func (tl *Timeline) OnComplete(fn func()) *Timeline {
+	tl.onComplete = fn
+	return tl
+}
+
+func (tl *Timeline) span() float32 {
+	var end float32
+	for _, entry := range tl.entries {
+		end = max(end, entry.offset+entry.animation.Duration())
+	}
+	return end
+}
+
+func (tl *Timeline) begin() {}
+
+// render - Moves the animations to a time of the timeline. Going forwards the earlier animations are moved first and
+// going backwards the later ones, so animations sharing a target leave it with the value of the current one
+func (tl *Timeline) render(time float32) {
+	backwards := time < tl.last
+	tl.last = time
+
+	for i := range tl.entries {
+		entry := tl.entries[i]
+		if backwards {
+			entry = tl.entries[len(tl.entries)-1-i]
+		}
+
+		child := entry.animation.base()
+		local := time - entry.offset
+		if local < 0 && !child.started {
+			// Not reached yet
+			continue
+		}
+
+		local = min(max(local, 0), child.Duration())
+		if child.started && local == child.time {
+			continue
+		}
+		entry.animation.Seek(local)
+	}
+}
+
+func (tl *Timeline) reset() {
+	tl.last = 0
+	for _, entry := range tl.entries {
+		entry.animation.base().restart()
+	}
+}
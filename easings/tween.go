@@ -0,0 +1,392 @@
+package easings
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// EaseFunc - Easing function like the ones in this package
+// t: current time, b: beginning value, c: change in value, d: duration
+type EaseFunc func(t, b, c, d float32) float32
+
+// Animation - Tween or timeline, played on its own or added to a timeline. Times are in seconds
+type Animation interface {
+	// Update - Advances the animation by dt seconds in its playing direction, returns false once it finished
+	Update(dt float32) bool
+	// Seek - Moves the animation to a time, setting its targets and calling its callbacks
+	Seek(time float32)
+	// Duration - Returns the animation duration including its delay and repeats (+Inf when it repeats forever)
+	Duration() float32
+
+	base() *player
+}
+
+// animator - Animation driven by a player
+type animator interface {
+	// span - Returns the duration of a single iteration
+	span() float32
+	// begin - Prepares the animation when it starts playing
+	begin()
+	// render - Sets the animation state at a time inside an iteration
+	render(time float32)
+	// reset - Forgets the animation started so it starts again
+	reset()
+}
+
+// player - Playback state shared by tweens and timelines
+type player struct {
+	// Animation driven by the player
+	animator animator
+	// Time waited before the first iteration
+	delay float32
+	// Iterations played after the first one (-1 repeats forever)
+	repeat int
+	// Odd iterations play backwards
+	yoyo bool
+	// Update moves the time backwards
+	reversed bool
+	// Update does not move the time
+	paused bool
+	// Current time, from 0 to the duration
+	time float32
+	// Time went past the delay and the start callback was called
+	started bool
+	// Time reached the end in the playing direction and the complete callback was called
+	completed bool
+	// Callbacks called when the animation starts, every time it changes and when it completes
+	onStart    func()
+	onUpdate   func()
+	onComplete func()
+}
+
+// Tween - Animates a value from the one it has when the tween first starts to a target value with an easing function
+type Tween struct {
+	player
+	// Duration of a single iteration
+	duration float32
+	// Easing function applied to the progress
+	ease EaseFunc
+	// Stores the animated value when the tween starts
+	capture func()
+	// Sets the animated value at an eased progress, 0 at the start value and 1 at the target value
+	apply func(progress float32)
+	// Start value already stored, restarted tweens keep it
+	captured bool
+}
+
+// NewTween - Creates a tween animating a float value to a target value over duration seconds (linear when ease is
+// nil)
+func NewTween(target *float32, to float32, duration float32, ease EaseFunc) *Tween {
+	return newTween(target, to, duration, ease, func(from, to float32, amount float32) float32 {
+		return from + (to-from)*amount
+	})
+}
+
+// NewTweenVector2 - Creates a tween animating a vector to a target vector over duration seconds (linear when ease is
+// nil)
+func NewTweenVector2(target *rl.Vector2, to rl.Vector2, duration float32, ease EaseFunc) *Tween {
+	return newTween(target, to, duration, ease, rl.Vector2Lerp)
+}
+
+// NewTweenVector3 - Creates a tween animating a vector to a target vector over duration seconds (linear when ease is
+// nil)
+func NewTweenVector3(target *rl.Vector3, to rl.Vector3, duration float32, ease EaseFunc) *Tween {
+	return newTween(target, to, duration, ease, rl.Vector3Lerp)
+}
+
+// NewTweenColor - Creates a tween animating a color to a target color over duration seconds, channels are clamped
+// when the easing overshoots (linear when ease is nil)
+func NewTweenColor(target *rl.Color, to rl.Color, duration float32, ease EaseFunc) *Tween {
+	return newTween(target, to, duration, ease, func(from, to rl.Color, amount float32) rl.Color {
+		return rl.NewColor(
+			lerpChannel(from.R, to.R, amount),
+			lerpChannel(from.G, to.G, amount),
+			lerpChannel(from.B, to.B, amount),
+			lerpChannel(from.A, to.A, amount),
+		)
+	})
+}
+
+// newTween - Creates a tween animating any value type with an interpolation function
+func newTween[T any](target *T, to T, duration float32, ease EaseFunc, lerp func(from, to T, amount float32) T) *Tween {
+	if ease == nil {
+		ease = LinearNone
+	}
+
+	var from T
+	t := &Tween{
+		duration: max(duration, 0),
+		ease:     ease,
+		capture:  func() { from = *target },
+		apply:    func(progress float32) { *target = lerp(from, to, progress) },
+	}
+	t.animator = t
+	return t
+}
+
+// lerpChannel - Interpolates a color channel, clamping it to its range
+func lerpChannel(from, to uint8, amount float32) uint8 {
+	value := float32(from) + (float32(to)-float32(from))*amount
+	return uint8(min(max(float32(math.Round(float64(value))), 0), 255))
+}
+
+// SetDelay - Sets the time waited before the tween first iteration
+func (t *Tween) SetDelay(seconds float32) *Tween {
+	t.delay = max(seconds, 0)
+	return t
+}
+
+// SetRepeat - Sets the iterations played after the first one (-1 repeats forever)
+func (t *Tween) SetRepeat(count int) *Tween {
+	t.repeat = max(count, -1)
+	return t
+}
+
+// SetYoyo - Sets if odd iterations play backwards, going back to the start value
+func (t *Tween) SetYoyo(yoyo bool) *Tween {
+	t.yoyo = yoyo
+	return t
+}
+
+// OnStart - Sets the callback called when the tween starts, after its delay
+func (t *Tween) OnStart(fn func()) *Tween {
+	t.onStart = fn
+	return t
+}
+
+// OnUpdate - Sets the callback called every time the tween sets its value
+func (t *Tween) OnUpdate(fn func()) *Tween {
+	t.onUpdate = fn
+	return t
+}
+
+// OnComplete - Sets the callback called when the tween reaches its end in its playing direction
+func (t *Tween) OnComplete(fn func()) *Tween {
+	t.onComplete = fn
+	return t
+}
+
+func (t *Tween) span() float32 {
+	return t.duration
+}
+
+func (t *Tween) begin() {
+	if !t.captured {
+		t.capture()
+		t.captured = true
+	}
+}
+
+func (t *Tween) render(time float32) {
+	progress := float32(1)
+	if t.duration > 0 {
+		progress = t.ease(time, 0, 1, t.duration)
+	}
+	t.apply(progress)
+}
+
+func (t *Tween) reset() {}
+
+// Update - Advances the animation by dt seconds in its playing direction, returns false once it finished
+func (p *player) Update(dt float32) bool {
+	// Animations without duration start and end on their first update
+	if !p.paused && (!p.IsFinished() || !p.started) {
+		if p.reversed {
+			dt = -dt
+		}
+		p.Seek(p.time + dt)
+	}
+	return !p.IsFinished()
+}
+
+// UpdateFrame - Advances the animation by the last frame time, returns false once it finished
+func (p *player) UpdateFrame() bool {
+	return p.Update(rl.GetFrameTime())
+}
+
+// Seek - Moves the animation to a time, setting its targets and calling its callbacks
+func (p *player) Seek(time float32) {
+	previous := p.time
+	p.time = min(max(time, 0), p.Duration())
+
+	if p.time < p.delay {
+		// Going back into the delay shows the start of the first iteration
+		if p.started {
+			p.renderBoundaries(previous-p.delay, 0)
+			p.render(0)
+		}
+	} else {
+		if !p.started {
+			p.started = true
+			p.animator.begin()
+			if p.onStart != nil {
+				p.onStart()
+			}
+		}
+		p.renderBoundaries(previous-p.delay, p.time-p.delay)
+		p.render(p.iterationTime(p.time - p.delay))
+	}
+
+	if !p.IsFinished() || !p.started {
+		p.completed = false
+	} else if !p.completed {
+		p.completed = true
+		if p.onComplete != nil {
+			p.onComplete()
+		}
+	}
+}
+
+// Duration - Returns the animation duration including its delay and repeats (+Inf when it repeats forever)
+func (p *player) Duration() float32 {
+	if p.repeat < 0 {
+		return float32(math.Inf(1))
+	}
+	return p.delay + p.animator.span()*float32(p.repeat+1)
+}
+
+// GetTime - Returns the animation current time
+func (p *player) GetTime() float32 {
+	return p.time
+}
+
+// Pause - Stops the animation time until it is resumed
+func (p *player) Pause() {
+	p.paused = true
+}
+
+// Resume - Resumes a paused animation
+func (p *player) Resume() {
+	p.paused = false
+}
+
+// IsPaused - Checks if the animation is paused
+func (p *player) IsPaused() bool {
+	return p.paused
+}
+
+// Reverse - Switches the animation playing direction, reversed animations play from their current time back to
+// their start. Restart plays them from their end, or from the end of their current iteration when they repeat forever
+func (p *player) Reverse() {
+	p.reversed = !p.reversed
+	p.completed = false
+}
+
+// IsReversed - Checks if the animation plays backwards
+func (p *player) IsReversed() bool {
+	return p.reversed
+}
+
+// IsFinished - Checks if the animation reached its end in its playing direction
+func (p *player) IsFinished() bool {
+	if p.reversed {
+		return p.time <= 0
+	}
+	return p.time >= p.Duration()
+}
+
+// Restart - Moves the animation back to its start in its playing direction, its callbacks are called again
+func (p *player) Restart() {
+	start := float32(0)
+	if p.reversed {
+		start = p.end()
+	}
+
+	if p.started {
+		p.Seek(start)
+	} else {
+		p.time = start
+	}
+	p.restart()
+}
+
+// end - Returns the time reversed animations restart from, the end of the current iteration when they repeat forever
+func (p *player) end() float32 {
+	duration := p.Duration()
+	if !math.IsInf(float64(duration), 1) {
+		return duration
+	}
+
+	span := p.animator.span()
+	if span <= 0 || math.IsInf(float64(span), 1) {
+		return min(max(p.time, p.delay), math.MaxFloat32)
+	}
+	iterations := max(float32(math.Ceil(float64((p.time-p.delay)/span))), 1)
+	return p.delay + iterations*span
+}
+
+func (p *player) base() *player {
+	return p
+}
+
+// restart - Forgets the animation started and completed
+func (p *player) restart() {
+	p.started = false
+	p.completed = false
+	p.animator.reset()
+}
+
+// render - Sets the animation state at a time inside an iteration and calls the update callback
+func (p *player) render(time float32) {
+	p.animator.render(time)
+	if p.onUpdate != nil {
+		p.onUpdate()
+	}
+}
+
+// renderBoundaries - Renders the iteration boundaries passed going from a time elapsed since the delay to another, so
+// the animation shows the end of every iteration it leaves. Going forwards each passed iteration ends where it stops,
+// at its span or at its start for yoyo odd iterations, and going backwards where the next one starts
+func (p *player) renderBoundaries(from, to float32) {
+	span := p.animator.span()
+	if span <= 0 || math.IsInf(float64(span), 1) {
+		return
+	}
+	from = max(from, 0)
+
+	if to > from {
+		for n := math.Floor(float64(from/span)) + 1; float32(n)*span <= to; n++ {
+			if p.repeat >= 0 && n > float64(p.repeat) {
+				break
+			}
+			if p.yoyo && int(n-1)%2 == 1 {
+				p.render(0)
+			} else {
+				p.render(span)
+			}
+		}
+	} else {
+		for n := math.Ceil(float64(from/span)) - 1; n >= 1 && float32(n)*span > to; n-- {
+			if p.yoyo && int(n)%2 == 1 {
+				p.render(span)
+			} else {
+				p.render(0)
+			}
+		}
+	}
+}
+
+// iterationTime - Returns the time inside the current iteration for a time elapsed since the delay, odd iterations
+// go backwards when playing yoyo
+func (p *player) iterationTime(elapsed float32) float32 {
+	span := p.animator.span()
+	if span <= 0 {
+		return 0
+	}
+	if math.IsInf(float64(span), 1) {
+		// Timelines with animations repeating forever never end their first iteration
+		return elapsed
+	}
+
+	iteration := float32(math.Floor(float64(elapsed / span)))
+	time := elapsed - iteration*span
+	if p.repeat >= 0 && iteration > float32(p.repeat) {
+		iteration, time = float32(p.repeat), span
+	}
+
+	if p.yoyo && int(iteration)%2 == 1 {
+		time = span - time
+	}
+	return time
+}
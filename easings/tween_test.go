@@ -0,0 +1,251 @@
+package easings
+
+import (
+	"math"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestTween(t *testing.T) {
+	value := float32(2)
+	var calls []string
+	tween := NewTween(&value, 12, 1, nil).
+		OnStart(func() { calls = append(calls, "start") }).
+		OnUpdate(func() { calls = append(calls, "update") }).
+		OnComplete(func() { calls = append(calls, "complete") })
+
+	if !tween.Update(0.5) || !nearlyEqual(value, 7) {
+		t.Fatalf("half way value is %v", value)
+	}
+	if tween.Update(0.75) || value != 12 || !tween.IsFinished() {
+		t.Fatalf("finished value is %v", value)
+	}
+	if tween.Update(1) || len(calls) != 4 || calls[0] != "start" || calls[3] != "complete" {
+		t.Errorf("callbacks called %v", calls)
+	}
+
+	// Easing functions shape the progress
+	value = 0
+	NewTween(&value, 100, 2, QuadIn).Seek(1)
+	if !nearlyEqual(value, 25) {
+		t.Errorf("quad in value is %v half way", value)
+	}
+
+	// Tweens without duration jump to their end value on their first update
+	value = 0
+	calls = nil
+	instant := NewTween(&value, 5, 0, nil).
+		OnStart(func() { calls = append(calls, "start") }).
+		OnComplete(func() { calls = append(calls, "complete") })
+	if instant.Update(0.1) || value != 5 || len(calls) != 2 || calls[0] != "start" || calls[1] != "complete" {
+		t.Errorf("tween without duration set %v and called %v", value, calls)
+	}
+}
+
+func TestTweenDelay(t *testing.T) {
+	value := float32(0)
+	started := false
+	tween := NewTween(&value, 10, 1, nil).SetDelay(0.5).OnStart(func() { started = true })
+
+	tween.Update(0.25)
+	value = 4
+	if started || tween.Duration() != 1.5 {
+		t.Fatalf("tween started during its delay, duration %v", tween.Duration())
+	}
+
+	// The start value is the one the target has when the delay ends
+	tween.Update(0.75)
+	if !started || !nearlyEqual(value, 7) {
+		t.Errorf("value is %v after the delay", value)
+	}
+}
+
+func TestTweenRepeat(t *testing.T) {
+	value := float32(0)
+	completed := 0
+	tween := NewTween(&value, 10, 1, nil).SetRepeat(2).SetYoyo(true).OnComplete(func() { completed++ })
+	if tween.Duration() != 3 {
+		t.Fatalf("duration is %v", tween.Duration())
+	}
+
+	for _, step := range []struct{ time, value float32 }{{0.25, 2.5}, {1, 10}, {1.25, 7.5}, {2, 0}, {2.5, 5}, {3, 10}} {
+		tween.Seek(step.time)
+		if !nearlyEqual(value, step.value) {
+			t.Errorf("value is %v at %v, expected %v", value, step.time, step.value)
+		}
+	}
+	if completed != 1 {
+		t.Errorf("completed %d times", completed)
+	}
+
+	forever := NewTween(&value, 0, 1, nil).SetRepeat(-1)
+	for i := 0; i < 100; i++ {
+		if !forever.Update(0.5) {
+			t.Fatal("tween repeating forever finished")
+		}
+	}
+}
+
+func TestTweenControls(t *testing.T) {
+	value := float32(0)
+	completed := 0
+	tween := NewTween(&value, 10, 1, nil).OnComplete(func() { completed++ })
+
+	tween.Update(0.5)
+	tween.Pause()
+	tween.Update(0.25)
+	if !tween.IsPaused() || !nearlyEqual(value, 5) {
+		t.Fatalf("paused tween moved to %v", value)
+	}
+	tween.Resume()
+
+	// Reversed tweens go back to their start value and complete there
+	tween.Reverse()
+	tween.Update(0.25)
+	if !tween.IsReversed() || !nearlyEqual(value, 2.5) {
+		t.Fatalf("reversed tween moved to %v", value)
+	}
+	if tween.Update(1) || value != 0 || completed != 1 {
+		t.Fatalf("reversed tween finished at %v and completed %d times", value, completed)
+	}
+
+	// Restarting reversed tweens plays them from their end with the same start value
+	tween.Restart()
+	tween.Update(0.25)
+	if !nearlyEqual(value, 7.5) || completed != 1 {
+		t.Errorf("restarted reversed tween moved to %v", value)
+	}
+
+	// Reversed tweens repeating forever restart from the end of their current iteration
+	value = 0
+	forever := NewTween(&value, 10, 1, nil).SetRepeat(-1)
+	forever.Update(1.5)
+	forever.Reverse()
+	forever.Restart()
+	forever.Update(0.25)
+	if !nearlyEqual(value, 7.5) || !nearlyEqual(forever.GetTime(), 1.75) {
+		t.Errorf("restarted reversed tween repeating forever moved to %v at %v", value, forever.GetTime())
+	}
+}
+
+func TestTweenTypes(t *testing.T) {
+	position := rl.NewVector2(0, 10)
+	NewTweenVector2(&position, rl.NewVector2(10, 0), 1, nil).Seek(0.5)
+	if position != rl.NewVector2(5, 5) {
+		t.Errorf("vector2 is %v half way", position)
+	}
+
+	point := rl.NewVector3(0, 0, 0)
+	NewTweenVector3(&point, rl.NewVector3(2, 4, 6), 1, nil).Seek(0.5)
+	if point != rl.NewVector3(1, 2, 3) {
+		t.Errorf("vector3 is %v half way", point)
+	}
+
+	// Overshooting easings keep color channels in range
+	color := rl.NewColor(250, 0, 0, 255)
+	tween := NewTweenColor(&color, rl.NewColor(255, 100, 0, 0), 1, BackOut)
+	for time := float32(0); time <= 1; time += 0.05 {
+		tween.Seek(time)
+		if color.R < 250 {
+			t.Fatalf("color %v wrapped around at %v", color, time)
+		}
+	}
+	if color != rl.NewColor(255, 100, 0, 0) {
+		t.Errorf("color is %v at the end", color)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	value := float32(0)
+	var calls []string
+	first := NewTween(&value, 10, 1, nil).OnComplete(func() { calls = append(calls, "first") })
+	second := NewTween(&value, 20, 1, nil).SetDelay(0.5).OnStart(func() { calls = append(calls, "second") })
+	sequence := NewSequence(first, second).OnComplete(func() { calls = append(calls, "sequence") })
+	if sequence.Duration() != 2.5 {
+		t.Fatalf("sequence duration is %v", sequence.Duration())
+	}
+
+	for _, step := range []struct{ time, value float32 }{{0.5, 5}, {1.25, 10}, {2, 15}, {2.5, 20}, {0.5, 5}, {2, 15}, {0, 0}} {
+		sequence.Seek(step.time)
+		if !nearlyEqual(value, step.value) {
+			t.Errorf("value is %v at %v, expected %v", value, step.time, step.value)
+		}
+	}
+	// Seeking back before the end of the first tween and past it again completes it again
+	if len(calls) != 4 || calls[0] != "first" || calls[1] != "second" || calls[2] != "sequence" || calls[3] != "first" {
+		t.Errorf("callbacks called %v", calls)
+	}
+
+	// Parallel groups play together, nested in sequences
+	position := rl.Vector2{}
+	color := rl.Black
+	scale := float32(1)
+	parallel := NewParallel(
+		NewTweenVector2(&position, rl.NewVector2(100, 0), 2, nil),
+		NewTweenColor(&color, rl.White, 1, nil),
+	)
+	timeline := NewSequence(parallel, NewTween(&scale, 2, 1, nil)).SetRepeat(1).SetYoyo(true)
+	if timeline.Duration() != 6 {
+		t.Fatalf("timeline duration is %v", timeline.Duration())
+	}
+
+	for i := 0; i < 10; i++ {
+		timeline.Update(0.1)
+	}
+	if !nearlyEqual(position.X, 50) || color != rl.White || scale != 1 {
+		t.Errorf("after a second the position is %v, color %v and scale %v", position, color, scale)
+	}
+	for i := 0; i < 20; i++ {
+		timeline.Update(0.1)
+	}
+	if !nearlyEqual(position.X, 100) || !nearlyEqual(scale, 2) {
+		t.Errorf("after the first iteration the position is %v and scale %v", position, scale)
+	}
+	for timeline.Update(0.1) {
+	}
+	if position.X != 0 || color != rl.Black || scale != 1 {
+		t.Errorf("yoyo timeline ended with position %v, color %v and scale %v", position, color, scale)
+	}
+
+	// Frames passing iteration ends show the animations at those ends
+	value = 0
+	scale = 0
+	seen := float32(0)
+	completed := 0
+	a := NewTween(&value, 1, 0.5, nil)
+	b := NewTween(&scale, 1, 0.5, nil).OnUpdate(func() { seen = max(seen, scale) }).OnComplete(func() { completed++ })
+	repeated := NewSequence(a, b).SetRepeat(1)
+	for i := 0; i < 5; i++ {
+		repeated.Update(0.4)
+	}
+	if completed != 2 || seen != 1 || scale != 1 {
+		t.Errorf("repeated sequence completed its last tween %d times, reaching %v and ending at %v", completed, seen, scale)
+	}
+	completed = 0
+	bounced := NewSequence(NewTween(&value, 1, 0.5, nil), b).SetRepeat(2).SetYoyo(true)
+	for bounced.Update(0.7) {
+	}
+	if completed != 2 || scale != 1 {
+		t.Errorf("yoyo sequence completed its last tween %d times, ending at %v", completed, scale)
+	}
+
+	// Animations repeating forever keep playing in timelines
+	value = 0
+	loop := NewSequence(NewTween(&value, 10, 1, nil).SetRepeat(-1))
+	loop.Update(0.5)
+	if !nearlyEqual(value, 5) || !loop.Update(1) || !nearlyEqual(value, 5) {
+		t.Errorf("looping tween in a sequence moved to %v", value)
+	}
+	value = 0
+	scale = 0
+	group := NewParallel(NewTween(&value, 10, 1, nil).SetRepeat(-1), NewTween(&scale, 2, 1, nil))
+	group.Update(2.25)
+	if !nearlyEqual(value, 2.5) || scale != 2 || !group.Update(0.1) {
+		t.Errorf("looping tween in a parallel group moved to %v and %v", value, scale)
+	}
+}
+
+func nearlyEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-4
+}
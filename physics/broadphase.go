@@ -0,0 +1,283 @@
+package physics
+
+import (
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BroadphaseType type
+type BroadphaseType int
+
+// Broadphase types used to generate collision pairs
+const (
+	// Tests every body against every other body
+	BruteForceBroadphase BroadphaseType = iota
+	// Sorts bodies bounds along the x axis and only tests overlapping intervals
+	SweepAndPruneBroadphase
+	// Buckets bodies bounds into a uniform grid and only tests bodies sharing a cell
+	GridBroadphase
+)
+
+// Uniform grid constants
+const (
+	// Default uniform grid cell size
+	defaultGridCellSize = 64
+	// Most cells a single body bounds may cover, larger bounds are tested against every body
+	maxGridBodyCells = 1024
+	// Largest absolute cell coordinate, bounds further away are tested against every body
+	maxGridCoordinate = 1 << 24
+)
+
+// AABB type
+type AABB struct {
+	// Minimum corner position
+	Min rl.Vector2
+	// Maximum corner position
+	Max rl.Vector2
+}
+
+// Overlaps - Checks if two bounding boxes overlap
+func (a AABB) Overlaps(b AABB) bool {
+	return a.Min.X <= b.Max.X && a.Max.X >= b.Min.X && a.Min.Y <= b.Max.Y && a.Max.Y >= b.Min.Y
+}
+
+// Contains - Checks if a point is inside the bounding box
+func (a AABB) Contains(point rl.Vector2) bool {
+	return point.X >= a.Min.X && point.X <= a.Max.X && point.Y >= a.Min.Y && point.Y <= a.Max.Y
+}
+
+// GetAABB - Returns the world space axis aligned bounding box of a physics body shapes
+func (b *Body) GetAABB() AABB {
+	fixtures := b.GetFixtures()
+	box := fixtures[0].GetAABB()
+	for _, shape := range fixtures[1:] {
+		other := shape.GetAABB()
+		box.Min.X, box.Min.Y = min(box.Min.X, other.Min.X), min(box.Min.Y, other.Min.Y)
+		box.Max.X, box.Max.Y = max(box.Max.X, other.Max.X), max(box.Max.Y, other.Max.Y)
+	}
+	return box
+}
+
+// GetAABB - Returns the world space axis aligned bounding box of a shape
+func (s *Shape) GetAABB() AABB {
+	switch s.Type {
+	case CircleShape:
+		center := s.GetPosition()
+		return AABB{
+			Min: rl.NewVector2(center.X-s.Radius, center.Y-s.Radius),
+			Max: rl.NewVector2(center.X+s.Radius, center.Y+s.Radius),
+		}
+	default:
+		box := AABB{
+			Min: rl.NewVector2(math.MaxFloat32, math.MaxFloat32),
+			Max: rl.NewVector2(-math.MaxFloat32, -math.MaxFloat32),
+		}
+		for i := 0; i < s.VertexData.VertexCount; i++ {
+			vertex := s.GetVertex(i)
+			box.Min.X = min(box.Min.X, vertex.X)
+			box.Min.Y = min(box.Min.Y, vertex.Y)
+			box.Max.X = max(box.Max.X, vertex.X)
+			box.Max.Y = max(box.Max.Y, vertex.Y)
+		}
+		return box
+	}
+}
+
+// SetBroadphase - Sets the algorithm used to find potentially colliding bodies pairs
+func SetBroadphase(broadphaseType BroadphaseType) {
+	defaultWorld.SetBroadphase(broadphaseType)
+}
+
+// SetBroadphase - Sets the algorithm used to find potentially colliding bodies pairs
+func (w *World) SetBroadphase(broadphaseType BroadphaseType) {
+	switch broadphaseType {
+	case BruteForceBroadphase:
+		w.broadphase = &bruteForce{}
+	case GridBroadphase:
+		w.broadphase = &uniformGrid{cellSize: defaultGridCellSize}
+	default:
+		w.broadphase = &sweepAndPrune{}
+	}
+}
+
+// SetGridCellSize - Sets the cell size used by the uniform grid broadphase, ignored by other broadphases
+func (w *World) SetGridCellSize(size float32) {
+	if grid, ok := w.broadphase.(*uniformGrid); ok && size > 0 {
+		grid.cellSize = size
+	}
+}
+
+// bodyPair - Pair of indices into the world bodies slice, always with a < b
+type bodyPair struct {
+	a, b int
+}
+
+// broadphase - Finds pairs of bodies that may be colliding
+type broadphase interface {
+	// findPairs - Appends potentially colliding pairs of bodies given their bounds, in a deterministic order
+	findPairs(bounds []AABB, pairs []bodyPair) []bodyPair
+}
+
+// bruteForce - Broadphase that returns every possible pair
+type bruteForce struct{}
+
+func (bf *bruteForce) findPairs(bounds []AABB, pairs []bodyPair) []bodyPair {
+	for i := 0; i < len(bounds); i++ {
+		for j := i + 1; j < len(bounds); j++ {
+			pairs = append(pairs, bodyPair{i, j})
+		}
+	}
+	return pairs
+}
+
+// sweepAndPrune - Broadphase that sorts bounds along the x axis
+type sweepAndPrune struct {
+	// Body indices sorted by bounds minimum x
+	order []int
+}
+
+func (sap *sweepAndPrune) findPairs(bounds []AABB, pairs []bodyPair) []bodyPair {
+	sap.order = sap.order[:0]
+	for i := range bounds {
+		sap.order = append(sap.order, i)
+	}
+
+	// Ties are broken by index so the resulting order does not depend on the sort algorithm
+	sort.Slice(sap.order, func(i, j int) bool {
+		a, b := sap.order[i], sap.order[j]
+		if bounds[a].Min.X != bounds[b].Min.X {
+			return bounds[a].Min.X < bounds[b].Min.X
+		}
+		return a < b
+	})
+
+	for i := 0; i < len(sap.order); i++ {
+		a := sap.order[i]
+		for j := i + 1; j < len(sap.order); j++ {
+			b := sap.order[j]
+
+			// Remaining bodies start after the current one ends
+			if bounds[b].Min.X > bounds[a].Max.X {
+				break
+			}
+
+			if bounds[a].Overlaps(bounds[b]) {
+				pairs = append(pairs, newBodyPair(a, b))
+			}
+		}
+	}
+	return pairs
+}
+
+// gridCell - Uniform grid cell coordinates
+type gridCell struct {
+	x, y int
+}
+
+// uniformGrid - Broadphase that buckets bounds into fixed size cells. Huge, far away or invalid bounds would cover
+// too many cells, they are kept in an overflow list and tested against every body instead
+type uniformGrid struct {
+	// Size of each square cell
+	cellSize float32
+	// Body indices stored in each cell
+	cells map[gridCell][]int
+	// Bodies left out of the grid, by index
+	overflow []bool
+}
+
+// cellRange - Returns the minimum and maximum cells covered by a bounding box, false when the box does not fit the
+// grid (too large, too far away, infinite or NaN)
+func (g *uniformGrid) cellRange(box AABB) (gridCell, gridCell, bool) {
+	size := float64(g.cellSize)
+	minX, minY := math.Floor(float64(box.Min.X)/size), math.Floor(float64(box.Min.Y)/size)
+	maxX, maxY := math.Floor(float64(box.Max.X)/size), math.Floor(float64(box.Max.Y)/size)
+
+	// Written so NaN coordinates fail every check
+	inside := minX >= -maxGridCoordinate && minY >= -maxGridCoordinate && maxX <= maxGridCoordinate && maxY <= maxGridCoordinate
+	if !inside || !(maxX >= minX && maxY >= minY) || (maxX-minX+1)*(maxY-minY+1) > maxGridBodyCells {
+		return gridCell{}, gridCell{}, false
+	}
+	return gridCell{int(minX), int(minY)}, gridCell{int(maxX), int(maxY)}, true
+}
+
+func (g *uniformGrid) findPairs(bounds []AABB, pairs []bodyPair) []bodyPair {
+	if g.cells == nil {
+		g.cells = make(map[gridCell][]int)
+	}
+	for cell, indices := range g.cells {
+		if len(indices) == 0 {
+			delete(g.cells, cell)
+			continue
+		}
+		g.cells[cell] = indices[:0]
+	}
+
+	g.overflow = g.overflow[:0]
+	overflowing := false
+	for i, box := range bounds {
+		minCell, maxCell, ok := g.cellRange(box)
+		g.overflow = append(g.overflow, !ok)
+		if !ok {
+			overflowing = true
+			continue
+		}
+
+		for x := minCell.x; x <= maxCell.x; x++ {
+			for y := minCell.y; y <= maxCell.y; y++ {
+				cell := gridCell{x, y}
+				g.cells[cell] = append(g.cells[cell], i)
+			}
+		}
+	}
+
+	// Visit bodies in index order so the pairs order is deterministic
+	for a, box := range bounds {
+		if g.overflow[a] {
+			for b := a + 1; b < len(bounds); b++ {
+				if box.Overlaps(bounds[b]) {
+					pairs = append(pairs, bodyPair{a, b})
+				}
+			}
+			continue
+		}
+
+		minCell, maxCell, _ := g.cellRange(box)
+		for x := minCell.x; x <= maxCell.x; x++ {
+			for y := minCell.y; y <= maxCell.y; y++ {
+				for _, b := range g.cells[gridCell{x, y}] {
+					if b <= a || !box.Overlaps(bounds[b]) {
+						continue
+					}
+
+					// Only report the pair from the first cell both bodies share
+					otherMin, _, _ := g.cellRange(bounds[b])
+					if x != max(minCell.x, otherMin.x) || y != max(minCell.y, otherMin.y) {
+						continue
+					}
+
+					pairs = append(pairs, bodyPair{a, b})
+				}
+			}
+		}
+
+		// Bodies left out of the grid are not in any cell
+		if overflowing {
+			for b := a + 1; b < len(bounds); b++ {
+				if g.overflow[b] && box.Overlaps(bounds[b]) {
+					pairs = append(pairs, bodyPair{a, b})
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// newBodyPair - Returns a body pair with the lowest index first
+func newBodyPair(a, b int) bodyPair {
+	if a > b {
+		a, b = b, a
+	}
+	return bodyPair{a, b}
+}
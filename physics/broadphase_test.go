@@ -0,0 +1,129 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var broadphaseTypes = []struct {
+	name string
+	typ  BroadphaseType
+}{
+	{"BruteForce", BruteForceBroadphase},
+	{"SweepAndPrune", SweepAndPruneBroadphase},
+	{"Grid", GridBroadphase},
+}
+
+// newScatteredWorld - Creates a world with count bodies randomly scattered over a square area
+func newScatteredWorld(count int, broadphaseType BroadphaseType) *World {
+	w := NewWorld()
+	w.SetBroadphase(broadphaseType)
+	w.SetGravity(0, 0)
+
+	rnd := rand.New(rand.NewSource(1))
+	size := float32(count) * 8
+	for i := 0; i < count; i++ {
+		pos := rl.NewVector2(rnd.Float32()*size, rnd.Float32()*size)
+		if i%2 == 0 {
+			w.NewBodyCircle(pos, 5+rnd.Float32()*10, 1)
+		} else {
+			w.NewBodyRectangle(pos, 10+rnd.Float32()*20, 10+rnd.Float32()*20, 1)
+		}
+	}
+	return w
+}
+
+func TestBroadphasesFindOverlappingPairs(t *testing.T) {
+	reference := newScatteredWorld(300, BruteForceBroadphase)
+	var bounds []AABB
+	for _, body := range reference.GetBodies() {
+		bounds = append(bounds, body.GetAABB())
+	}
+
+	expected := map[bodyPair]bool{}
+	for _, pair := range (&bruteForce{}).findPairs(bounds, nil) {
+		if bounds[pair.a].Overlaps(bounds[pair.b]) {
+			expected[pair] = true
+		}
+	}
+	if len(expected) == 0 {
+		t.Fatal("scattered world has no overlapping pairs")
+	}
+
+	for _, bp := range broadphaseTypes[1:] {
+		w := NewWorld()
+		w.SetBroadphase(bp.typ)
+
+		found := map[bodyPair]bool{}
+		for _, pair := range w.broadphase.findPairs(bounds, nil) {
+			if pair.a >= pair.b {
+				t.Errorf("%s: pair %v is not ordered", bp.name, pair)
+			}
+			if found[pair] {
+				t.Errorf("%s: pair %v reported twice", bp.name, pair)
+			}
+			found[pair] = true
+		}
+
+		for pair := range expected {
+			if !found[pair] {
+				t.Errorf("%s: missing overlapping pair %v", bp.name, pair)
+			}
+		}
+		for pair := range found {
+			if !expected[pair] {
+				t.Errorf("%s: reported non overlapping pair %v", bp.name, pair)
+			}
+		}
+	}
+}
+
+func TestGridBroadphaseOverflow(t *testing.T) {
+	inf := float32(math.Inf(1))
+	nan := float32(math.NaN())
+	bounds := []AABB{
+		{Min: rl.NewVector2(0, 0), Max: rl.NewVector2(10, 10)},
+		{Min: rl.NewVector2(-1e30, -1e30), Max: rl.NewVector2(1e30, 1e30)},
+		{Min: rl.NewVector2(5, 5), Max: rl.NewVector2(20, 20)},
+		{Min: rl.NewVector2(-inf, 0), Max: rl.NewVector2(inf, 1)},
+		{Min: rl.NewVector2(nan, nan), Max: rl.NewVector2(nan, nan)},
+		{Min: rl.NewVector2(1e12, 1e12), Max: rl.NewVector2(1e12+10, 1e12+10)},
+		{Min: rl.NewVector2(1e12+5, 1e12+5), Max: rl.NewVector2(1e12+20, 1e12+20)},
+	}
+
+	// Huge, infinite, NaN and far away bounds are tested against every body instead of filling the grid
+	grid := &uniformGrid{cellSize: defaultGridCellSize}
+	found := map[bodyPair]bool{}
+	for _, pair := range grid.findPairs(bounds, nil) {
+		if found[pair] {
+			t.Errorf("pair %v reported twice", pair)
+		}
+		found[pair] = true
+	}
+	for _, pair := range (&bruteForce{}).findPairs(bounds, nil) {
+		if overlaps := bounds[pair.a].Overlaps(bounds[pair.b]); overlaps != found[pair] {
+			t.Errorf("pair %v overlaps %v, reported %v", pair, overlaps, found[pair])
+		}
+	}
+	if len(grid.cells) > 2 {
+		t.Errorf("grid stored %d cells for two small bodies", len(grid.cells))
+	}
+}
+
+func BenchmarkBroadphase(b *testing.B) {
+	for _, count := range []int{64, 256, 1024} {
+		for _, bp := range broadphaseTypes {
+			b.Run(fmt.Sprintf("%s/%d", bp.name, count), func(b *testing.B) {
+				w := newScatteredWorld(count, bp.typ)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					w.step(w.deltaTime)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package physics
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Distance bullets are moved into the surface they hit, so the next step generates a contact and solves it
+const bulletSlop = penetrationAllowance
+
+// bulletSweep - Bullet body position before integrating its velocity
+type bulletSweep struct {
+	body  *Body
+	start rl.Vector2
+}
+
+// isBullet - Checks if a body needs continuous collision detection
+func (b *Body) isBullet() bool {
+	return b.IsBullet && !b.IsSensor && !b.IsSleeping && b.invMass() != 0
+}
+
+// beginBullets - Stores bullet bodies positions before integrating velocities
+func (w *World) beginBullets() {
+	w.bullets = w.bullets[:0]
+	for _, body := range w.bodies {
+		if body.isBullet() {
+			w.bullets = append(w.bullets, bulletSweep{body, body.Position})
+		}
+	}
+}
+
+// solveBullets - Moves bullet bodies back to the first static or kinematic body their shape hits while moving
+// from their start position, ignoring rotation during the step
+func (w *World) solveBullets() {
+	for _, sweep := range w.bullets {
+		body := sweep.body
+		translation := rl.Vector2Subtract(body.Position, sweep.start)
+		if rl.Vector2LengthSqr(translation) < epsilon {
+			continue
+		}
+
+		// Body bounds at the start position, swept along the translation
+		box := body.GetAABB()
+		box = AABB{Min: rl.Vector2Subtract(box.Min, translation), Max: rl.Vector2Subtract(box.Max, translation)}
+		bounds := sweptAABB(box, translation)
+
+		var closest RaycastHit
+		found := false
+		for _, target := range w.bodies {
+			if target == body || target.IsSensor || target.invMass() != 0 {
+				continue
+			}
+			if !target.GetAABB().Overlaps(bounds) || !w.shouldCollide(body, target) {
+				continue
+			}
+
+			for _, shape := range body.GetFixtures() {
+				start := rl.Vector2Add(sweep.start, rl.Mat2MultiplyVector2(shape.Transform, shape.Offset))
+				caster := newShapeCaster(shape, start)
+
+				for _, targetShape := range target.GetFixtures() {
+					// Only surfaces facing the motion stop the bullet, so it can leave bodies it already touches
+					hit, ok := caster.cast(translation, targetShape)
+					if !ok || rl.Vector2DotProduct(hit.Normal, translation) >= 0 {
+						continue
+					}
+					if !found || hit.Fraction < closest.Fraction {
+						closest = hit
+						found = true
+					}
+				}
+			}
+		}
+
+		if found {
+			position := rl.Vector2Add(sweep.start, rl.Vector2Scale(translation, closest.Fraction))
+			body.Position = rl.Vector2Subtract(position, rl.Vector2Scale(closest.Normal, bulletSlop))
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// CharacterController - Moves a kinematic physics body by sliding its main shape along the world shapes with shape
+// casts. Slopes up to MaxSlopeAngle are walked on and steeper ones block like walls, ledges up to StepHeight are
+// climbed and the body is kept on the ground when walking down slopes and steps
+type CharacterController struct {
+	// Kinematic physics body moved by the controller
+	Body *Body
+	// Up direction, opposite to the world gravity when created
+	Up rl.Vector2
+	// Steepest walkable slope angle, in radians
+	MaxSlopeAngle float32
+	// Tallest ledge climbed while walking, in pixels (0 disables stepping up)
+	StepHeight float32
+	// Greatest distance the body is pulled down to stay on the ground, in pixels (0 disables ground snapping)
+	SnapDistance float32
+	// Gap kept between the body and the surfaces it touches, in pixels
+	SkinWidth float32
+	// Decides which bodies block the character, sensors and dynamic bodies never do
+	Filter QueryFilter
+	// Standing on a walkable surface after the last move
+	IsGrounded bool
+	// Normal of the surface the body stands on (zero when not grounded)
+	GroundNormal rl.Vector2
+	// Physics body the character stands on (nil when not grounded)
+	GroundBody *Body
+	// Velocity of the ground under the character, used to carry it along moving platforms
+	GroundVelocity rl.Vector2
+	// Blocked by a wall or a slope too steep to walk on during the last move
+	IsTouchingWall bool
+	// Blocked by a ceiling during the last move
+	IsTouchingCeiling bool
+}
+
+// Character controller defaults
+const (
+	defaultMaxSlopeAngle = 45 * degToRad
+	defaultStepHeight    = 8
+	defaultSnapDistance  = 8
+	defaultSkinWidth     = 0.1
+
+	// Greatest number of surfaces slid along in a single move
+	maxCharacterSlides = 4
+)
+
+// NewCharacterController - Creates a character controller moving a physics body, the body is made kinematic and
+// its rotation is frozen
+func NewCharacterController(body *Body) *CharacterController {
+	body.SetType(KinematicBody)
+	body.FreezeOrient = true
+	body.AngularVelocity = 0
+
+	up := rl.NewVector2(0, -1)
+	if body.world != nil && rl.Vector2LengthSqr(body.world.gravityForce) > epsilon {
+		up = rl.Vector2Negate(body.world.gravityForce)
+		normalize(&up)
+	}
+
+	return &CharacterController{
+		Body:          body,
+		Up:            up,
+		MaxSlopeAngle: defaultMaxSlopeAngle,
+		StepHeight:    defaultStepHeight,
+		SnapDistance:  defaultSnapDistance,
+		SkinWidth:     defaultSkinWidth,
+	}
+}
+
+// Move - Moves the character with a velocity during the next physics step of dt milliseconds, call it before
+// every step. The body velocity is set so the step moves it to the position found, pushing the dynamic bodies on
+// its way. Returns the velocity left after removing the parts blocked by walls, ceilings and floors
+func (c *CharacterController) Move(velocity rl.Vector2, dt float32) rl.Vector2 {
+	body := c.Body
+	if dt <= 0 || body.world == nil {
+		return velocity
+	}
+
+	start := body.Position
+	position := start
+	wasGrounded, groundNormal, groundBody := c.IsGrounded, c.GroundNormal, c.GroundBody
+
+	// Moving platforms carry the character standing on them
+	if wasGrounded && c.GroundVelocity != (rl.Vector2{}) {
+		position, _, _ = c.sweep(position, rl.Vector2Scale(c.GroundVelocity, dt), groundBody)
+	}
+
+	c.IsGrounded, c.GroundNormal, c.GroundBody, c.GroundVelocity = false, rl.Vector2{}, nil, rl.Vector2{}
+	c.IsTouchingWall, c.IsTouchingCeiling = false, false
+
+	upSpeed := rl.Vector2DotProduct(velocity, c.Up)
+	lateral := rl.Vector2Subtract(velocity, rl.Vector2Scale(c.Up, upSpeed))
+
+	// Walk along the ground so walking down slopes does not leave it
+	if wasGrounded && upSpeed <= 0 {
+		along := rl.Vector2Subtract(lateral, rl.Vector2Scale(groundNormal, rl.Vector2DotProduct(lateral, groundNormal)))
+		if length := rl.Vector2Length(along); length > epsilon {
+			lateral = rl.Vector2Scale(along, rl.Vector2Length(lateral)/length)
+		}
+	}
+
+	position, velocity = c.slide(position, rl.Vector2Scale(lateral, dt), velocity, true, wasGrounded)
+	position, velocity = c.slide(position, rl.Vector2Scale(c.Up, upSpeed*dt), velocity, false, false)
+
+	// Find the ground under the character, pulling it down to the ground it walked off
+	if !c.IsGrounded && rl.Vector2DotProduct(velocity, c.Up) <= 0 {
+		distance := 2 * c.SkinWidth
+		if wasGrounded {
+			distance = max(distance, c.SnapDistance)
+		}
+
+		if landed, hit, ok := c.sweep(position, rl.Vector2Scale(c.Up, -distance), nil); ok && c.isFloor(hit.Normal) {
+			position = landed
+			c.setGround(hit)
+		}
+	}
+
+	body.Velocity = rl.Vector2Scale(rl.Vector2Subtract(position, start), 1/dt)
+	return velocity
+}
+
+// slide - Moves the character by a translation sliding along the surfaces it hits, returning the position reached
+// and the velocity left. Lateral moves treat steep slopes as vertical walls and may step up ledges, vertical moves
+// stop on the ground
+func (c *CharacterController) slide(position, translation, velocity rl.Vector2, lateral, stepUp bool) (rl.Vector2, rl.Vector2) {
+	for i := 0; i < maxCharacterSlides && rl.Vector2LengthSqr(translation) > epsilon; i++ {
+		moved, hit, ok := c.sweep(position, translation, nil)
+		translation = rl.Vector2Subtract(translation, rl.Vector2Subtract(moved, position))
+		position = moved
+		if !ok {
+			break
+		}
+
+		normal := hit.Normal
+		slope := rl.Vector2DotProduct(normal, c.Up)
+		switch {
+		case c.isFloor(normal):
+			c.setGround(hit)
+			if !lateral {
+				// Landing stops the fall
+				if speed := rl.Vector2DotProduct(velocity, c.Up); speed < 0 {
+					velocity = rl.Vector2Subtract(velocity, rl.Vector2Scale(c.Up, speed))
+				}
+				return position, velocity
+			}
+		case slope < -float32(math.Cos(float64(c.MaxSlopeAngle))):
+			c.IsTouchingCeiling = true
+		default:
+			c.IsTouchingWall = true
+			if stepUp && c.StepHeight > 0 {
+				if stepped, left, ok := c.stepUp(position, translation); ok {
+					position, translation = stepped, left
+					continue
+				}
+			}
+
+			// Walking into steep slopes does not climb them
+			if lateral && slope > 0 {
+				normal = rl.Vector2Subtract(normal, rl.Vector2Scale(c.Up, slope))
+				normalize(&normal)
+			}
+		}
+
+		translation = rl.Vector2Subtract(translation, rl.Vector2Scale(normal, rl.Vector2DotProduct(translation, normal)))
+		if speed := rl.Vector2DotProduct(velocity, normal); speed < 0 && !c.isFloor(normal) {
+			velocity = rl.Vector2Subtract(velocity, rl.Vector2Scale(normal, speed))
+		}
+	}
+
+	return rl.Vector2Add(position, translation), velocity
+}
+
+// stepUp - Climbs the ledge blocking a lateral move: rises up to StepHeight, moves forward and drops back onto the
+// ledge top. Returns the position on the ledge and the translation left
+func (c *CharacterController) stepUp(position, translation rl.Vector2) (rl.Vector2, rl.Vector2, bool) {
+	raised, _, _ := c.sweep(position, rl.Vector2Scale(c.Up, c.StepHeight), nil)
+	risen := rl.Vector2DotProduct(rl.Vector2Subtract(raised, position), c.Up)
+	if risen <= c.SkinWidth {
+		return position, translation, false
+	}
+
+	// The ledge is too tall when moving forward is still blocked, short moves probe past the skin
+	length := rl.Vector2Length(translation)
+	forward, _, _ := c.sweep(raised, rl.Vector2Scale(translation, max(length, 2*c.SkinWidth)/length), nil)
+	advanced := rl.Vector2Length(rl.Vector2Subtract(forward, raised))
+	if advanced <= c.SkinWidth {
+		return position, translation, false
+	}
+	advanced = min(advanced, length)
+	forward = rl.Vector2Add(raised, rl.Vector2Scale(translation, advanced/length))
+
+	landed, hit, ok := c.sweep(forward, rl.Vector2Scale(c.Up, -(risen+c.SkinWidth)), nil)
+	if !ok || !c.isFloor(hit.Normal) {
+		return position, translation, false
+	}
+
+	c.setGround(hit)
+	left := rl.Vector2Scale(translation, 1-advanced/length)
+	return landed, left, true
+}
+
+// sweep - Moves the character main shape by a translation until it hits a surface, keeping SkinWidth away from it.
+// Returns the position reached and the surface hit
+func (c *CharacterController) sweep(position, translation rl.Vector2, ignore *Body) (rl.Vector2, RaycastHit, bool) {
+	length := rl.Vector2Length(translation)
+	if length < epsilon {
+		return position, RaycastHit{}, false
+	}
+
+	// Surfaces closer than SkinWidth past the end of the translation are hit too
+	reach := length + c.SkinWidth
+	hit, ok := c.cast(position, rl.Vector2Scale(translation, reach/length), ignore)
+	if !ok {
+		return rl.Vector2Add(position, translation), RaycastHit{}, false
+	}
+
+	travel := min(max(hit.Fraction*reach-c.SkinWidth, 0), length)
+	return rl.Vector2Add(position, rl.Vector2Scale(translation, travel/length)), hit, true
+}
+
+// cast - Returns the first surface hit by the character main shape moved by a translation. Surfaces the shape
+// touches but moves away from do not block it
+func (c *CharacterController) cast(position, translation rl.Vector2, ignore *Body) (RaycastHit, bool) {
+	w := c.Body.world
+	caster := newShapeCaster(&c.Body.Shape, position)
+	sweep := sweptAABB(caster.bounds(), translation)
+
+	var closest RaycastHit
+	found := false
+
+	for _, body := range w.bodies {
+		if body == c.Body || body == ignore || body.Type == DynamicBody || !filterBodies(c.Body, body) {
+			continue
+		}
+		if !w.castCandidate(body, sweep, c.Filter) {
+			continue
+		}
+
+		for _, shape := range body.GetFixtures() {
+			hit, ok := caster.cast(translation, shape)
+			if !ok || rl.Vector2DotProduct(hit.Normal, translation) >= 0 {
+				continue
+			}
+
+			if !found || hit.Fraction < closest.Fraction {
+				hit.Body, hit.Shape = body, shape
+				closest = hit
+				found = true
+			}
+		}
+	}
+
+	return closest, found
+}
+
+// isFloor - Checks if a surface normal is walkable
+func (c *CharacterController) isFloor(normal rl.Vector2) bool {
+	return rl.Vector2DotProduct(normal, c.Up) >= float32(math.Cos(float64(c.MaxSlopeAngle)))-epsilon
+}
+
+// setGround - Stands the character on the surface hit
+func (c *CharacterController) setGround(hit RaycastHit) {
+	c.IsGrounded = true
+	c.GroundNormal = hit.Normal
+	c.GroundBody = hit.Body
+
+	// Ground velocity at the point of contact, including the ground rotation
+	radius := rl.Vector2Subtract(hit.Point, hit.Body.Position)
+	angular := rl.NewVector2(-hit.Body.AngularVelocity*radius.Y, hit.Body.AngularVelocity*radius.X)
+	c.GroundVelocity = rl.Vector2Add(hit.Body.Velocity, angular)
+}
@@ -0,0 +1,246 @@
+package physics
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Contact - Contact information between two touching physics bodies
+type Contact struct {
+	// First physics body reference
+	BodyA *Body
+	// Second physics body reference
+	BodyB *Body
+	// First body shape reference
+	ShapeA *Shape
+	// Second body shape reference
+	ShapeB *Shape
+	// Normal direction vector from 'a' to 'b'
+	Normal rl.Vector2
+	// Depth of penetration from collision
+	Penetration float32
+	// Points of contact during collision
+	Contacts [2]rl.Vector2
+	// Current collision number of contacts
+	ContactsCount int
+	// Normal impulse applied at each point of contact during the last step
+	NormalImpulses [2]float32
+	// Friction impulse applied at each point of contact during the last step
+	TangentImpulses [2]float32
+	// Overlap between a sensor and another body
+	IsSensor bool
+}
+
+// GetOther - Returns the body touching the given one
+func (c Contact) GetOther(body *Body) *Body {
+	if c.BodyA == body {
+		return c.BodyB
+	}
+	return c.BodyA
+}
+
+// ContactListener - Callbacks called when bodies start, keep and stop touching. Nil callbacks are ignored
+type ContactListener struct {
+	// Called on the first step two bodies touch
+	Begin func(contact Contact)
+	// Called on every following step the bodies keep touching
+	Persist func(contact Contact)
+	// Called on the first step the bodies stop touching or when one of them is destroyed
+	End func(contact Contact)
+}
+
+// contactEventType type
+type contactEventType int
+
+// Contact event types
+const (
+	contactBegin contactEventType = iota
+	contactPersist
+	contactEnd
+)
+
+// contactKey - Identifies a pair of bodies regardless of their order
+type contactKey struct {
+	a, b *Body
+}
+
+// contactEvent - Contact event waiting to be dispatched
+type contactEvent struct {
+	kind    contactEventType
+	contact Contact
+}
+
+// SetContactListener - Sets the world callbacks called when bodies start, keep and stop touching
+func SetContactListener(listener ContactListener) {
+	defaultWorld.SetContactListener(listener)
+}
+
+// SetContactListener - Sets the world callbacks called when bodies start, keep and stop touching
+func (w *World) SetContactListener(listener ContactListener) {
+	w.contactListener = listener
+}
+
+// GetContacts - Returns the contacts between touching bodies found in the last step
+func (w *World) GetContacts() []Contact {
+	return w.contacts
+}
+
+// GetContacts - Returns the contacts of the body found in the last step
+func (b *Body) GetContacts() []Contact {
+	if b.world == nil {
+		return nil
+	}
+
+	var contacts []Contact
+	for _, contact := range b.world.contacts {
+		if contact.BodyA == b || contact.BodyB == b {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts
+}
+
+// newContactKey - Returns the key of a bodies pair ordered by their identifiers
+func newContactKey(bodyA, bodyB *Body) contactKey {
+	if bodyA.ID > bodyB.ID {
+		bodyA, bodyB = bodyB, bodyA
+	}
+	return contactKey{bodyA, bodyB}
+}
+
+// newContact - Returns the contact information of a solved manifold
+func newContact(manifold *Manifold) Contact {
+	return Contact{
+		BodyA:           manifold.BodyA,
+		BodyB:           manifold.BodyB,
+		ShapeA:          manifold.ShapeA,
+		ShapeB:          manifold.ShapeB,
+		Normal:          manifold.Normal,
+		Penetration:     manifold.Penetration,
+		Contacts:        manifold.Contacts,
+		ContactsCount:   manifold.ContactsCount,
+		NormalImpulses:  manifold.NormalImpulses,
+		TangentImpulses: manifold.TangentImpulses,
+		IsSensor:        manifold.IsSensor,
+	}
+}
+
+// updateContacts - Compares the touching pairs of this step manifolds with the previous step and dispatches events
+func (w *World) updateContacts() {
+	previous, previousIndex := w.contacts, w.contactIndex
+
+	current := w.contactsBuffer[:0]
+	currentIndex := make(map[contactKey]int, len(w.manifolds))
+	for _, manifold := range w.manifolds {
+		if manifold.ContactsCount == 0 {
+			continue
+		}
+
+		key := newContactKey(manifold.BodyA, manifold.BodyB)
+		if _, ok := currentIndex[key]; ok {
+			continue
+		}
+		currentIndex[key] = len(current)
+		current = append(current, newContact(manifold))
+	}
+
+	w.contacts, w.contactIndex = current, currentIndex
+	w.contactsBuffer = previous
+
+	events := w.contactEvents[:0]
+	for _, contact := range current {
+		kind := contactBegin
+		if _, ok := previousIndex[newContactKey(contact.BodyA, contact.BodyB)]; ok {
+			kind = contactPersist
+		}
+		events = append(events, contactEvent{kind, contact})
+	}
+	for _, contact := range previous {
+		if _, ok := currentIndex[newContactKey(contact.BodyA, contact.BodyB)]; !ok {
+			events = append(events, contactEvent{contactEnd, contact})
+			contact.wakeBodies()
+		}
+	}
+	w.contactEvents = events
+
+	for _, event := range events {
+		// Skip events of bodies destroyed by previous callbacks, their end events were already sent
+		if event.contact.BodyA.world != w || event.contact.BodyB.world != w {
+			if event.kind != contactEnd {
+				continue
+			}
+		}
+		w.dispatchContactEvent(event)
+	}
+}
+
+// endBodyContacts - Removes the contacts of a body being destroyed and dispatches their end events
+func (w *World) endBodyContacts(body *Body) {
+	var ended []Contact
+
+	contacts := w.contacts[:0]
+	for _, contact := range w.contacts {
+		if contact.BodyA == body || contact.BodyB == body {
+			ended = append(ended, contact)
+			continue
+		}
+		contacts = append(contacts, contact)
+	}
+	if len(ended) == 0 {
+		return
+	}
+
+	w.contacts = contacts
+	w.contactIndex = make(map[contactKey]int, len(contacts))
+	for i, contact := range contacts {
+		w.contactIndex[newContactKey(contact.BodyA, contact.BodyB)] = i
+	}
+
+	for _, contact := range ended {
+		contact.wakeBodies()
+		w.dispatchContactEvent(contactEvent{contactEnd, contact})
+	}
+}
+
+// wakeBodies - Wakes the bodies of a contact that stopped touching so they do not rest on nothing, sensors wake
+// no bodies
+func (c Contact) wakeBodies() {
+	if !c.IsSensor {
+		c.BodyA.Wake()
+		c.BodyB.Wake()
+	}
+}
+
+// clearContacts - Forgets every touching pair without dispatching events
+func (w *World) clearContacts() {
+	w.contacts = w.contacts[:0]
+	w.contactIndex = nil
+}
+
+// dispatchContactEvent - Calls the world and bodies listeners of a contact event
+func (w *World) dispatchContactEvent(event contactEvent) {
+	if event.contact.IsSensor {
+		w.dispatchSensorEvent(event)
+		return
+	}
+
+	w.contactListener.call(event)
+	event.contact.BodyA.ContactListener.call(event)
+	event.contact.BodyB.ContactListener.call(event)
+}
+
+// call - Calls the listener callback matching the event type
+func (l ContactListener) call(event contactEvent) {
+	var callback func(contact Contact)
+	switch event.kind {
+	case contactBegin:
+		callback = l.Begin
+	case contactPersist:
+		callback = l.Persist
+	case contactEnd:
+		callback = l.End
+	}
+
+	if callback != nil {
+		callback(event.contact)
+	}
+}
@@ -0,0 +1,168 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// DebugDrawFlags type
+type DebugDrawFlags uint32
+
+// Debug draw layers
+const (
+	// Draw bodies shapes outlines
+	DrawShapes DebugDrawFlags = 1 << iota
+	// Draw bodies axis aligned bounding boxes
+	DrawAABBs
+	// Draw manifolds points of contact
+	DrawContacts
+	// Draw manifolds normals at their points of contact
+	DrawNormals
+	// Draw joints anchors and the lines joining them to their bodies
+	DrawJoints
+	// Draw sleeping bodies shapes with a dimmed color
+	DrawSleeping
+
+	// Draw every layer
+	DrawAll = DrawShapes | DrawAABBs | DrawContacts | DrawNormals | DrawJoints | DrawSleeping
+)
+
+// Debug draw sizes
+const (
+	// Length of the drawn contact normals, in pixels
+	debugNormalLength = 10
+	// Radius of the drawn points of contact and joint anchors, in pixels
+	debugPointRadius = 2
+)
+
+// DebugRenderer - Renderer used to draw the physics world debug information, positions are in world space
+type DebugRenderer interface {
+	// DrawSegment - Draws a line between two points
+	DrawSegment(start, end rl.Vector2, color rl.Color)
+	// DrawCircle - Draws a circle outline
+	DrawCircle(center rl.Vector2, radius float32, color rl.Color)
+	// DrawPoint - Draws a filled point
+	DrawPoint(point rl.Vector2, radius float32, color rl.Color)
+}
+
+// RaylibRenderer - Debug renderer drawing with raylib, use it between rl.BeginDrawing (or rl.BeginMode2D) and
+// rl.EndDrawing
+type RaylibRenderer struct{}
+
+// DrawSegment - Draws a line between two points
+func (RaylibRenderer) DrawSegment(start, end rl.Vector2, color rl.Color) {
+	rl.DrawLineV(start, end, color)
+}
+
+// DrawCircle - Draws a circle outline
+func (RaylibRenderer) DrawCircle(center rl.Vector2, radius float32, color rl.Color) {
+	rl.DrawCircleLines(int32(center.X), int32(center.Y), radius, color)
+}
+
+// DrawPoint - Draws a filled point
+func (RaylibRenderer) DrawPoint(point rl.Vector2, radius float32, color rl.Color) {
+	rl.DrawCircleV(point, radius, color)
+}
+
+// DebugDraw - Draws the physics world layers enabled by flags, a nil renderer draws with raylib
+func DebugDraw(renderer DebugRenderer, flags DebugDrawFlags) {
+	defaultWorld.DebugDraw(renderer, flags)
+}
+
+// DebugDraw - Draws the physics world layers enabled by flags, a nil renderer draws with raylib
+func (w *World) DebugDraw(renderer DebugRenderer, flags DebugDrawFlags) {
+	if renderer == nil {
+		renderer = RaylibRenderer{}
+	}
+
+	if flags&DrawShapes != 0 {
+		for _, body := range w.bodies {
+			color := debugBodyColor(body, flags)
+			for _, shape := range body.GetFixtures() {
+				drawShape(renderer, shape, color)
+			}
+		}
+	}
+
+	if flags&DrawAABBs != 0 {
+		for _, body := range w.bodies {
+			box := body.GetAABB()
+			corners := [4]rl.Vector2{box.Min, {X: box.Max.X, Y: box.Min.Y}, box.Max, {X: box.Min.X, Y: box.Max.Y}}
+			for i := range corners {
+				renderer.DrawSegment(corners[i], corners[(i+1)%len(corners)], rl.Magenta)
+			}
+		}
+	}
+
+	if flags&(DrawContacts|DrawNormals) != 0 {
+		for _, manifold := range w.manifolds {
+			for i := 0; i < manifold.ContactsCount; i++ {
+				contact := manifold.Contacts[i]
+				if flags&DrawContacts != 0 {
+					renderer.DrawPoint(contact, debugPointRadius, rl.Red)
+				}
+				if flags&DrawNormals != 0 {
+					end := rl.Vector2Add(contact, rl.Vector2Scale(manifold.Normal, debugNormalLength))
+					renderer.DrawSegment(contact, end, rl.Orange)
+				}
+			}
+		}
+	}
+
+	if flags&DrawJoints != 0 {
+		for _, joint := range w.joints {
+			drawJoint(renderer, joint)
+		}
+	}
+}
+
+// debugBodyColor - Returns the color used to draw a body shapes
+func debugBodyColor(body *Body, flags DebugDrawFlags) rl.Color {
+	switch {
+	case body.IsSensor:
+		return rl.Yellow
+	case !body.Enabled || body.Type == StaticBody:
+		return rl.Gray
+	case body.Type == KinematicBody:
+		return rl.SkyBlue
+	case body.IsSleeping && flags&DrawSleeping != 0:
+		return rl.DarkGreen
+	default:
+		return rl.Green
+	}
+}
+
+// drawShape - Draws a shape outline, circles include a radius showing their rotation
+func drawShape(renderer DebugRenderer, shape *Shape, color rl.Color) {
+	switch shape.Type {
+	case CircleShape:
+		center := shape.GetPosition()
+		renderer.DrawCircle(center, shape.Radius, color)
+
+		orient := float64(shape.Body.Orient)
+		radius := rl.NewVector2(float32(math.Cos(orient))*shape.Radius, float32(math.Sin(orient))*shape.Radius)
+		renderer.DrawSegment(center, rl.Vector2Add(center, radius), color)
+	case EdgeShape:
+		renderer.DrawSegment(shape.GetVertex(0), shape.GetVertex(1), color)
+	default:
+		count := shape.VertexData.VertexCount
+		for i := 0; i < count; i++ {
+			renderer.DrawSegment(shape.GetVertex(i), shape.GetVertex(getNextIndex(i, count)), color)
+		}
+	}
+}
+
+// drawJoint - Draws the joint anchors and the lines joining them to their bodies
+func drawJoint(renderer DebugRenderer, joint Joint) {
+	anchorA, anchorB := joint.GetAnchorA(), joint.GetAnchorB()
+
+	if bodyA := joint.GetBodyA(); bodyA != nil {
+		renderer.DrawSegment(bodyA.Position, anchorA, rl.SkyBlue)
+	}
+	renderer.DrawSegment(anchorA, anchorB, rl.SkyBlue)
+	renderer.DrawSegment(anchorB, joint.GetBodyB().Position, rl.SkyBlue)
+
+	renderer.DrawPoint(anchorA, debugPointRadius, rl.SkyBlue)
+	renderer.DrawPoint(anchorB, debugPointRadius, rl.SkyBlue)
+}
@@ -0,0 +1,222 @@
+package physics
+
+import (
+	"errors"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// NewBodyFromConcave - Creates a new compound physics body from a simple polygon, convex or concave, split into
+// convex fixtures. Vertices are relative to pos and the body position is placed at the polygon center of mass
+func NewBodyFromConcave(pos rl.Vector2, vertices []rl.Vector2, density float32) (*Body, error) {
+	return defaultWorld.NewBodyFromConcave(pos, vertices, density)
+}
+
+// NewBodyFromConcave - Creates a new compound physics body from a simple polygon, convex or concave, split into
+// convex fixtures. Vertices are relative to pos and the body position is placed at the polygon center of mass
+func (w *World) NewBodyFromConcave(pos rl.Vector2, vertices []rl.Vector2, density float32) (*Body, error) {
+	pieces, err := ConvexDecomposition(vertices)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := make([]FixtureDef, len(pieces))
+	for i, piece := range pieces {
+		fixtures[i] = FixtureDef{
+			Type:            PolygonShape,
+			Vertices:        piece,
+			Density:         density,
+			StaticFriction:  0.4,
+			DynamicFriction: 0.2,
+			Restitution:     0.0,
+		}
+	}
+	return w.NewBodyCompound(pos, fixtures)
+}
+
+// ConvexDecomposition - Splits a simple polygon, without holes or self intersections, into convex polygons of
+// at most maxVertices vertices wound counter clockwise. The polygon is triangulated by ear clipping and the
+// triangles merged back while they stay convex (Hertel-Mehlhorn)
+func ConvexDecomposition(vertices []rl.Vector2) ([][]rl.Vector2, error) {
+	points := removeDuplicateVertices(vertices)
+	if len(points) < 3 {
+		return nil, errors.New("physics: polygon needs at least 3 vertices")
+	}
+
+	// Wind the polygon like physics polygons
+	area := float32(0.0)
+	for i := range points {
+		area += rl.Vector2CrossProduct(points[i], points[getNextIndex(i, len(points))])
+	}
+	if area > -epsilon && area < epsilon {
+		return nil, errors.New("physics: polygon has no area")
+	}
+	if area < 0 {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+
+	pieces, err := triangulate(points)
+	if err != nil {
+		return nil, err
+	}
+	pieces = mergeConvexPieces(points, pieces)
+
+	result := make([][]rl.Vector2, len(pieces))
+	for i, piece := range pieces {
+		result[i] = make([]rl.Vector2, len(piece))
+		for j, index := range piece {
+			result[i][j] = points[index]
+		}
+	}
+	return result, nil
+}
+
+// removeDuplicateVertices - Returns a copy of the polygon vertices without consecutive repeated vertices
+func removeDuplicateVertices(vertices []rl.Vector2) []rl.Vector2 {
+	points := make([]rl.Vector2, 0, len(vertices))
+	for _, vertex := range vertices {
+		if len(points) == 0 || rl.Vector2DistanceSqr(vertex, points[len(points)-1]) > epsilon {
+			points = append(points, vertex)
+		}
+	}
+	for len(points) > 1 && rl.Vector2DistanceSqr(points[0], points[len(points)-1]) <= epsilon {
+		points = points[:len(points)-1]
+	}
+	return points
+}
+
+// triangulate - Splits a counter clockwise simple polygon into triangles by ear clipping, returning the
+// triangles vertex indices
+func triangulate(points []rl.Vector2) ([][]int, error) {
+	remaining := make([]int, len(points))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	triangles := make([][]int, 0, len(points)-2)
+	for len(remaining) > 3 {
+		ear := -1
+		for i := range remaining {
+			if isEar(points, remaining, i) {
+				ear = i
+				break
+			}
+		}
+
+		// Every simple polygon has at least two ears
+		if ear < 0 {
+			return nil, errors.New("physics: polygon is not simple")
+		}
+
+		count := len(remaining)
+		triangles = append(triangles, []int{
+			remaining[(ear+count-1)%count],
+			remaining[ear],
+			remaining[(ear+1)%count],
+		})
+		remaining = append(remaining[:ear], remaining[ear+1:]...)
+	}
+
+	if hullTurn(points[remaining[0]], points[remaining[1]], points[remaining[2]]) > 0 {
+		triangles = append(triangles, remaining)
+	}
+	if len(triangles) == 0 {
+		return nil, errors.New("physics: polygon has no area")
+	}
+	return triangles, nil
+}
+
+// isEar - Checks if the triangle formed by a remaining vertex and its neighbours is inside the polygon and does
+// not contain any other remaining vertex
+func isEar(points []rl.Vector2, remaining []int, index int) bool {
+	count := len(remaining)
+	a := points[remaining[(index+count-1)%count]]
+	b := points[remaining[index]]
+	c := points[remaining[(index+1)%count]]
+
+	// Reflex and collinear vertices are not ears
+	if hullTurn(a, b, c) <= epsilon {
+		return false
+	}
+
+	for i := range remaining {
+		p := points[remaining[i]]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if hullTurn(a, b, p) >= 0 && hullTurn(b, c, p) >= 0 && hullTurn(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeConvexPieces - Merges pieces sharing a diagonal while the result stays convex and small enough to be a
+// physics polygon
+func mergeConvexPieces(points []rl.Vector2, pieces [][]int) [][]int {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				piece, ok := mergePieces(points, pieces[i], pieces[j])
+				if !ok {
+					continue
+				}
+
+				pieces[i] = piece
+				pieces = append(pieces[:j], pieces[j+1:]...)
+				merged = true
+			}
+		}
+	}
+	return pieces
+}
+
+// mergePieces - Joins two counter clockwise pieces sharing an edge, failing if they do not share one or the
+// joined piece would be concave or have too many vertices
+func mergePieces(points []rl.Vector2, a, b []int) ([]int, bool) {
+	if len(a)+len(b)-2 > maxVertices {
+		return nil, false
+	}
+
+	for i := range a {
+		start, end := a[i], a[getNextIndex(i, len(a))]
+		for j := range b {
+			// Shared edges run in opposite directions in each piece
+			if b[j] != end || b[getNextIndex(j, len(b))] != start {
+				continue
+			}
+
+			// Walk a from the end of the shared edge back to its start, then the rest of b
+			piece := make([]int, 0, len(a)+len(b)-2)
+			for k := 1; k <= len(a); k++ {
+				piece = append(piece, a[(i+k)%len(a)])
+			}
+			for k := 2; k < len(b); k++ {
+				piece = append(piece, b[(j+k)%len(b)])
+			}
+
+			if !isConvexPiece(points, piece) {
+				return nil, false
+			}
+			return piece, true
+		}
+	}
+	return nil, false
+}
+
+// isConvexPiece - Checks if a counter clockwise piece has no reflex vertex
+func isConvexPiece(points []rl.Vector2, piece []int) bool {
+	count := len(piece)
+	for i := range piece {
+		a := points[piece[(i+count-1)%count]]
+		b := points[piece[i]]
+		c := points[piece[(i+1)%count]]
+		if hullTurn(a, b, c) < -epsilon {
+			return false
+		}
+	}
+	return true
+}
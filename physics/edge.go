@@ -0,0 +1,217 @@
+package physics
+
+import (
+	"errors"
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// smoothJointCos - Cosine of the greatest angle between two chain edges bending away from their front side for
+// the joint to be smooth. Concave joints are always smooth
+const smoothJointCos = 0.9
+
+// NewBodyEdge - Creates a new static physics body made of a one-sided edge going from v1 to v2, relative to pos.
+// The edge collides on its front side, the one at the left of the v1 to v2 direction (up for an edge going right)
+func NewBodyEdge(pos rl.Vector2, v1, v2 rl.Vector2) (*Body, error) {
+	return defaultWorld.NewBodyEdge(pos, v1, v2)
+}
+
+// NewBodyChain - Creates a new static physics body made of one-sided edges joining the vertices relative to pos,
+// closing the chain when loop is set. Chains wound like polygons collide on their outer side
+func NewBodyChain(pos rl.Vector2, vertices []rl.Vector2, loop bool) (*Body, error) {
+	return defaultWorld.NewBodyChain(pos, vertices, loop)
+}
+
+// NewBodyEdge - Creates a new static physics body made of a one-sided edge going from v1 to v2, relative to pos.
+// The edge collides on its front side, the one at the left of the v1 to v2 direction (up for an edge going right)
+func (w *World) NewBodyEdge(pos rl.Vector2, v1, v2 rl.Vector2) (*Body, error) {
+	shape, err := newEdgeShape(v1, v2)
+	if err != nil {
+		return nil, err
+	}
+	return w.newEdgeBody(pos, []Shape{shape}), nil
+}
+
+// NewBodyChain - Creates a new static physics body made of one-sided edges joining the vertices relative to pos,
+// closing the chain when loop is set. Chains wound like polygons collide on their outer side
+func (w *World) NewBodyChain(pos rl.Vector2, vertices []rl.Vector2, loop bool) (*Body, error) {
+	count := len(vertices)
+	if count < 2 || loop && count < 3 {
+		return nil, errors.New("physics: chain has not enough vertices")
+	}
+
+	edges := count - 1
+	if loop {
+		edges = count
+	}
+
+	shapes := make([]Shape, edges)
+	for i := range shapes {
+		shape, err := newEdgeShape(vertices[i], vertices[(i+1)%count])
+		if err != nil {
+			return nil, fmt.Errorf("physics: chain edge %d: %w", i, err)
+		}
+		shapes[i] = shape
+	}
+
+	// Joints between neighbour edges
+	for i := 0; i < edges; i++ {
+		next := i + 1
+		if next == edges {
+			if !loop {
+				break
+			}
+			next = 0
+		}
+
+		smooth := isSmoothJoint(shapes[i], shapes[next])
+		shapes[i].SmoothJoints[1] = smooth
+		shapes[next].SmoothJoints[0] = smooth
+	}
+
+	return w.newEdgeBody(pos, shapes), nil
+}
+
+// newEdgeBody - Creates a new static physics body made of edge shapes
+func (w *World) newEdgeBody(pos rl.Vector2, shapes []Shape) *Body {
+	newBody := &Body{
+		ID:              w.bodyIDs.acquire(),
+		Type:            StaticBody,
+		Enabled:         true,
+		Position:        pos,
+		Shape:           shapes[0],
+		StaticFriction:  0.4,
+		DynamicFriction: 0.2,
+		Restitution:     0.0,
+		UseGravity:      true,
+		AllowSleep:      true,
+		CategoryBits:    DefaultCategoryBits,
+		MaskBits:        DefaultMaskBits,
+		world:           w,
+	}
+
+	newBody.Shape.Body = newBody
+	newBody.fixtures = make([]*Shape, 0, len(shapes))
+	newBody.fixtures = append(newBody.fixtures, &newBody.Shape)
+	for i := 1; i < len(shapes); i++ {
+		shape := shapes[i]
+		shape.Body = newBody
+		newBody.fixtures = append(newBody.fixtures, &shape)
+	}
+
+	// Add new body to bodies pointers array and update bodies count
+	w.bodies = append(w.bodies, newBody)
+	return newBody
+}
+
+// newEdgeShape - Creates an edge shape from v1 to v2. It is stored as a two vertices polygon whose first normal is
+// the edge front side normal
+func newEdgeShape(v1, v2 rl.Vector2) (Shape, error) {
+	face := rl.Vector2Subtract(v2, v1)
+	if rl.Vector2LengthSqr(face) < epsilon*epsilon {
+		return Shape{}, errors.New("edge has no length")
+	}
+
+	normal := rl.NewVector2(face.Y, -face.X)
+	normalize(&normal)
+
+	shape := Shape{
+		Type:            EdgeShape,
+		Transform:       rl.Mat2Radians(0),
+		StaticFriction:  0.4,
+		DynamicFriction: 0.2,
+		Restitution:     0.0,
+	}
+	shape.VertexData.VertexCount = 2
+	shape.VertexData.Positions[0] = v1
+	shape.VertexData.Positions[1] = v2
+	shape.VertexData.Normals[0] = normal
+	shape.VertexData.Normals[1] = rl.Vector2Negate(normal)
+	return shape, nil
+}
+
+// isSmoothJoint - Checks if the joint between an edge and the next one is concave or bends just a little
+func isSmoothJoint(edge, next Shape) bool {
+	direction := rl.Vector2Normalize(rl.Vector2Subtract(edge.VertexData.Positions[1], edge.VertexData.Positions[0]))
+	nextDirection := rl.Vector2Normalize(rl.Vector2Subtract(next.VertexData.Positions[1], next.VertexData.Positions[0]))
+	if rl.Vector2DotProduct(edge.VertexData.Normals[0], nextDirection) >= 0 {
+		return true
+	}
+	return rl.Vector2DotProduct(direction, nextDirection) >= smoothJointCos
+}
+
+// getEdgeNormal - Returns the edge front side normal in world space
+func (s *Shape) getEdgeNormal() rl.Vector2 {
+	return rl.Mat2MultiplyVector2(s.Transform, s.VertexData.Normals[0])
+}
+
+// solveEdgeManifold - Solves collision between a one-sided edge and a circle or polygon shape. Shapes whose
+// center is behind the edge do not collide with it
+func solveEdgeManifold(manifold *Manifold) {
+	manifold.ContactsCount = 0
+	if manifold.BodyA == nil || manifold.BodyB == nil {
+		return
+	}
+
+	edgeIsA := manifold.ShapeA.Type == EdgeShape
+	edge, other := manifold.ShapeA, manifold.ShapeB
+	if !edgeIsA {
+		edge, other = other, edge
+	}
+	if other.Type == EdgeShape {
+		return
+	}
+
+	normal := edge.getEdgeNormal()
+	if rl.Vector2DotProduct(normal, rl.Vector2Subtract(other.GetPosition(), edge.GetVertex(0))) < 0 {
+		return
+	}
+
+	switch other.Type {
+	case CircleShape:
+		solveDifferentShapes(manifold, other, edge)
+		if edgeIsA {
+			manifold.Normal = rl.Vector2Negate(manifold.Normal)
+		}
+	case PolygonShape:
+		solveEdgeToPolygon(manifold, edge, other, edgeIsA)
+	}
+
+	// Discard contacts pushing the shape behind the edge
+	direction := manifold.Normal
+	if !edgeIsA {
+		direction = rl.Vector2Negate(direction)
+	}
+	if rl.Vector2DotProduct(direction, normal) < 0 {
+		manifold.ContactsCount = 0
+	}
+}
+
+// solveEdgeToPolygon - Solves collision between an edge and a polygon shape. The edge front face is used as
+// reference face while the polygon center is over the edge or past a smooth joint, so polygons sliding along a
+// chain do not catch on the vertices between edges
+func solveEdgeToPolygon(manifold *Manifold, edge, polygon *Shape, edgeIsA bool) {
+	// Check for separating axis with the edge and the polygon face planes
+	_, penetrationEdge := findAxisLeastPenetration(*edge, *polygon)
+	if penetrationEdge >= 0 {
+		return
+	}
+
+	facePolygon, penetrationPolygon := findAxisLeastPenetration(*polygon, *edge)
+	if penetrationPolygon >= 0 {
+		return
+	}
+
+	// Polygon center position along the edge (0 at the first vertex and 1 at the second one)
+	v1, v2 := edge.GetVertex(0), edge.GetVertex(1)
+	face := rl.Vector2Subtract(v2, v1)
+	t := rl.Vector2DotProduct(rl.Vector2Subtract(polygon.GetPosition(), v1), face) / rl.Vector2LengthSqr(face)
+
+	smooth := t >= 0 && t <= 1 || t < 0 && edge.SmoothJoints[0] || t > 1 && edge.SmoothJoints[1]
+	if smooth || biasGreaterThan(penetrationEdge, penetrationPolygon) {
+		clipIncidentFace(manifold, *edge, *polygon, 0, !edgeIsA)
+	} else {
+		clipIncidentFace(manifold, *polygon, *edge, facePolygon, edgeIsA)
+	}
+}
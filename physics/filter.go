@@ -0,0 +1,68 @@
+package physics
+
+// Default collision filtering values
+const (
+	// Category assigned to new bodies
+	DefaultCategoryBits uint16 = 0x0001
+	// Mask assigned to new bodies, collides with every category
+	DefaultMaskBits uint16 = 0xFFFF
+)
+
+// ContactFilter - Decides if two bodies that passed the category, mask and group checks may collide.
+// Returning false vetoes the contact for the current step
+type ContactFilter func(bodyA, bodyB *Body) bool
+
+// SetContactFilter - Sets the user callback that can veto contacts between bodies pairs (nil to disable)
+func SetContactFilter(filter ContactFilter) {
+	defaultWorld.SetContactFilter(filter)
+}
+
+// SetContactFilter - Sets the user callback that can veto contacts between bodies pairs (nil to disable)
+func (w *World) SetContactFilter(filter ContactFilter) {
+	w.contactFilter = filter
+}
+
+// SetFilter - Sets physics body collision category, mask and group
+func (b *Body) SetFilter(categoryBits, maskBits uint16, groupIndex int16) {
+	b.CategoryBits = categoryBits
+	b.MaskBits = maskBits
+	b.GroupIndex = groupIndex
+}
+
+// shouldCollide - Checks if a pair found by the broadphase must be tested for collision
+func (w *World) shouldCollide(bodyA, bodyB *Body) bool {
+	// Sensors do not detect other sensors
+	if bodyA.IsSensor && bodyB.IsSensor {
+		return false
+	}
+
+	// Bodies with infinite mass never respond to collisions, but sensors still report overlaps
+	if bodyA.invMass() == 0 && bodyB.invMass() == 0 && !bodyA.IsSensor && !bodyB.IsSensor {
+		return false
+	}
+
+	if isConnected(bodyA, bodyB) {
+		return false
+	}
+
+	if !filterBodies(bodyA, bodyB) {
+		return false
+	}
+
+	if w.contactFilter != nil && !w.contactFilter(bodyA, bodyB) {
+		return false
+	}
+
+	return true
+}
+
+// filterBodies - Checks bodies collision groups, categories and masks.
+// Bodies sharing a positive group always collide and bodies sharing a negative group never collide,
+// otherwise each body category must be included in the other body mask
+func filterBodies(bodyA, bodyB *Body) bool {
+	if bodyA.GroupIndex == bodyB.GroupIndex && bodyA.GroupIndex != 0 {
+		return bodyA.GroupIndex > 0
+	}
+
+	return bodyA.MaskBits&bodyB.CategoryBits != 0 && bodyA.CategoryBits&bodyB.MaskBits != 0
+}
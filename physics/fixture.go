@@ -0,0 +1,266 @@
+package physics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// FixtureDef - Definition of a shape attached to a compound physics body
+type FixtureDef struct {
+	// Physics shape type (circle or polygon)
+	Type ShapeType
+	// Shape pivot position relative to the body position
+	Offset rl.Vector2
+	// Circle shape radius (used for circle shapes)
+	Radius float32
+	// Polygon vertices relative to the offset, their convex hull is used (used for polygon shapes)
+	Vertices []rl.Vector2
+	// Shape density
+	Density float32
+	// Friction when the shape has not movement (0 to 1)
+	StaticFriction float32
+	// Friction when the shape has movement (0 to 1)
+	DynamicFriction float32
+	// Restitution coefficient of the shape (0 to 1)
+	Restitution float32
+}
+
+// NewBodyFromVertices - Creates a new polygon physics body from the convex hull of the given vertices, relative
+// to pos. The body position is placed at the polygon centroid
+func NewBodyFromVertices(pos rl.Vector2, vertices []rl.Vector2, density float32) (*Body, error) {
+	return defaultWorld.NewBodyFromVertices(pos, vertices, density)
+}
+
+// NewBodyCompound - Creates a new physics body made of several circle and polygon shapes. The body position is
+// placed at the shapes center of mass
+func NewBodyCompound(pos rl.Vector2, fixtures []FixtureDef) (*Body, error) {
+	return defaultWorld.NewBodyCompound(pos, fixtures)
+}
+
+// NewBodyFromVertices - Creates a new polygon physics body from the convex hull of the given vertices, relative
+// to pos. The body position is placed at the polygon centroid
+func (w *World) NewBodyFromVertices(pos rl.Vector2, vertices []rl.Vector2, density float32) (*Body, error) {
+	return w.NewBodyCompound(pos, []FixtureDef{{
+		Type:            PolygonShape,
+		Vertices:        vertices,
+		Density:         density,
+		StaticFriction:  0.4,
+		DynamicFriction: 0.2,
+		Restitution:     0.0,
+	}})
+}
+
+// NewBodyCompound - Creates a new physics body made of several circle and polygon shapes. The body position is
+// placed at the shapes center of mass. The first shape is the body main shape and its material is copied to the
+// body friction and restitution values
+func (w *World) NewBodyCompound(pos rl.Vector2, fixtures []FixtureDef) (*Body, error) {
+	if len(fixtures) == 0 {
+		return nil, errors.New("physics: compound body needs at least one fixture")
+	}
+
+	shapes := make([]Shape, len(fixtures))
+	masses := make([]float32, len(fixtures))
+	inertias := make([]float32, len(fixtures))
+	var mass float32
+	var center rl.Vector2
+
+	for i, def := range fixtures {
+		shape := Shape{
+			Type:            def.Type,
+			Transform:       rl.Mat2Radians(0),
+			Offset:          def.Offset,
+			StaticFriction:  def.StaticFriction,
+			DynamicFriction: def.DynamicFriction,
+			Restitution:     def.Restitution,
+		}
+
+		switch def.Type {
+		case CircleShape:
+			if def.Radius <= 0 {
+				return nil, fmt.Errorf("physics: fixture %d circle radius must be positive", i)
+			}
+			shape.Radius = def.Radius
+			masses[i] = def.Density * math.Pi * def.Radius * def.Radius
+			// Same inertia as NewBodyCircle so single circle compounds spin like circle bodies
+			inertias[i] = masses[i] * def.Radius * def.Radius
+		case PolygonShape:
+			data, err := newConvexPolygon(def.Vertices)
+			if err != nil {
+				return nil, fmt.Errorf("physics: fixture %d: %w", i, err)
+			}
+
+			// Polygon vertices are stored around their centroid
+			area, centroid, inertia := polygonMassData(data)
+			for j := 0; j < data.VertexCount; j++ {
+				data.Positions[j] = rl.Vector2Subtract(data.Positions[j], centroid)
+			}
+			shape.VertexData = data
+			shape.Offset = rl.Vector2Add(shape.Offset, centroid)
+			masses[i] = def.Density * area
+			inertias[i] = def.Density * inertia
+		default:
+			return nil, fmt.Errorf("physics: fixture %d has an invalid shape type", i)
+		}
+
+		shapes[i] = shape
+		mass += masses[i]
+		center = rl.Vector2Add(center, rl.Vector2Scale(shape.Offset, masses[i]))
+	}
+	center = rl.Vector2Scale(center, safeDiv(1, mass))
+
+	// Move shapes around the center of mass and sum their inertia about it
+	var inertia float32
+	for i := range shapes {
+		shapes[i].Offset = rl.Vector2Subtract(shapes[i].Offset, center)
+		inertia += inertias[i] + masses[i]*rl.Vector2LengthSqr(shapes[i].Offset)
+	}
+
+	newBody := &Body{
+		ID:              w.bodyIDs.acquire(),
+		Type:            DynamicBody,
+		Enabled:         true,
+		Position:        rl.Vector2Add(pos, center),
+		Shape:           shapes[0],
+		StaticFriction:  fixtures[0].StaticFriction,
+		DynamicFriction: fixtures[0].DynamicFriction,
+		Restitution:     fixtures[0].Restitution,
+		UseGravity:      true,
+		AllowSleep:      true,
+		CategoryBits:    DefaultCategoryBits,
+		MaskBits:        DefaultMaskBits,
+		world:           w,
+	}
+
+	newBody.Shape.Body = newBody
+	newBody.fixtures = make([]*Shape, 0, len(shapes))
+	newBody.fixtures = append(newBody.fixtures, &newBody.Shape)
+	for i := 1; i < len(shapes); i++ {
+		shape := shapes[i]
+		shape.Body = newBody
+		newBody.fixtures = append(newBody.fixtures, &shape)
+	}
+
+	newBody.Mass = mass
+	newBody.InverseMass = safeDiv(1.0, newBody.Mass)
+	newBody.Inertia = inertia
+	newBody.InverseInertia = safeDiv(1.0, newBody.Inertia)
+
+	// Add new body to bodies pointers array and update bodies count
+	w.bodies = append(w.bodies, newBody)
+	return newBody, nil
+}
+
+// ConvexHull - Returns the convex hull of a set of points wound counter clockwise, without collinear points
+func ConvexHull(points []rl.Vector2) []rl.Vector2 {
+	sorted := append([]rl.Vector2(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	// Andrew's monotone chain, building the lower and upper hulls
+	hull := make([]rl.Vector2, 0, 2*len(sorted))
+	for _, point := range sorted {
+		for len(hull) >= 2 && hullTurn(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, point)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		for len(hull) >= lower && hullTurn(hull[len(hull)-2], hull[len(hull)-1], sorted[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, sorted[i])
+	}
+
+	// Last point is the same as the first one
+	return hull[:len(hull)-1]
+}
+
+// GetFixtures - Returns the shapes attached to the body, the main shape first
+func (b *Body) GetFixtures() []*Shape {
+	if len(b.fixtures) == 0 {
+		b.fixtures = []*Shape{&b.Shape}
+	}
+	return b.fixtures
+}
+
+// GetPosition - Returns the shape pivot position in world space
+func (s *Shape) GetPosition() rl.Vector2 {
+	if s.Offset == (rl.Vector2{}) {
+		return s.Body.Position
+	}
+	return rl.Vector2Add(s.Body.Position, rl.Mat2MultiplyVector2(s.Transform, s.Offset))
+}
+
+// getMaterial - Returns the shape static friction, dynamic friction and restitution, the main shape of a body
+// uses the body values
+func (s *Shape) getMaterial() (float32, float32, float32) {
+	if s == &s.Body.Shape {
+		return s.Body.StaticFriction, s.Body.DynamicFriction, s.Body.Restitution
+	}
+	return s.StaticFriction, s.DynamicFriction, s.Restitution
+}
+
+// newConvexPolygon - Creates polygon vertex data from the convex hull of a set of points
+func newConvexPolygon(points []rl.Vector2) (Polygon, error) {
+	hull := ConvexHull(points)
+	if len(hull) < 3 {
+		return Polygon{}, errors.New("polygon needs at least 3 non collinear vertices")
+	}
+	if len(hull) > maxVertices {
+		return Polygon{}, fmt.Errorf("polygon convex hull has %d vertices, the maximum is %d", len(hull), maxVertices)
+	}
+
+	data, ok := newPolygonData(hull)
+	if !ok {
+		return Polygon{}, errors.New("polygon has no area")
+	}
+	return data, nil
+}
+
+// polygonMassData - Returns a polygon area, centroid and moment of inertia about its centroid for unit density
+func polygonMassData(data Polygon) (float32, rl.Vector2, float32) {
+	var center rl.Vector2
+	area := float32(0.0)
+	inertia := float32(0.0)
+
+	for i := 0; i < data.VertexCount; i++ {
+		// Triangle vertices, third vertex implied as (0, 0)
+		p1 := data.Positions[i]
+		p2 := data.Positions[getNextIndex(i, data.VertexCount)]
+
+		cross := rl.Vector2CrossProduct(p1, p2)
+		triangleArea := cross / 2
+		area += triangleArea
+
+		// Use area to weight the centroid average, not just vertex position
+		center.X += triangleArea * physacK * (p1.X + p2.X)
+		center.Y += triangleArea * physacK * (p1.Y + p2.Y)
+
+		intx2 := p1.X*p1.X + p2.X*p1.X + p2.X*p2.X
+		inty2 := p1.Y*p1.Y + p2.Y*p1.Y + p2.Y*p2.Y
+		inertia += cross / 12 * (intx2 + inty2)
+	}
+
+	center = rl.Vector2Scale(center, safeDiv(1, area))
+
+	// Move inertia from the origin to the centroid
+	inertia -= area * rl.Vector2LengthSqr(center)
+	return area, center, inertia
+}
+
+// hullTurn - Returns positive values for counter clockwise turns from a to b to c
+func hullTurn(a, b, c rl.Vector2) float32 {
+	return rl.Vector2CrossProduct(rl.Vector2Subtract(b, a), rl.Vector2Subtract(c, a))
+}
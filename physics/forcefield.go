@@ -0,0 +1,308 @@
+package physics
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ForceFieldType type
+type ForceFieldType int
+
+// Force field types
+const (
+	// Constant acceleration, like wind or gravity zones
+	UniformField ForceFieldType = iota
+	// Acceleration towards a point, like planets gravity
+	RadialField
+	// Slows bodies down towards the flow velocity, like air or currents
+	DragField
+	// Pushes bodies against gravity by the weight of the fluid their shapes displace, slowing their submerged part
+	// down (water volumes). Bodies not using gravity weigh nothing and are only slowed down
+	BuoyancyField
+)
+
+// Force fields constants
+const (
+	// Sides of the polygons circles are approximated with to find their submerged area
+	buoyancyCircleSides = 16
+	// Default buoyancy fields linear and angular drag, per second
+	defaultFluidDrag = 1.0
+)
+
+// ForceField - Region of the physics world applying forces to the bodies inside it every step. Accelerations are in
+// the world gravity units and velocities in the bodies velocity units
+type ForceField struct {
+	// Force field type
+	Type ForceFieldType
+	// World space region where the field acts, fields with an empty region act everywhere. Radial fields act within
+	// Radius of Center instead
+	Region AABB
+	// Uniform field acceleration
+	Acceleration rl.Vector2
+	// Radial field attraction point
+	Center rl.Vector2
+	// Radial field reach (0 reaches everywhere)
+	Radius float32
+	// Radial field acceleration towards Center, negative values push bodies away
+	Strength float32
+	// Buoyancy field fluid density, bodies with a lower density float
+	Density float32
+	// Fraction of the bodies velocity relative to the flow removed every second, by drag and buoyancy fields
+	LinearDrag float32
+	// Fraction of the bodies angular velocity removed every second, by drag and buoyancy fields
+	AngularDrag float32
+	// Drag and buoyancy fields fluid velocity
+	FlowVelocity rl.Vector2
+	// Categories of the bodies the field acts on
+	MaskBits uint16
+	// Disabled fields do not act
+	Enabled bool
+
+	// Physics world the field belongs to
+	world *World
+	// Field settings the sleeping bodies were last woken up for, nil until the field first steps
+	applied *ForceField
+}
+
+// NewUniformField - Creates a force field accelerating the bodies inside a region
+func NewUniformField(region AABB, acceleration rl.Vector2) *ForceField {
+	return defaultWorld.NewUniformField(region, acceleration)
+}
+
+// NewRadialField - Creates a force field accelerating the bodies within radius towards a center point
+func NewRadialField(center rl.Vector2, radius, strength float32) *ForceField {
+	return defaultWorld.NewRadialField(center, radius, strength)
+}
+
+// NewDragField - Creates a force field slowing the bodies inside a region down towards a flow velocity
+func NewDragField(region AABB, drag float32, flowVelocity rl.Vector2) *ForceField {
+	return defaultWorld.NewDragField(region, drag, flowVelocity)
+}
+
+// NewBuoyancyField - Creates a fluid volume making the bodies inside a region float by their submerged area
+func NewBuoyancyField(region AABB, density float32) *ForceField {
+	return defaultWorld.NewBuoyancyField(region, density)
+}
+
+// GetForceFields - Returns the slice of created force fields
+func GetForceFields() []*ForceField {
+	return defaultWorld.GetForceFields()
+}
+
+// NewUniformField - Creates a force field accelerating the bodies inside a region
+func (w *World) NewUniformField(region AABB, acceleration rl.Vector2) *ForceField {
+	return w.addForceField(&ForceField{Type: UniformField, Region: region, Acceleration: acceleration})
+}
+
+// NewRadialField - Creates a force field accelerating the bodies within radius towards a center point
+func (w *World) NewRadialField(center rl.Vector2, radius, strength float32) *ForceField {
+	return w.addForceField(&ForceField{Type: RadialField, Center: center, Radius: radius, Strength: strength})
+}
+
+// NewDragField - Creates a force field slowing the bodies inside a region down towards a flow velocity, removing
+// the drag fraction of their relative velocity every second
+func (w *World) NewDragField(region AABB, drag float32, flowVelocity rl.Vector2) *ForceField {
+	return w.addForceField(&ForceField{
+		Type:         DragField,
+		Region:       region,
+		LinearDrag:   drag,
+		AngularDrag:  drag,
+		FlowVelocity: flowVelocity,
+	})
+}
+
+// NewBuoyancyField - Creates a fluid volume making the bodies inside a region float by their submerged area. Its
+// surface is the region top side for the default gravity
+func (w *World) NewBuoyancyField(region AABB, density float32) *ForceField {
+	return w.addForceField(&ForceField{
+		Type:        BuoyancyField,
+		Region:      region,
+		Density:     density,
+		LinearDrag:  defaultFluidDrag,
+		AngularDrag: defaultFluidDrag,
+	})
+}
+
+// GetForceFields - Returns the slice of created force fields
+func (w *World) GetForceFields() []*ForceField {
+	return w.forceFields
+}
+
+// Destroy - Removes the force field from its physics world
+func (f *ForceField) Destroy() {
+	w := f.world
+	if w == nil {
+		return
+	}
+
+	for i, field := range w.forceFields {
+		if field == f {
+			copy(w.forceFields[i:], w.forceFields[i+1:])
+			w.forceFields[len(w.forceFields)-1] = nil
+			w.forceFields = w.forceFields[:len(w.forceFields)-1]
+			break
+		}
+	}
+	if f.applied != nil {
+		w.wakeReachedBodies(f.applied)
+	}
+	f.applied = nil
+	f.world = nil
+}
+
+// addForceField - Enables a force field acting on every body category and adds it to the world
+func (w *World) addForceField(field *ForceField) *ForceField {
+	field.MaskBits = DefaultMaskBits
+	field.Enabled = true
+	field.world = w
+	w.forceFields = append(w.forceFields, field)
+	return field
+}
+
+// applyForceFields - Integrates the world force fields acting on a physics body into its velocity
+func (w *World) applyForceFields(body *Body, dt float32) {
+	for _, field := range w.forceFields {
+		if !field.Enabled || field.MaskBits&body.CategoryBits == 0 {
+			continue
+		}
+
+		switch field.Type {
+		case UniformField:
+			if field.contains(body.Position) {
+				body.Velocity = rl.Vector2Add(body.Velocity, rl.Vector2Scale(field.Acceleration, dt/1000/2))
+			}
+		case RadialField:
+			direction := rl.Vector2Subtract(field.Center, body.Position)
+			distance := rl.Vector2Length(direction)
+			if distance > epsilon && (field.Radius <= 0 || distance <= field.Radius) {
+				body.Velocity = rl.Vector2Add(body.Velocity, rl.Vector2Scale(direction, field.Strength/distance*(dt/1000/2)))
+			}
+		case DragField:
+			if field.contains(body.Position) {
+				field.applyDrag(body, 1, dt)
+			}
+		case BuoyancyField:
+			w.applyBuoyancy(field, body, dt)
+		}
+	}
+}
+
+// wakeForceFieldBodies - Wakes the sleeping bodies reached by the force fields created, changed or enabled since the
+// last step, before and after the change, as sleeping bodies skip the fields forces
+func (w *World) wakeForceFieldBodies() {
+	for _, field := range w.forceFields {
+		settings := *field
+		settings.world = nil
+		settings.applied = nil
+		if field.applied != nil && *field.applied == settings {
+			continue
+		}
+
+		if field.applied != nil {
+			w.wakeReachedBodies(field.applied)
+		}
+		w.wakeReachedBodies(&settings)
+		field.applied = &settings
+	}
+}
+
+// wakeReachedBodies - Wakes the sleeping bodies a force field acts on
+func (w *World) wakeReachedBodies(field *ForceField) {
+	if !field.Enabled {
+		return
+	}
+
+	for _, body := range w.bodies {
+		if body != nil && body.IsSleeping && field.reaches(body) {
+			body.Wake()
+		}
+	}
+}
+
+// reaches - Checks if the force field acts on a physics body
+func (f *ForceField) reaches(body *Body) bool {
+	if f.MaskBits&body.CategoryBits == 0 {
+		return false
+	}
+
+	switch f.Type {
+	case RadialField:
+		return f.Radius <= 0 || rl.Vector2Distance(f.Center, body.Position) <= f.Radius
+	case BuoyancyField:
+		return f.Region == (AABB{}) || f.Region.Overlaps(body.GetAABB())
+	default:
+		return f.contains(body.Position)
+	}
+}
+
+// contains - Checks if a point is inside the field region, empty regions contain every point
+func (f *ForceField) contains(point rl.Vector2) bool {
+	return f.Region == (AABB{}) || f.Region.Contains(point)
+}
+
+// applyDrag - Removes a fraction of the body velocity relative to the flow, scaled by the part of the body inside
+// the field
+func (f *ForceField) applyDrag(body *Body, fraction, dt float32) {
+	linear := min(f.LinearDrag*fraction*(dt/1000/2), 1)
+	relative := rl.Vector2Subtract(body.Velocity, f.FlowVelocity)
+	body.Velocity = rl.Vector2Subtract(body.Velocity, rl.Vector2Scale(relative, linear))
+
+	if !body.FreezeOrient {
+		body.AngularVelocity -= body.AngularVelocity * min(f.AngularDrag*fraction*(dt/1000/2), 1)
+	}
+}
+
+// applyBuoyancy - Pushes a body against gravity by the weight of the fluid displaced by its submerged area, at the
+// submerged area centroid, and drags it by the submerged fraction of its area. Bodies not using gravity do not sink
+// either, so they are only dragged
+func (w *World) applyBuoyancy(field *ForceField, body *Body, dt float32) {
+	var area, submergedArea float32
+	var centroid rl.Vector2
+
+	for _, shape := range body.GetFixtures() {
+		if shape.Type == EdgeShape {
+			continue
+		}
+
+		sides := 0
+		if shape.Type == CircleShape {
+			sides = buoyancyCircleSides
+		}
+		outline := shapeOutline(shape, sides)
+		shapeArea, _ := outlineMassData(outline)
+		area += shapeArea
+
+		if field.Region != (AABB{}) {
+			outline = clipOutline(outline, rl.NewVector2(-1, 0), -field.Region.Min.X)
+			outline = clipOutline(outline, rl.NewVector2(1, 0), field.Region.Max.X)
+			outline = clipOutline(outline, rl.NewVector2(0, -1), -field.Region.Min.Y)
+			outline = clipOutline(outline, rl.NewVector2(0, 1), field.Region.Max.Y)
+		}
+		if len(outline) < 3 {
+			continue
+		}
+
+		submerged, center := outlineMassData(outline)
+		submergedArea += submerged
+		centroid = rl.Vector2Add(centroid, rl.Vector2Scale(center, submerged))
+	}
+
+	if submergedArea <= epsilon || area <= epsilon {
+		return
+	}
+	centroid = rl.Vector2Scale(centroid, 1/submergedArea)
+
+	if !body.UseGravity {
+		field.applyDrag(body, min(submergedArea/area, 1), dt)
+		return
+	}
+
+	// Weight of the displaced fluid pushing at the submerged centroid
+	force := rl.Vector2Scale(w.gravityForce, -field.Density*submergedArea)
+	body.Velocity = rl.Vector2Add(body.Velocity, rl.Vector2Scale(force, body.InverseMass*(dt/1000/2)))
+	if !body.FreezeOrient {
+		radius := rl.Vector2Subtract(centroid, body.Position)
+		body.AngularVelocity += rl.Vector2CrossProduct(radius, force) * body.InverseInertia * (dt / 1000 / 2)
+	}
+
+	field.applyDrag(body, min(submergedArea/area, 1), dt)
+}
@@ -0,0 +1,292 @@
+package physics
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// FracturePattern type
+type FracturePattern int
+
+// Fracture patterns
+const (
+	// Triangle fan around the impact point, one fragment per outline side
+	FractureRadial FracturePattern = iota
+	// Voronoi cells of random sites scattered around the impact point, smaller fragments close to it
+	FractureVoronoi
+)
+
+// FractureDef - Definition of how a physics body breaks
+type FractureDef struct {
+	// Fragments layout (radial or voronoi)
+	Pattern FracturePattern
+	// Number of fragments, circle outlines are split in as many sides. Polygons use one fragment per side when 0
+	Pieces int
+	// Distance from the impact point where voronoi sites are scattered, the body size when 0
+	Radius float32
+	// Force pushing every fragment away from the impact point, applied during the next step
+	Force float32
+	// Seed of the voronoi sites, the same seed breaks a body the same way
+	Seed int64
+}
+
+// Fracture constants
+const (
+	// Fragments smaller than this area are dropped, in square pixels
+	minFragmentArea = 1.0
+	// Default number of voronoi cells and circle outline sides
+	defaultFracturePieces = 8
+)
+
+// Fracture - Breaks a circle or polygon physics body in fragments at an impact point, returning them
+func Fracture(body *Body, impact rl.Vector2, def FractureDef) ([]*Body, error) {
+	return defaultWorld.Fracture(body, impact, def)
+}
+
+// Fracture - Breaks a circle or polygon physics body in fragments at an impact point, returning them. The body is
+// destroyed and its fragments keep its density, material, collision filter and velocity, so the momentum is kept
+// before the fracture force is applied. Impact points outside the body are moved inside it. Compound and edge
+// bodies can not be fractured
+func (w *World) Fracture(body *Body, impact rl.Vector2, def FractureDef) ([]*Body, error) {
+	if body == nil || body.world != w {
+		return nil, errors.New("physics: fractured body does not belong to the world")
+	}
+	if len(body.GetFixtures()) > 1 || body.Shape.Type == EdgeShape {
+		return nil, errors.New("physics: only circle and polygon bodies can be fractured")
+	}
+
+	outline := shapeOutline(&body.Shape, def.Pieces)
+	area, _ := outlineMassData(outline)
+	if area <= 0 {
+		return nil, errors.New("physics: fractured body has no area")
+	}
+	density := body.Mass / area
+	impact = moveInside(outline, impact)
+
+	var cells [][]rl.Vector2
+	switch def.Pattern {
+	case FractureRadial:
+		for i := range outline {
+			cells = append(cells, []rl.Vector2{outline[i], outline[getNextIndex(i, len(outline))], impact})
+		}
+	case FractureVoronoi:
+		cells = voronoiCells(outline, impact, def)
+	default:
+		return nil, errors.New("physics: invalid fracture pattern")
+	}
+
+	// Create every fragment before destroying the body so a failure leaves the world unchanged
+	fragments := make([]*Body, 0, len(cells))
+	for _, cell := range cells {
+		if cellArea, _ := outlineMassData(cell); cellArea < minFragmentArea {
+			continue
+		}
+
+		fragment, err := w.NewBodyFromVertices(rl.Vector2{}, simplifyOutline(cell, maxVertices), density)
+		if err != nil {
+			for _, created := range fragments {
+				created.Destroy()
+			}
+			return nil, err
+		}
+		fragments = append(fragments, fragment)
+	}
+
+	for _, fragment := range fragments {
+		inheritBody(fragment, body)
+
+		// Push the fragment away from the impact point
+		direction := rl.Vector2Subtract(fragment.Position, impact)
+		normalize(&direction)
+		AddForce(fragment, rl.Vector2Scale(direction, def.Force))
+	}
+
+	body.Destroy()
+	return fragments, nil
+}
+
+// inheritBody - Copies the material, collision filter, settings and rigid motion of a fractured body to a fragment
+func inheritBody(fragment, body *Body) {
+	fragment.Type = body.Type
+	fragment.Enabled = body.Enabled
+	fragment.StaticFriction = body.StaticFriction
+	fragment.DynamicFriction = body.DynamicFriction
+	fragment.Restitution = body.Restitution
+	fragment.UseGravity = body.UseGravity
+	fragment.FreezeOrient = body.FreezeOrient
+	fragment.IsBullet = body.IsBullet
+	fragment.AllowSleep = body.AllowSleep
+	fragment.SetFilter(body.CategoryBits, body.MaskBits, body.GroupIndex)
+
+	// Fragments keep moving like the point of the body they were part of
+	offset := rl.Vector2Subtract(fragment.Position, body.Position)
+	spin := rl.NewVector2(-body.AngularVelocity*offset.Y, body.AngularVelocity*offset.X)
+	fragment.Velocity = rl.Vector2Add(body.Velocity, spin)
+	fragment.AngularVelocity = body.AngularVelocity
+}
+
+// shapeOutline - Returns a shape outline in world space, circles are split in as many sides as pieces and polygons
+// sides are split until they have as many
+func shapeOutline(shape *Shape, pieces int) []rl.Vector2 {
+	if shape.Type == CircleShape {
+		if pieces < 3 {
+			pieces = defaultFracturePieces
+		}
+		center := shape.GetPosition()
+		outline := make([]rl.Vector2, pieces)
+		for i := range outline {
+			angle := 2*math.Pi*float64(i)/float64(pieces) + float64(shape.Body.Orient)
+			outline[i] = rl.NewVector2(
+				center.X+float32(math.Cos(angle))*shape.Radius,
+				center.Y+float32(math.Sin(angle))*shape.Radius,
+			)
+		}
+		return outline
+	}
+
+	outline := make([]rl.Vector2, shape.VertexData.VertexCount)
+	for i := range outline {
+		outline[i] = shape.GetVertex(i)
+	}
+
+	// Radial fractures with more pieces than sides split the longest sides
+	for len(outline) < pieces {
+		longest := 0
+		for i := range outline {
+			next := getNextIndex(i, len(outline))
+			if rl.Vector2DistanceSqr(outline[i], outline[next]) > rl.Vector2DistanceSqr(outline[longest], outline[getNextIndex(longest, len(outline))]) {
+				longest = i
+			}
+		}
+		middle := rl.Vector2Lerp(outline[longest], outline[getNextIndex(longest, len(outline))], 0.5)
+		outline = slices.Insert(outline, longest+1, middle)
+	}
+	return outline
+}
+
+// moveInside - Returns a point inside a convex outline, points outside are moved to the outline and slightly
+// towards its centroid
+func moveInside(outline []rl.Vector2, point rl.Vector2) rl.Vector2 {
+	_, centroid := outlineMassData(outline)
+	inside := true
+	closest, distance := point, float32(math.MaxFloat32)
+	for i := range outline {
+		next := outline[getNextIndex(i, len(outline))]
+		if (hullTurn(outline[i], next, point) < 0) != (hullTurn(outline[i], next, centroid) < 0) {
+			inside = false
+		}
+		if candidate := closestPointOnSegment(outline[i], next, point); rl.Vector2DistanceSqr(candidate, point) < distance {
+			closest, distance = candidate, rl.Vector2DistanceSqr(candidate, point)
+		}
+	}
+
+	if inside {
+		return point
+	}
+	return rl.Vector2Lerp(closest, centroid, 0.05)
+}
+
+// voronoiCells - Splits a convex outline in the voronoi cells of random sites scattered around the impact point.
+// Sites are denser close to the impact point so fragments get smaller there
+func voronoiCells(outline []rl.Vector2, impact rl.Vector2, def FractureDef) [][]rl.Vector2 {
+	pieces := def.Pieces
+	if pieces <= 0 {
+		pieces = defaultFracturePieces
+	}
+
+	radius := def.Radius
+	if radius <= 0 {
+		for _, vertex := range outline {
+			radius = max(radius, rl.Vector2Distance(vertex, impact))
+		}
+	}
+
+	// The impact point is always a site, the others are kept inside the outline
+	random := rand.New(rand.NewSource(def.Seed))
+	sites := []rl.Vector2{impact}
+	for attempts := 0; len(sites) < pieces && attempts < pieces*20; attempts++ {
+		angle := random.Float64() * 2 * math.Pi
+		distance := radius * float32(random.Float64()*random.Float64())
+		site := rl.NewVector2(
+			impact.X+float32(math.Cos(angle))*distance,
+			impact.Y+float32(math.Sin(angle))*distance,
+		)
+		if moveInside(outline, site) == site {
+			sites = append(sites, site)
+		}
+	}
+
+	// Each cell is the outline clipped by the bisectors between its site and every other site
+	cells := make([][]rl.Vector2, 0, len(sites))
+	for i, site := range sites {
+		cell := append([]rl.Vector2(nil), outline...)
+		for j, other := range sites {
+			if i == j || len(cell) == 0 {
+				continue
+			}
+			normal := rl.Vector2Subtract(other, site)
+			middle := rl.Vector2Lerp(site, other, 0.5)
+			cell = clipOutline(cell, normal, rl.Vector2DotProduct(normal, middle))
+		}
+
+		if len(cell) >= 3 {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// clipOutline - Returns the part of an outline behind a line, the points whose projection on normal is at most
+// offset
+func clipOutline(outline []rl.Vector2, normal rl.Vector2, offset float32) []rl.Vector2 {
+	clipped := make([]rl.Vector2, 0, len(outline)+1)
+	for i, current := range outline {
+		next := outline[getNextIndex(i, len(outline))]
+		distanceCurrent := rl.Vector2DotProduct(normal, current) - offset
+		distanceNext := rl.Vector2DotProduct(normal, next) - offset
+
+		if distanceCurrent <= 0 {
+			clipped = append(clipped, current)
+		}
+		if distanceCurrent*distanceNext < 0 {
+			clipped = append(clipped, rl.Vector2Lerp(current, next, distanceCurrent/(distanceCurrent-distanceNext)))
+		}
+	}
+	return clipped
+}
+
+// simplifyOutline - Removes the vertices spanning the smallest triangles until a convex outline has at most count
+// vertices
+func simplifyOutline(outline []rl.Vector2, count int) []rl.Vector2 {
+	for len(outline) > count {
+		smallest, smallestArea := 0, float32(math.MaxFloat32)
+		for i := range outline {
+			previous := outline[(i+len(outline)-1)%len(outline)]
+			next := outline[getNextIndex(i, len(outline))]
+			if area := float32(math.Abs(float64(hullTurn(previous, outline[i], next)))); area < smallestArea {
+				smallest, smallestArea = i, area
+			}
+		}
+		outline = append(outline[:smallest], outline[smallest+1:]...)
+	}
+	return outline
+}
+
+// outlineMassData - Returns the area and centroid of an outline in any winding order
+func outlineMassData(outline []rl.Vector2) (float32, rl.Vector2) {
+	var area float32
+	var center rl.Vector2
+	for i, current := range outline {
+		next := outline[getNextIndex(i, len(outline))]
+		cross := rl.Vector2CrossProduct(current, next)
+		area += cross / 2
+		center = rl.Vector2Add(center, rl.Vector2Scale(rl.Vector2Add(current, next), cross*physacK/2))
+	}
+
+	center = rl.Vector2Scale(center, safeDiv(1, area))
+	return float32(math.Abs(float64(area))), center
+}
@@ -0,0 +1,764 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Joints constants
+const (
+	// Fraction of the joints position error corrected every step
+	jointBaumgarte = 0.2
+	// Default mouse joint spring frequency in hertz
+	defaultMouseFrequency = 5.0
+	// Default mouse joint damping ratio
+	defaultMouseDampingRatio = 0.7
+)
+
+// Joint - Constraint between two physics bodies, solved together with collision impulses
+type Joint interface {
+	// GetBodyA - Returns the first constrained physics body (nil for mouse joints)
+	GetBodyA() *Body
+	// GetBodyB - Returns the second constrained physics body
+	GetBodyB() *Body
+	// GetAnchorA - Returns the first body anchor point in world space (the target for mouse joints)
+	GetAnchorA() rl.Vector2
+	// GetAnchorB - Returns the second body anchor point in world space
+	GetAnchorB() rl.Vector2
+	// Destroy - Removes the joint from its physics world
+	Destroy()
+
+	base() *jointBase
+	initialize(dt float32)
+	solveVelocity(dt float32)
+}
+
+// jointBase - Data shared by every joint type
+type jointBase struct {
+	// First constrained physics body
+	bodyA *Body
+	// Second constrained physics body
+	bodyB *Body
+	// Allow collisions between the constrained bodies
+	CollideConnected bool
+	// Anchor point in first body local space
+	localAnchorA rl.Vector2
+	// Anchor point in second body local space
+	localAnchorB rl.Vector2
+	// Physics world the joint belongs to
+	world *World
+}
+
+// GetBodyA - Returns the first constrained physics body
+func (j *jointBase) GetBodyA() *Body {
+	return j.bodyA
+}
+
+// GetBodyB - Returns the second constrained physics body
+func (j *jointBase) GetBodyB() *Body {
+	return j.bodyB
+}
+
+// GetAnchorA - Returns the first body anchor point in world space
+func (j *jointBase) GetAnchorA() rl.Vector2 {
+	return localToWorld(j.bodyA, j.localAnchorA)
+}
+
+// GetAnchorB - Returns the second body anchor point in world space
+func (j *jointBase) GetAnchorB() rl.Vector2 {
+	return localToWorld(j.bodyB, j.localAnchorB)
+}
+
+func (j *jointBase) base() *jointBase {
+	return j
+}
+
+// DistanceJoint - Keeps the distance between two anchor points fixed or between limits (ropes)
+type DistanceJoint struct {
+	jointBase
+	// Rest length between anchors, used when MinLength is not lower than MaxLength
+	Length float32
+	// Minimum length between anchors
+	MinLength float32
+	// Maximum length between anchors
+	MaxLength float32
+
+	axis         rl.Vector2
+	currentLen   float32
+	mass         float32
+	rA, rB       rl.Vector2
+	impulse      float32
+	lowerImpulse float32
+	upperImpulse float32
+}
+
+// RevoluteJoint - Pins two bodies together at an anchor point allowing relative rotation (hinges)
+type RevoluteJoint struct {
+	jointBase
+	// Relative angle between bodies when the joint was created
+	ReferenceAngle float32
+	// Enable relative angle limits
+	EnableLimit bool
+	// Lower relative angle limit in radians
+	LowerAngle float32
+	// Upper relative angle limit in radians
+	UpperAngle float32
+	// Enable the joint motor
+	EnableMotor bool
+	// Motor target relative angular velocity
+	MotorSpeed float32
+	// Maximum torque the motor can apply
+	MaxMotorTorque float32
+
+	rA, rB       rl.Vector2
+	angle        float32
+	axialMass    float32
+	motorImpulse float32
+	lowerImpulse float32
+	upperImpulse float32
+}
+
+// PrismaticJoint - Allows relative translation of two bodies along an axis only (sliders, pistons)
+type PrismaticJoint struct {
+	jointBase
+	// Relative angle between bodies when the joint was created
+	ReferenceAngle float32
+	// Enable translation limits
+	EnableLimit bool
+	// Lower translation limit along the axis
+	LowerTranslation float32
+	// Upper translation limit along the axis
+	UpperTranslation float32
+	// Enable the joint motor
+	EnableMotor bool
+	// Motor target relative velocity along the axis
+	MotorSpeed float32
+	// Maximum force the motor can apply
+	MaxMotorForce float32
+
+	localAxis                    rl.Vector2
+	axis, perp                   rl.Vector2
+	a1, a2, s1, s2               float32
+	axialMass, perpMass, angMass float32
+	translation, angle           float32
+	perpBias                     float32
+	motorImpulse                 float32
+	lowerImpulse                 float32
+	upperImpulse                 float32
+}
+
+// WeldJoint - Glues two bodies together at an anchor point
+type WeldJoint struct {
+	jointBase
+	// Relative angle between bodies when the joint was created
+	ReferenceAngle float32
+
+	rA, rB    rl.Vector2
+	angle     float32
+	axialMass float32
+}
+
+// MouseJoint - Pulls a body anchor point towards a world target with a soft spring (mouse dragging)
+type MouseJoint struct {
+	jointBase
+	// World space target position
+	Target rl.Vector2
+	// Maximum force applied to reach the target
+	MaxForce float32
+	// Spring frequency in hertz
+	Frequency float32
+	// Spring damping ratio (0 to 1)
+	DampingRatio float32
+
+	rB      rl.Vector2
+	bias    rl.Vector2
+	gamma   float32
+	mass    rl.Mat2
+	impulse rl.Vector2
+}
+
+// NewDistanceJoint - Creates a distance joint between two world space anchor points
+func NewDistanceJoint(bodyA, bodyB *Body, anchorA, anchorB rl.Vector2) *DistanceJoint {
+	return defaultWorld.NewDistanceJoint(bodyA, bodyB, anchorA, anchorB)
+}
+
+// NewRevoluteJoint - Creates a revolute joint between two bodies at a world space anchor point
+func NewRevoluteJoint(bodyA, bodyB *Body, anchor rl.Vector2) *RevoluteJoint {
+	return defaultWorld.NewRevoluteJoint(bodyA, bodyB, anchor)
+}
+
+// NewPrismaticJoint - Creates a prismatic joint between two bodies at a world space anchor point and axis
+func NewPrismaticJoint(bodyA, bodyB *Body, anchor, axis rl.Vector2) *PrismaticJoint {
+	return defaultWorld.NewPrismaticJoint(bodyA, bodyB, anchor, axis)
+}
+
+// NewWeldJoint - Creates a weld joint between two bodies at a world space anchor point
+func NewWeldJoint(bodyA, bodyB *Body, anchor rl.Vector2) *WeldJoint {
+	return defaultWorld.NewWeldJoint(bodyA, bodyB, anchor)
+}
+
+// NewMouseJoint - Creates a mouse joint pulling a body from a world space point towards the same target
+func NewMouseJoint(body *Body, target rl.Vector2, maxForce float32) *MouseJoint {
+	return defaultWorld.NewMouseJoint(body, target, maxForce)
+}
+
+// GetJoints - Returns the slice of created joints
+func GetJoints() []Joint {
+	return defaultWorld.GetJoints()
+}
+
+// NewDistanceJoint - Creates a distance joint between two world space anchor points. Returns nil when a body is nil or
+// belongs to another world
+func (w *World) NewDistanceJoint(bodyA, bodyB *Body, anchorA, anchorB rl.Vector2) *DistanceJoint {
+	if !w.owns(bodyA) || !w.owns(bodyB) {
+		return nil
+	}
+
+	length := rl.Vector2Distance(anchorA, anchorB)
+	joint := &DistanceJoint{
+		jointBase: jointBase{bodyA: bodyA, bodyB: bodyB, localAnchorA: worldToLocal(bodyA, anchorA), localAnchorB: worldToLocal(bodyB, anchorB)},
+		Length:    length,
+		MinLength: length,
+		MaxLength: length,
+	}
+	w.addJoint(joint)
+	return joint
+}
+
+// NewRevoluteJoint - Creates a revolute joint between two bodies at a world space anchor point. Returns nil when a body
+// is nil or belongs to another world
+func (w *World) NewRevoluteJoint(bodyA, bodyB *Body, anchor rl.Vector2) *RevoluteJoint {
+	if !w.owns(bodyA) || !w.owns(bodyB) {
+		return nil
+	}
+
+	joint := &RevoluteJoint{
+		jointBase:      jointBase{bodyA: bodyA, bodyB: bodyB, localAnchorA: worldToLocal(bodyA, anchor), localAnchorB: worldToLocal(bodyB, anchor)},
+		ReferenceAngle: bodyB.Orient - bodyA.Orient,
+	}
+	w.addJoint(joint)
+	return joint
+}
+
+// NewPrismaticJoint - Creates a prismatic joint between two bodies at a world space anchor point and axis. Returns nil
+// when a body is nil or belongs to another world
+func (w *World) NewPrismaticJoint(bodyA, bodyB *Body, anchor, axis rl.Vector2) *PrismaticJoint {
+	if !w.owns(bodyA) || !w.owns(bodyB) {
+		return nil
+	}
+
+	normalize(&axis)
+	joint := &PrismaticJoint{
+		jointBase:      jointBase{bodyA: bodyA, bodyB: bodyB, localAnchorA: worldToLocal(bodyA, anchor), localAnchorB: worldToLocal(bodyB, anchor)},
+		ReferenceAngle: bodyB.Orient - bodyA.Orient,
+		localAxis:      rotate(axis, -bodyA.Orient),
+	}
+	w.addJoint(joint)
+	return joint
+}
+
+// NewWeldJoint - Creates a weld joint between two bodies at a world space anchor point. Returns nil when a body is nil
+// or belongs to another world
+func (w *World) NewWeldJoint(bodyA, bodyB *Body, anchor rl.Vector2) *WeldJoint {
+	if !w.owns(bodyA) || !w.owns(bodyB) {
+		return nil
+	}
+
+	joint := &WeldJoint{
+		jointBase:      jointBase{bodyA: bodyA, bodyB: bodyB, localAnchorA: worldToLocal(bodyA, anchor), localAnchorB: worldToLocal(bodyB, anchor)},
+		ReferenceAngle: bodyB.Orient - bodyA.Orient,
+	}
+	w.addJoint(joint)
+	return joint
+}
+
+// NewMouseJoint - Creates a mouse joint pulling a body from a world space point towards the same target. Returns nil
+// when a body is nil or belongs to another world
+func (w *World) NewMouseJoint(body *Body, target rl.Vector2, maxForce float32) *MouseJoint {
+	if !w.owns(body) {
+		return nil
+	}
+
+	joint := &MouseJoint{
+		jointBase:    jointBase{bodyB: body, localAnchorB: worldToLocal(body, target)},
+		Target:       target,
+		MaxForce:     maxForce,
+		Frequency:    defaultMouseFrequency,
+		DampingRatio: defaultMouseDampingRatio,
+	}
+	w.addJoint(joint)
+	return joint
+}
+
+// GetJoints - Returns the slice of created joints
+func (w *World) GetJoints() []Joint {
+	return w.joints
+}
+
+// owns - Checks if a body belongs to the world, nil and destroyed bodies do not
+func (w *World) owns(body *Body) bool {
+	return body != nil && body.world == w
+}
+
+// addJoint - Adds a joint to the world and to its bodies joints lists
+func (w *World) addJoint(joint Joint) {
+	base := joint.base()
+	base.world = w
+	if base.bodyA != nil {
+		base.bodyA.joints = append(base.bodyA.joints, joint)
+		base.bodyA.Wake()
+	}
+	if base.bodyB != nil {
+		base.bodyB.joints = append(base.bodyB.joints, joint)
+		base.bodyB.Wake()
+	}
+	w.joints = append(w.joints, joint)
+}
+
+// Destroy - Removes the joint from its physics world
+func (j *DistanceJoint) Destroy() { destroyJoint(j) }
+
+// Destroy - Removes the joint from its physics world
+func (j *RevoluteJoint) Destroy() { destroyJoint(j) }
+
+// Destroy - Removes the joint from its physics world
+func (j *PrismaticJoint) Destroy() { destroyJoint(j) }
+
+// Destroy - Removes the joint from its physics world
+func (j *WeldJoint) Destroy() { destroyJoint(j) }
+
+// Destroy - Removes the joint from its physics world
+func (j *MouseJoint) Destroy() { destroyJoint(j) }
+
+// destroyJoint - Removes a joint from its world and from its bodies joints lists
+func destroyJoint(joint Joint) {
+	base := joint.base()
+	w := base.world
+	if w == nil {
+		return
+	}
+
+	w.joints = removeJoint(w.joints, joint)
+	if base.bodyA != nil {
+		base.bodyA.joints = removeJoint(base.bodyA.joints, joint)
+		base.bodyA.Wake()
+	}
+	if base.bodyB != nil {
+		base.bodyB.joints = removeJoint(base.bodyB.joints, joint)
+		base.bodyB.Wake()
+	}
+	base.world = nil
+}
+
+// removeJoint - Removes a joint from a joints slice keeping the order
+func removeJoint(joints []Joint, joint Joint) []Joint {
+	for i := range joints {
+		if joints[i] == joint {
+			copy(joints[i:], joints[i+1:])
+			joints[len(joints)-1] = nil
+			return joints[:len(joints)-1]
+		}
+	}
+	return joints
+}
+
+// isConnected - Checks if two bodies are constrained by a joint that disables their collisions
+func isConnected(bodyA, bodyB *Body) bool {
+	joints := bodyA.joints
+	if len(bodyB.joints) < len(joints) {
+		joints = bodyB.joints
+	}
+
+	for _, joint := range joints {
+		base := joint.base()
+		if base.CollideConnected {
+			continue
+		}
+		if (base.bodyA == bodyA && base.bodyB == bodyB) || (base.bodyA == bodyB && base.bodyB == bodyA) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCurrentLength - Returns the current distance between the joint anchors
+func (j *DistanceJoint) GetCurrentLength() float32 {
+	return rl.Vector2Distance(j.GetAnchorA(), j.GetAnchorB())
+}
+
+func (j *DistanceJoint) initialize(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+	j.rA = rotate(j.localAnchorA, bodyA.Orient)
+	j.rB = rotate(j.localAnchorB, bodyB.Orient)
+
+	d := rl.Vector2Subtract(rl.Vector2Add(bodyB.Position, j.rB), rl.Vector2Add(bodyA.Position, j.rA))
+	j.currentLen = rl.Vector2Length(d)
+	j.axis = d
+	normalize(&j.axis)
+
+	crA := rl.Vector2CrossProduct(j.rA, j.axis)
+	crB := rl.Vector2CrossProduct(j.rB, j.axis)
+	j.mass = safeDiv(1, bodyA.invMass()+bodyB.invMass()+bodyA.invInertia()*crA*crA+bodyB.invInertia()*crB*crB)
+
+	j.impulse = 0
+	j.lowerImpulse = 0
+	j.upperImpulse = 0
+}
+
+func (j *DistanceJoint) solveVelocity(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+	if j.mass == 0 {
+		return
+	}
+
+	cdot := rl.Vector2DotProduct(j.axis, relativeVelocity(bodyA, bodyB, j.rA, j.rB))
+
+	if j.MinLength >= j.MaxLength {
+		// Rigid rod
+		c := j.currentLen - j.Length
+		impulse := -j.mass * (cdot + jointBaumgarte/dt*c)
+		j.impulse += impulse
+		applyImpulsePair(bodyA, bodyB, j.rA, j.rB, rl.Vector2Scale(j.axis, impulse))
+		return
+	}
+
+	// Lower limit, pushes anchors apart
+	c := j.currentLen - j.MinLength
+	impulse := -j.mass * (cdot + limitBias(c, dt))
+	newImpulse := max(j.lowerImpulse+impulse, 0)
+	impulse = newImpulse - j.lowerImpulse
+	j.lowerImpulse = newImpulse
+	applyImpulsePair(bodyA, bodyB, j.rA, j.rB, rl.Vector2Scale(j.axis, impulse))
+
+	// Upper limit, pulls anchors together
+	cdot = rl.Vector2DotProduct(j.axis, relativeVelocity(bodyA, bodyB, j.rA, j.rB))
+	c = j.MaxLength - j.currentLen
+	impulse = -j.mass * (-cdot + limitBias(c, dt))
+	newImpulse = max(j.upperImpulse+impulse, 0)
+	impulse = newImpulse - j.upperImpulse
+	j.upperImpulse = newImpulse
+	applyImpulsePair(bodyA, bodyB, j.rA, j.rB, rl.Vector2Scale(j.axis, -impulse))
+}
+
+// GetJointAngle - Returns the current relative angle between the bodies
+func (j *RevoluteJoint) GetJointAngle() float32 {
+	return j.bodyB.Orient - j.bodyA.Orient - j.ReferenceAngle
+}
+
+// GetJointSpeed - Returns the current relative angular velocity between the bodies
+func (j *RevoluteJoint) GetJointSpeed() float32 {
+	return j.bodyB.AngularVelocity - j.bodyA.AngularVelocity
+}
+
+func (j *RevoluteJoint) initialize(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+	j.rA = rotate(j.localAnchorA, bodyA.Orient)
+	j.rB = rotate(j.localAnchorB, bodyB.Orient)
+	j.angle = j.GetJointAngle()
+	j.axialMass = safeDiv(1, bodyA.invInertia()+bodyB.invInertia())
+
+	j.motorImpulse = 0
+	j.lowerImpulse = 0
+	j.upperImpulse = 0
+}
+
+func (j *RevoluteJoint) solveVelocity(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+
+	// Solve motor constraint
+	if j.EnableMotor {
+		cdot := bodyB.AngularVelocity - bodyA.AngularVelocity - j.MotorSpeed
+		impulse := -j.axialMass * cdot
+		maxImpulse := j.MaxMotorTorque * dt
+		newImpulse := clamp(j.motorImpulse+impulse, -maxImpulse, maxImpulse)
+		impulse = newImpulse - j.motorImpulse
+		j.motorImpulse = newImpulse
+		applyAngularImpulsePair(bodyA, bodyB, impulse)
+	}
+
+	// Solve angle limits
+	if j.EnableLimit {
+		j.lowerImpulse, j.upperImpulse = solveAngularLimits(bodyA, bodyB, j.axialMass, j.angle, j.LowerAngle, j.UpperAngle, j.lowerImpulse, j.upperImpulse, dt)
+	}
+
+	// Solve point to point constraint
+	solvePointConstraint(bodyA, bodyB, j.rA, j.rB, dt)
+}
+
+// GetJointTranslation - Returns the current translation along the joint axis
+func (j *PrismaticJoint) GetJointTranslation() float32 {
+	d := rl.Vector2Subtract(j.GetAnchorB(), j.GetAnchorA())
+	return rl.Vector2DotProduct(d, rotate(j.localAxis, j.bodyA.Orient))
+}
+
+func (j *PrismaticJoint) initialize(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+	rA := rotate(j.localAnchorA, bodyA.Orient)
+	rB := rotate(j.localAnchorB, bodyB.Orient)
+	d := rl.Vector2Subtract(rl.Vector2Add(bodyB.Position, rB), rl.Vector2Add(bodyA.Position, rA))
+
+	mA, mB := bodyA.invMass(), bodyB.invMass()
+	iA, iB := bodyA.invInertia(), bodyB.invInertia()
+
+	j.axis = rotate(j.localAxis, bodyA.Orient)
+	j.a1 = rl.Vector2CrossProduct(rl.Vector2Add(d, rA), j.axis)
+	j.a2 = rl.Vector2CrossProduct(rB, j.axis)
+	j.axialMass = safeDiv(1, mA+mB+iA*j.a1*j.a1+iB*j.a2*j.a2)
+
+	j.perp = rl.NewVector2(-j.axis.Y, j.axis.X)
+	j.s1 = rl.Vector2CrossProduct(rl.Vector2Add(d, rA), j.perp)
+	j.s2 = rl.Vector2CrossProduct(rB, j.perp)
+	j.perpMass = safeDiv(1, mA+mB+iA*j.s1*j.s1+iB*j.s2*j.s2)
+	j.perpBias = jointBaumgarte / dt * rl.Vector2DotProduct(j.perp, d)
+
+	j.angMass = safeDiv(1, iA+iB)
+	j.translation = rl.Vector2DotProduct(j.axis, d)
+	j.angle = bodyB.Orient - bodyA.Orient - j.ReferenceAngle
+
+	j.motorImpulse = 0
+	j.lowerImpulse = 0
+	j.upperImpulse = 0
+}
+
+func (j *PrismaticJoint) solveVelocity(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+
+	// axisVelocity - Returns the relative velocity along the joint axis
+	axisVelocity := func() float32 {
+		return rl.Vector2DotProduct(j.axis, rl.Vector2Subtract(bodyB.Velocity, bodyA.Velocity)) +
+			j.a2*bodyB.AngularVelocity - j.a1*bodyA.AngularVelocity
+	}
+
+	// Solve motor constraint
+	if j.EnableMotor {
+		impulse := -j.axialMass * (axisVelocity() - j.MotorSpeed)
+		maxImpulse := j.MaxMotorForce * dt
+		newImpulse := clamp(j.motorImpulse+impulse, -maxImpulse, maxImpulse)
+		impulse = newImpulse - j.motorImpulse
+		j.motorImpulse = newImpulse
+		applyAxialImpulse(bodyA, bodyB, j.axis, j.a1, j.a2, impulse)
+	}
+
+	// Solve translation limits
+	if j.EnableLimit {
+		c := j.translation - j.LowerTranslation
+		impulse := -j.axialMass * (axisVelocity() + limitBias(c, dt))
+		newImpulse := max(j.lowerImpulse+impulse, 0)
+		impulse = newImpulse - j.lowerImpulse
+		j.lowerImpulse = newImpulse
+		applyAxialImpulse(bodyA, bodyB, j.axis, j.a1, j.a2, impulse)
+
+		c = j.UpperTranslation - j.translation
+		impulse = -j.axialMass * (-axisVelocity() + limitBias(c, dt))
+		newImpulse = max(j.upperImpulse+impulse, 0)
+		impulse = newImpulse - j.upperImpulse
+		j.upperImpulse = newImpulse
+		applyAxialImpulse(bodyA, bodyB, j.axis, j.a1, j.a2, -impulse)
+	}
+
+	// Solve perpendicular constraint
+	cdot := rl.Vector2DotProduct(j.perp, rl.Vector2Subtract(bodyB.Velocity, bodyA.Velocity)) +
+		j.s2*bodyB.AngularVelocity - j.s1*bodyA.AngularVelocity
+	applyAxialImpulse(bodyA, bodyB, j.perp, j.s1, j.s2, -j.perpMass*(cdot+j.perpBias))
+
+	// Solve angular constraint
+	cdot = bodyB.AngularVelocity - bodyA.AngularVelocity
+	applyAngularImpulsePair(bodyA, bodyB, -j.angMass*(cdot+jointBaumgarte/dt*j.angle))
+}
+
+func (j *WeldJoint) initialize(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+	j.rA = rotate(j.localAnchorA, bodyA.Orient)
+	j.rB = rotate(j.localAnchorB, bodyB.Orient)
+	j.angle = bodyB.Orient - bodyA.Orient - j.ReferenceAngle
+	j.axialMass = safeDiv(1, bodyA.invInertia()+bodyB.invInertia())
+}
+
+func (j *WeldJoint) solveVelocity(dt float32) {
+	bodyA, bodyB := j.bodyA, j.bodyB
+
+	// Solve angular constraint
+	cdot := bodyB.AngularVelocity - bodyA.AngularVelocity
+	applyAngularImpulsePair(bodyA, bodyB, -j.axialMass*(cdot+jointBaumgarte/dt*j.angle))
+
+	// Solve point to point constraint
+	solvePointConstraint(bodyA, bodyB, j.rA, j.rB, dt)
+}
+
+// GetAnchorA - Returns the joint target since mouse joints have no first body
+func (j *MouseJoint) GetAnchorA() rl.Vector2 {
+	return j.Target
+}
+
+// SetTarget - Sets the world space position the body is pulled towards
+func (j *MouseJoint) SetTarget(target rl.Vector2) {
+	j.bodyB.Wake()
+	j.Target = target
+}
+
+func (j *MouseJoint) initialize(dt float32) {
+	body := j.bodyB
+	j.rB = rotate(j.localAnchorB, body.Orient)
+	j.impulse = rl.Vector2{}
+
+	mass := safeDiv(1, body.invMass())
+	if mass == 0 {
+		j.mass = rl.Mat2{}
+		return
+	}
+
+	// Spring stiffness and damping, frequency is converted to the milliseconds time step unit
+	omega := 2 * math.Pi * j.Frequency / 1000
+	damping := 2 * mass * j.DampingRatio * omega
+	stiffness := mass * omega * omega
+	j.gamma = safeDiv(1, dt*(damping+dt*stiffness))
+	beta := dt * stiffness * j.gamma
+
+	invM, invI := body.invMass(), body.invInertia()
+	k := rl.NewMat2(
+		invM+invI*j.rB.Y*j.rB.Y+j.gamma, -invI*j.rB.X*j.rB.Y,
+		-invI*j.rB.X*j.rB.Y, invM+invI*j.rB.X*j.rB.X+j.gamma,
+	)
+	j.mass = invertMat2(k)
+
+	c := rl.Vector2Subtract(rl.Vector2Add(body.Position, j.rB), j.Target)
+	j.bias = rl.Vector2Scale(c, beta)
+}
+
+func (j *MouseJoint) solveVelocity(dt float32) {
+	body := j.bodyB
+
+	cdot := rl.Vector2Add(body.Velocity, rl.Vector2Cross(body.AngularVelocity, j.rB))
+	rhs := rl.Vector2Add(rl.Vector2Add(cdot, j.bias), rl.Vector2Scale(j.impulse, j.gamma))
+	impulse := rl.Mat2MultiplyVector2(j.mass, rl.Vector2Negate(rhs))
+
+	// Clamp accumulated impulse to the maximum force
+	oldImpulse := j.impulse
+	j.impulse = rl.Vector2Add(j.impulse, impulse)
+	maxImpulse := j.MaxForce * dt
+	if rl.Vector2LengthSqr(j.impulse) > maxImpulse*maxImpulse {
+		j.impulse = rl.Vector2Scale(j.impulse, maxImpulse/rl.Vector2Length(j.impulse))
+	}
+	impulse = rl.Vector2Subtract(j.impulse, oldImpulse)
+
+	applyImpulse(body, impulse, j.rB)
+}
+
+// solvePointConstraint - Solves a point to point constraint between two bodies anchors
+func solvePointConstraint(bodyA, bodyB *Body, rA, rB rl.Vector2, dt float32) {
+	mA, mB := bodyA.invMass(), bodyB.invMass()
+	iA, iB := bodyA.invInertia(), bodyB.invInertia()
+
+	k := rl.NewMat2(
+		mA+mB+rA.Y*rA.Y*iA+rB.Y*rB.Y*iB, -rA.Y*rA.X*iA-rB.Y*rB.X*iB,
+		-rA.Y*rA.X*iA-rB.Y*rB.X*iB, mA+mB+rA.X*rA.X*iA+rB.X*rB.X*iB,
+	)
+
+	c := rl.Vector2Subtract(rl.Vector2Add(bodyB.Position, rB), rl.Vector2Add(bodyA.Position, rA))
+	cdot := relativeVelocity(bodyA, bodyB, rA, rB)
+	rhs := rl.Vector2Add(cdot, rl.Vector2Scale(c, jointBaumgarte/dt))
+
+	impulse := rl.Mat2MultiplyVector2(invertMat2(k), rl.Vector2Negate(rhs))
+	applyImpulsePair(bodyA, bodyB, rA, rB, impulse)
+}
+
+// solveAngularLimits - Solves lower and upper relative angle limits, returns the new accumulated impulses
+func solveAngularLimits(bodyA, bodyB *Body, axialMass, angle, lower, upper, lowerImpulse, upperImpulse, dt float32) (float32, float32) {
+	// Lower limit
+	c := angle - lower
+	cdot := bodyB.AngularVelocity - bodyA.AngularVelocity
+	impulse := -axialMass * (cdot + limitBias(c, dt))
+	newImpulse := max(lowerImpulse+impulse, 0)
+	impulse = newImpulse - lowerImpulse
+	lowerImpulse = newImpulse
+	applyAngularImpulsePair(bodyA, bodyB, impulse)
+
+	// Upper limit
+	c = upper - angle
+	cdot = bodyA.AngularVelocity - bodyB.AngularVelocity
+	impulse = -axialMass * (cdot + limitBias(c, dt))
+	newImpulse = max(upperImpulse+impulse, 0)
+	impulse = newImpulse - upperImpulse
+	upperImpulse = newImpulse
+	applyAngularImpulsePair(bodyA, bodyB, -impulse)
+
+	return lowerImpulse, upperImpulse
+}
+
+// limitBias - Returns the velocity bias of an inequality constraint given its position error
+func limitBias(c, dt float32) float32 {
+	// Speculative when the limit is not reached yet, Baumgarte correction otherwise
+	if c > 0 {
+		return c / dt
+	}
+	return jointBaumgarte / dt * c
+}
+
+// relativeVelocity - Returns the velocity of the second anchor relative to the first one
+func relativeVelocity(bodyA, bodyB *Body, rA, rB rl.Vector2) rl.Vector2 {
+	return rl.Vector2Subtract(
+		rl.Vector2Add(bodyB.Velocity, rl.Vector2Cross(bodyB.AngularVelocity, rB)),
+		rl.Vector2Add(bodyA.Velocity, rl.Vector2Cross(bodyA.AngularVelocity, rA)),
+	)
+}
+
+// applyImpulse - Applies a linear impulse to a body at an offset from its center
+func applyImpulse(body *Body, impulse rl.Vector2, r rl.Vector2) {
+	body.Velocity.X += body.invMass() * impulse.X
+	body.Velocity.Y += body.invMass() * impulse.Y
+	body.AngularVelocity += body.invInertia() * rl.Vector2CrossProduct(r, impulse)
+}
+
+// applyImpulsePair - Applies opposite linear impulses to two bodies anchors
+func applyImpulsePair(bodyA, bodyB *Body, rA, rB rl.Vector2, impulse rl.Vector2) {
+	applyImpulse(bodyA, rl.Vector2Negate(impulse), rA)
+	applyImpulse(bodyB, impulse, rB)
+}
+
+// applyAngularImpulsePair - Applies opposite angular impulses to two bodies
+func applyAngularImpulsePair(bodyA, bodyB *Body, impulse float32) {
+	bodyA.AngularVelocity -= bodyA.invInertia() * impulse
+	bodyB.AngularVelocity += bodyB.invInertia() * impulse
+}
+
+// applyAxialImpulse - Applies an impulse along an axis with precomputed angular arms
+func applyAxialImpulse(bodyA, bodyB *Body, axis rl.Vector2, armA, armB, impulse float32) {
+	bodyA.Velocity.X -= bodyA.invMass() * axis.X * impulse
+	bodyA.Velocity.Y -= bodyA.invMass() * axis.Y * impulse
+	bodyA.AngularVelocity -= bodyA.invInertia() * armA * impulse
+
+	bodyB.Velocity.X += bodyB.invMass() * axis.X * impulse
+	bodyB.Velocity.Y += bodyB.invMass() * axis.Y * impulse
+	bodyB.AngularVelocity += bodyB.invInertia() * armB * impulse
+}
+
+// invertMat2 - Returns the inverse of a matrix 2x2 or a zero matrix if it is singular
+func invertMat2(m rl.Mat2) rl.Mat2 {
+	det := m.M00*m.M11 - m.M01*m.M10
+	if det == 0 {
+		return rl.Mat2{}
+	}
+	det = 1 / det
+	return rl.NewMat2(det*m.M11, -det*m.M01, -det*m.M10, det*m.M00)
+}
+
+// rotate - Returns a vector rotated by an angle in radians
+func rotate(v rl.Vector2, radians float32) rl.Vector2 {
+	return rl.Mat2MultiplyVector2(rl.Mat2Radians(radians), v)
+}
+
+// worldToLocal - Transforms a world space point into a body local space
+func worldToLocal(body *Body, point rl.Vector2) rl.Vector2 {
+	return rotate(rl.Vector2Subtract(point, body.Position), -body.Orient)
+}
+
+// localToWorld - Transforms a body local space point into world space
+func localToWorld(body *Body, point rl.Vector2) rl.Vector2 {
+	return rl.Vector2Add(body.Position, rotate(point, body.Orient))
+}
+
+// clamp - Clamps a value between a minimum and a maximum
+func clamp(value, minValue, maxValue float32) float32 {
+	return max(minValue, min(value, maxValue))
+}
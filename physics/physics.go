@@ -6,6 +6,7 @@ package physics
 import (
 	"math"
 	"math/rand"
+	"sort"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -20,6 +21,21 @@ const (
 	CircleShape ShapeType = iota
 	// Polygon type
 	PolygonShape
+	// One-sided edge type, used for static terrain
+	EdgeShape
+)
+
+// BodyType type
+type BodyType int
+
+// Physics body types
+const (
+	// Never moves, behaves as if it had infinite mass
+	StaticBody BodyType = iota
+	// Moved by its velocity only, unaffected by forces and collisions but pushes dynamic bodies
+	KinematicBody
+	// Moved by forces, gravity and collisions
+	DynamicBody
 )
 
 // Polygon type
@@ -34,7 +50,7 @@ type Polygon struct {
 
 // Shape type
 type Shape struct {
-	// Physics shape type (circle or polygon)
+	// Physics shape type (circle, polygon or edge)
 	Type ShapeType
 	// Shape physics body reference
 	Body *Body
@@ -42,14 +58,26 @@ type Shape struct {
 	Radius float32
 	// Vertices transform matrix 2x2
 	Transform rl.Mat2
-	// Polygon shape vertices position and normals data (just used for polygon shapes)
+	// Polygon shape vertices position and normals data (used for polygon and edge shapes)
 	VertexData Polygon
+	// Shape pivot position in body local space (used for compound bodies fixtures)
+	Offset rl.Vector2
+	// Friction when the shape has not movement (0 to 1), the body main shape uses the body value
+	StaticFriction float32
+	// Friction when the shape has movement (0 to 1), the body main shape uses the body value
+	DynamicFriction float32
+	// Restitution coefficient of the shape (0 to 1), the body main shape uses the body value
+	Restitution float32
+	// Chain edge joined smoothly to its neighbour edge at the first and second vertex (used for edge shapes)
+	SmoothJoints [2]bool
 }
 
 // Body type
 type Body struct {
 	// Reference unique identifier
 	ID int
+	// Physics body type (static, kinematic or dynamic)
+	Type BodyType
 	// Enabled dynamics state (collisions are calculated anyway)
 	Enabled bool
 	// Physics body shape pivot
@@ -84,8 +112,37 @@ type Body struct {
 	IsGrounded bool
 	// Physics rotation constraint
 	FreezeOrient bool
-	// Physics body shape information (type, radius, vertices, normals)
+	// Continuous collision state, bullets are swept against static and kinematic bodies so they can not tunnel
+	// through them when moving fast
+	IsBullet bool
+	// Physics body can fall asleep when it comes to rest
+	AllowSleep bool
+	// Sleeping state, sleeping bodies are not simulated until woken
+	IsSleeping bool
+	// Collision category bits, usually a single bit
+	CategoryBits uint16
+	// Collision mask bits, categories the body collides with
+	MaskBits uint16
+	// Collision group index, overrides categories and masks when not zero
+	GroupIndex int16
+	// Callbacks called when the body starts, keeps and stops touching other bodies
+	ContactListener ContactListener
+	// Sensor state, sensors report overlaps but generate no collision response
+	IsSensor bool
+	// Callbacks called when bodies enter and exit the body (used for sensor bodies)
+	SensorListener SensorListener
+	// Physics body main shape information (type, radius, vertices, normals)
 	Shape Shape
+	// Shapes attached to the body, the main shape first
+	fixtures []*Shape
+	// Joints attached to the body
+	joints []Joint
+	// Time the body has been resting, in milliseconds
+	sleepTime float32
+	// Body index in the island parents of the current step
+	islandIndex int
+	// Physics world the body belongs to
+	world *World
 }
 
 // Manifold type
@@ -96,6 +153,10 @@ type Manifold struct {
 	BodyA *Body
 	// Manifold second physics body reference
 	BodyB *Body
+	// Manifold first body shape reference
+	ShapeA *Shape
+	// Manifold second body shape reference
+	ShapeB *Shape
 	// Depth of penetration from collision
 	Penetration float32
 	// Normal direction vector from 'a' to 'b'
@@ -110,18 +171,107 @@ type Manifold struct {
 	DynamicFriction float32
 	// Mixed static friction during collision
 	StaticFriction float32
+	// Normal impulse accumulated at each point of contact during the step, carried over to the next step
+	NormalImpulses [2]float32
+	// Friction impulse accumulated at each point of contact during the step, carried over to the next step
+	TangentImpulses [2]float32
+	// Overlap between a sensor and another body, solved for overlap only
+	IsSensor bool
+	// Collision solved in the current step, the pre-solve callback can clear it to let the bodies pass through
+	Enabled bool
+	// Relative tangent velocity of body B over body A targeted by friction, along the manifold tangent. Reset every
+	// step before the pre-solve callback, used for conveyor belts
+	TangentSpeed float32
+	// Relative normal velocity targeted at each point of contact, used for restitution
+	velocityBias [2]float32
+	// Bodies positions before correcting positions, used to track the penetration left
+	positionA, positionB rl.Vector2
+}
+
+// manifoldKey - Identifies the manifold of a pair of shapes
+type manifoldKey struct {
+	a, b *Shape
+}
+
+// World type
+type World struct {
+	// Start time in milliseconds
+	startTime float32
+	// Delta time used for physics steps, in milliseconds
+	deltaTime float32
+	// Current time in milliseconds
+	currentTime float32
+	// Physics time step delta time accumulator
+	accumulator float32
+	// Physics world gravity force
+	gravityForce rl.Vector2
+	// Physics bodies pointers slice
+	bodies []*Body
+	// Physics body identifiers in use and released for reuse
+	bodyIDs idPool
+	// Physics manifolds pointers slice
+	manifolds []*Manifold
+	// Previous step manifolds, reused to avoid allocations
+	manifoldsBuffer []*Manifold
+	// Previous step manifolds by shapes pair, reused to avoid allocations
+	manifoldCache map[manifoldKey]*Manifold
+	// Number of iterations solving velocities every step
+	velocityIterations int
+	// Number of iterations correcting positions every step
+	positionIterations int
+	// Manifolds impulses are not carried over to the next step
+	warmStartingDisabled bool
+	// Physics manifold identifiers in use and released for reuse
+	manifoldIDs idPool
+	// Physics joints pointers slice
+	joints []Joint
+	// Force field regions acting on bodies every step
+	forceFields []*ForceField
+	// Broadphase used to find potentially colliding bodies
+	broadphase broadphase
+	// Bodies bounding boxes computed every step
+	bounds []AABB
+	// Potentially colliding bodies pairs found every step
+	pairs []bodyPair
+	// User callback that can veto contacts between bodies pairs
+	contactFilter ContactFilter
+	// User callback called before solving every manifold
+	preSolve PreSolve
+	// Callbacks called when bodies start, keep and stop touching
+	contactListener ContactListener
+	// Callbacks called when bodies enter and exit sensor bodies
+	sensorListener SensorListener
+	// Contacts between touching bodies found in the last step
+	contacts []Contact
+	// Contacts indices by bodies pair
+	contactIndex map[contactKey]int
+	// Previous step contacts, reused to avoid allocations
+	contactsBuffer []Contact
+	// Contact events waiting to be dispatched, reused to avoid allocations
+	contactEvents []contactEvent
+	// Bullet bodies start positions of the current step, reused to avoid allocations
+	bullets []bulletSweep
+	// Resting bodies never fall asleep
+	sleepDisabled bool
+	// Island parent index of every body, reused to avoid allocations
+	islandParents []int
+	// Islands awake state by root index, reused to avoid allocations
+	islandAwake []bool
+	// Islands shortest resting time by root index, reused to avoid allocations
+	islandSleepTimes []float32
 }
 
 // Constants
 const (
-	maxBodies      = 64
-	maxManifolds   = 4096
 	maxVertices    = 24
 	circleVertices = 24
 
-	collisionIterations   = 100
-	penetrationAllowance  = 0.05
-	penetrationCorrection = 0.4
+	defaultVelocityIterations = 10
+	defaultPositionIterations = 1
+	penetrationAllowance      = 0.05
+	penetrationCorrection     = 0.4
+	contactMatchDistance      = 1.0
+	contactMatchCos           = 0.9
 
 	degToRad = math.Pi / 180
 	epsilon  = 0.000001
@@ -133,59 +283,154 @@ var (
 	// Offset time for MONOTONIC clock
 	baseTime = time.Now()
 
-	// Start time in milliseconds
-	startTime float32
+	// Hi-res clock frequency
+	frequency uint64 = 0
 
-	// Delta time used for physics steps, in milliseconds
-	deltaTime float32 = 1.0 / 60.0 / 10.0 * 1000
+	// Physics world used by the package-level functions
+	defaultWorld = NewWorld()
+)
 
-	// Current time in milliseconds
-	currentTime float32
+// NewWorld - Creates a new physics world with default gravity and time step
+func NewWorld() *World {
+	return &World{
+		deltaTime:    1.0 / 60.0 / 10.0 * 1000,
+		gravityForce: rl.NewVector2(0, 9.81),
+		broadphase:   &sweepAndPrune{},
 
-	// Hi-res clock frequency
-	frequency uint64 = 0
+		velocityIterations: defaultVelocityIterations,
+		positionIterations: defaultPositionIterations,
+	}
+}
 
-	// Physics time step delta time accumulator
-	accumulator float32
+// Init - Initializes physics values, pointers and creates physics loop thread
+func Init() {
+	defaultWorld.Init()
+}
 
-	// Physics world gravity force
-	gravityForce = rl.NewVector2(0, 9.81)
+// SetGravity - Sets physics global gravity force
+func SetGravity(x, y float32) {
+	defaultWorld.SetGravity(x, y)
+}
 
-	// Physics bodies pointers array
-	bodies [64]*Body
+// SetIterations - Sets the number of velocity and position solver iterations run every step
+func SetIterations(velocityIterations, positionIterations int) {
+	defaultWorld.SetIterations(velocityIterations, positionIterations)
+}
 
-	// Physics world current bodies counter
-	bodiesCount int
+// SetWarmStarting - Enables or disables carrying contact impulses over to the next step
+func SetWarmStarting(enabled bool) {
+	defaultWorld.SetWarmStarting(enabled)
+}
 
-	// Physics bodies pointers array
-	manifolds [4096]*Manifold
+// NewBodyCircle - Creates a new circle physics body with generic parameters
+func NewBodyCircle(pos rl.Vector2, radius, density float32) *Body {
+	return defaultWorld.NewBodyCircle(pos, radius, density)
+}
 
-	// Physics world current manifolds counter
-	manifoldsCount int
-)
+// NewBodyRectangle - Creates a new rectangle physics body with generic parameters
+func NewBodyRectangle(pos rl.Vector2, width, height, density float32) *Body {
+	return defaultWorld.NewBodyRectangle(pos, width, height, density)
+}
+
+// NewBodyPolygon - Creates a new polygon physics body with generic parameters
+func NewBodyPolygon(pos rl.Vector2, radius float32, sides int, density float32) *Body {
+	return defaultWorld.NewBodyPolygon(pos, radius, sides, density)
+}
+
+// Reset - Destroys created physics bodies and manifolds
+func Reset() {
+	defaultWorld.Reset()
+}
+
+// Shatter - Shatters a polygon shape physics body to little physics bodies with explosion force
+func Shatter(body *Body, position rl.Vector2, force float32) {
+	defaultWorld.Shatter(body, position, force)
+}
+
+// GetBodies - Returns the slice of created physics bodies
+func GetBodies() []*Body {
+	return defaultWorld.GetBodies()
+}
+
+// GetBodiesCount - Returns the current amount of created physics bodies
+func GetBodiesCount() int {
+	return defaultWorld.GetBodiesCount()
+}
+
+// GetBody - Returns a physics body of the bodies pool at a specific index
+func GetBody(index int) *Body {
+	return defaultWorld.GetBody(index)
+}
+
+// GetBodyByID - Returns the physics body with the given identifier or nil if there is none
+func GetBodyByID(id int) *Body {
+	return defaultWorld.GetBodyByID(id)
+}
+
+// GetShapeType - Returns the physics body shape type (PHYSICS_CIRCLE or PHYSICS_POLYGON)
+func GetShapeType(index int) ShapeType {
+	return defaultWorld.GetShapeType(index)
+}
+
+// GetShapeVerticesCount - Returns the amount of vertices of a physics body shape
+func GetShapeVerticesCount(index int) int {
+	return defaultWorld.GetShapeVerticesCount(index)
+}
+
+// Close - Unitializes physics pointers
+func Close() {
+	defaultWorld.Close()
+}
+
+// Update - Runs physics step
+func Update() {
+	defaultWorld.Update()
+}
+
+// Step - Runs a single physics step of dt milliseconds without reading the clock
+func Step(dt float32) {
+	defaultWorld.Step(dt)
+}
+
+// SetTimeStep - Sets physics fixed time step in milliseconds. 1.666666 by default
+func SetTimeStep(delta float32) {
+	defaultWorld.SetTimeStep(delta)
+}
 
 // Init - Initializes physics values, pointers and creates physics loop thread
-func Init() {
+func (w *World) Init() {
 	initTimer()
-	accumulator = 0
+	w.startTime = getCurrentTime()
+	w.accumulator = 0
 }
 
 // SetGravity - Sets physics global gravity force
-func SetGravity(x, y float32) {
-	gravityForce.X = x
-	gravityForce.Y = y
+func (w *World) SetGravity(x, y float32) {
+	w.gravityForce.X = x
+	w.gravityForce.Y = y
+}
+
+// SetIterations - Sets the number of velocity and position solver iterations run every step. More velocity
+// iterations make stacks and joints stiffer, position iterations remove penetration faster
+func (w *World) SetIterations(velocityIterations, positionIterations int) {
+	w.velocityIterations = max(velocityIterations, 1)
+	w.positionIterations = max(positionIterations, 0)
+}
+
+// SetWarmStarting - Enables or disables carrying contact impulses over to the next step, warm starting helps stacks
+// to come to rest with few velocity iterations
+func (w *World) SetWarmStarting(enabled bool) {
+	w.warmStartingDisabled = !enabled
 }
 
 // NewBodyCircle - Creates a new circle physics body with generic parameters
-func NewBodyCircle(pos rl.Vector2, radius, density float32) *Body {
-	newID := findAvailableBodyIndex()
-	if newID < 0 {
-		return nil
-	}
+func (w *World) NewBodyCircle(pos rl.Vector2, radius, density float32) *Body {
+	newID := w.bodyIDs.acquire()
 
 	// Initialize new body with generic values
 	newBody := &Body{
 		ID:              newID,
+		Type:            DynamicBody,
 		Enabled:         true,
 		Position:        pos,
 		Velocity:        rl.Vector2{},
@@ -203,8 +448,12 @@ func NewBodyCircle(pos rl.Vector2, radius, density float32) *Body {
 		DynamicFriction: 0.2,
 		Restitution:     0.0,
 		UseGravity:      true,
+		AllowSleep:      true,
 		IsGrounded:      false,
 		FreezeOrient:    false,
+		CategoryBits:    DefaultCategoryBits,
+		MaskBits:        DefaultMaskBits,
+		world:           w,
 	}
 
 	newBody.Shape.Body = newBody
@@ -215,21 +464,18 @@ func NewBodyCircle(pos rl.Vector2, radius, density float32) *Body {
 	newBody.InverseInertia = safeDiv(1.0, newBody.Inertia)
 
 	// Add new body to bodies pointers array and update bodies count
-	bodies[bodiesCount] = newBody
-	bodiesCount++
+	w.bodies = append(w.bodies, newBody)
 	return newBody
 }
 
 // NewBodyRectangle - Creates a new rectangle physics body with generic parameters
-func NewBodyRectangle(pos rl.Vector2, width, height, density float32) *Body {
-	newID := findAvailableBodyIndex()
-	if newID < 0 {
-		return nil
-	}
+func (w *World) NewBodyRectangle(pos rl.Vector2, width, height, density float32) *Body {
+	newID := w.bodyIDs.acquire()
 
 	// Initialize new body with generic values
 	newBody := &Body{
 		ID:              newID,
+		Type:            DynamicBody,
 		Enabled:         true,
 		Position:        pos,
 		Velocity:        rl.Vector2{},
@@ -246,8 +492,12 @@ func NewBodyRectangle(pos rl.Vector2, width, height, density float32) *Body {
 		DynamicFriction: 0.2,
 		Restitution:     0.0,
 		UseGravity:      true,
+		AllowSleep:      true,
 		IsGrounded:      false,
 		FreezeOrient:    false,
+		CategoryBits:    DefaultCategoryBits,
+		MaskBits:        DefaultMaskBits,
+		world:           w,
 	}
 
 	// Calculate centroid and moment of inertia
@@ -292,21 +542,18 @@ func NewBodyRectangle(pos rl.Vector2, width, height, density float32) *Body {
 	newBody.InverseInertia = safeDiv(1.0, newBody.Inertia)
 
 	// Add new body to bodies pointers array and update bodies count
-	bodies[bodiesCount] = newBody
-	bodiesCount++
+	w.bodies = append(w.bodies, newBody)
 	return newBody
 }
 
 // NewBodyPolygon - Creates a new polygon physics body with generic parameters
-func NewBodyPolygon(pos rl.Vector2, radius float32, sides int, density float32) *Body {
-	newID := findAvailableBodyIndex()
-	if newID < 0 {
-		return nil
-	}
+func (w *World) NewBodyPolygon(pos rl.Vector2, radius float32, sides int, density float32) *Body {
+	newID := w.bodyIDs.acquire()
 
 	// Initialize new body with generic values
 	newBody := &Body{
 		ID:              newID,
+		Type:            DynamicBody,
 		Enabled:         true,
 		Position:        pos,
 		Velocity:        rl.Vector2{},
@@ -323,8 +570,12 @@ func NewBodyPolygon(pos rl.Vector2, radius float32, sides int, density float32)
 		DynamicFriction: 0.2,
 		Restitution:     0.0,
 		UseGravity:      true,
+		AllowSleep:      true,
 		IsGrounded:      false,
 		FreezeOrient:    false,
+		CategoryBits:    DefaultCategoryBits,
+		MaskBits:        DefaultMaskBits,
+		world:           w,
 	}
 
 	newBody.Shape.Body = newBody
@@ -370,20 +621,22 @@ func NewBodyPolygon(pos rl.Vector2, radius float32, sides int, density float32)
 	newBody.InverseInertia = safeDiv(1.0, newBody.Inertia)
 
 	// Add new body to bodies pointers array and update bodies count
-	bodies[bodiesCount] = newBody
-	bodiesCount++
+	w.bodies = append(w.bodies, newBody)
 	return newBody
 }
 
 // Reset - Destroys created physics bodies and manifolds
-func Reset() {
-	Close()
+func (w *World) Reset() {
+	w.Close()
 }
 
 // AddForce - Adds a force to a physics body
 func AddForce(body *Body, force rl.Vector2) {
 	if body != nil {
 		body.Force = rl.Vector2Add(body.Force, force)
+		if force != (rl.Vector2{}) {
+			body.Wake()
+		}
 	}
 }
 
@@ -391,175 +644,73 @@ func AddForce(body *Body, force rl.Vector2) {
 func AddTorque(body *Body, amount float32) {
 	if body != nil {
 		body.Torque += amount
-	}
-}
-
-// Shatter - Shatters a polygon shape physics body to little physics bodies with explosion force
-func Shatter(body *Body, position rl.Vector2, force float32) {
-	if body == nil || body.Shape.Type != PolygonShape {
-		return
-	}
-
-	vertexData := body.Shape.VertexData
-	collision := false
-
-	for i := 0; i < vertexData.VertexCount; i++ {
-		nextIndex := getNextIndex(i, vertexData.VertexCount)
-		pA := body.Position
-		pB := rl.Mat2MultiplyVector2(body.Shape.Transform,
-			rl.Vector2Add(body.Position, vertexData.Positions[i]))
-		pC := rl.Mat2MultiplyVector2(body.Shape.Transform,
-			rl.Vector2Add(body.Position, vertexData.Positions[nextIndex]))
-
-		// Check collision between each triangle
-		alpha := ((pB.Y-pC.Y)*(position.X-pC.X) + (pC.X-pB.X)*(position.Y-pC.Y)) /
-			((pB.Y-pC.Y)*(pA.X-pC.X) + (pC.X-pB.X)*(pA.Y-pC.Y))
-		beta := ((pC.Y-pA.Y)*(position.X-pC.X) + (pA.X-pC.X)*(position.Y-pC.Y)) /
-			((pB.Y-pC.Y)*(pA.X-pC.X) + (pC.X-pB.X)*(pA.Y-pC.Y))
-		gamma := 1.0 - alpha - beta
-
-		if alpha > 0 && beta > 0 && gamma > 0 {
-			collision = true
-			break
+		if amount != 0 {
+			body.Wake()
 		}
 	}
+}
 
-	if !collision {
+// Shatter - Shatters a polygon shape physics body containing position to little physics bodies with explosion
+// force, compound bodies are not shattered. It breaks the body with the Fracture radial pattern, so the fragments
+// keep the body density and touch their neighbours. Use Fracture to get the fragments, pick the pattern or break
+// circles
+func (w *World) Shatter(body *Body, position rl.Vector2, force float32) {
+	if body == nil || body.Shape.Type != PolygonShape || len(body.GetFixtures()) > 1 || !body.ContainsPoint(position) {
 		return
 	}
 
-	count := vertexData.VertexCount
-	bodyPos := body.Position
-	trans := body.Shape.Transform
-
-	vertices := make([]rl.Vector2, count)
-	for i := 0; i < count; i++ {
-		vertices[i] = vertexData.Positions[i]
-	}
-
-	// Destroy shattered physics body
-	body.Destroy()
-	for i := 0; i < count; i++ {
-		nextIndex := getNextIndex(i, count)
-		center := triangleBarycenter(vertices[i], vertices[nextIndex], rl.Vector2{})
-		center = rl.Vector2Add(bodyPos, center)
-		offset := rl.Vector2Subtract(center, bodyPos)
-
-		var newBody *Body = NewBodyPolygon(center, 10, 3, 10)
-		var newData Polygon = Polygon{}
-		newData.VertexCount = 3
-		newData.Positions[0] = rl.Vector2Subtract(vertices[i], offset)
-		newData.Positions[1] = rl.Vector2Subtract(vertices[nextIndex], offset)
-		newData.Positions[2] = rl.Vector2Subtract(position, center)
-
-		// Separate vertices to avoid unnecessary physics collisions
-		newData.Positions[0].X *= 0.95
-		newData.Positions[0].Y *= 0.95
-		newData.Positions[1].X *= 0.95
-		newData.Positions[1].Y *= 0.95
-		newData.Positions[2].X *= 0.95
-		newData.Positions[2].Y *= 0.95
-
-		// Calculate polygon faces normals
-		for j := 0; j < newData.VertexCount; j++ {
-			nextVertex := getNextIndex(j, newData.VertexCount)
-			face := rl.Vector2Subtract(newData.Positions[nextVertex], newData.Positions[j])
-
-			newData.Normals[j] = rl.NewVector2(face.Y, -face.X)
-			normalize(&newData.Normals[j])
-		}
-
-		// Apply computed vertex data to new physics body shape
-		newBody.Shape.VertexData = newData
-		newBody.Shape.Transform = trans
-
-		// Calculate centroid and moment of inertia
-		center = rl.Vector2{}
-		area := float32(0.0)
-		inertia := float32(0.0)
-
-		for j := 0; j < newBody.Shape.VertexData.VertexCount; j++ {
-			// Triangle vertices, third vertex implied as (0, 0)
-			nextVertex := getNextIndex(j, newBody.Shape.VertexData.VertexCount)
-			p1 := newBody.Shape.VertexData.Positions[j]
-			p2 := newBody.Shape.VertexData.Positions[nextVertex]
-
-			D := rl.Vector2CrossProduct(p1, p2)
-			triangleArea := D / 2
-
-			area += triangleArea
-
-			// Use area to weight the centroid average, not just vertex position
-			center.X += triangleArea * physacK * (p1.X + p2.X)
-			center.Y += triangleArea * physacK * (p1.Y + p2.Y)
-
-			intx2 := p1.X*p1.X + p2.X*p1.X + p2.X*p2.X
-			inty2 := p1.Y*p1.Y + p2.Y*p1.Y + p2.Y*p2.Y
-			inertia += (0.25*physacK*D)*intx2 + inty2
-		}
-
-		center.X *= 1.0 / area
-		center.Y *= 1.0 / area
-
-		newBody.Mass = area
-		newBody.InverseMass = safeDiv(1.0, newBody.Mass)
-		newBody.Inertia = inertia
-		newBody.InverseInertia = safeDiv(1.0, newBody.Inertia)
-
-		// Calculate explosion force direction
-		pointA := newBody.Position
-		pointB := rl.Vector2Subtract(newData.Positions[1], newData.Positions[0])
-		pointB.X /= 2.0
-		pointB.Y /= 2.0
-		forceDirection := rl.Vector2Subtract(
-			rl.Vector2Add(pointA, rl.Vector2Add(newData.Positions[0], pointB)),
-			newBody.Position,
-		)
-		normalize(&forceDirection)
-		forceDirection.X *= force
-		forceDirection.Y *= force
-
-		// Apply force to new physics body
-		AddForce(newBody, forceDirection)
-	}
+	w.Fracture(body, position, FractureDef{Pattern: FractureRadial, Force: force})
 }
 
 // GetBodies - Returns the slice of created physics bodies
-func GetBodies() []*Body {
-	return bodies[:bodiesCount]
+func (w *World) GetBodies() []*Body {
+	return w.bodies
 }
 
 // GetBodiesCount - Returns the current amount of created physics bodies
-func GetBodiesCount() int {
-	return bodiesCount
+func (w *World) GetBodiesCount() int {
+	return len(w.bodies)
 }
 
 // GetBody - Returns a physics body of the bodies pool at a specific index
-func GetBody(index int) *Body {
-	return bodies[index]
+func (w *World) GetBody(index int) *Body {
+	if index < 0 || index >= len(w.bodies) {
+		return nil
+	}
+	return w.bodies[index]
+}
+
+// GetBodyByID - Returns the physics body with the given identifier or nil if there is none
+func (w *World) GetBodyByID(id int) *Body {
+	for _, body := range w.bodies {
+		if body.ID == id {
+			return body
+		}
+	}
+	return nil
 }
 
 // GetShapeType - Returns the physics body shape type (PHYSICS_CIRCLE or PHYSICS_POLYGON)
-func GetShapeType(index int) ShapeType {
+func (w *World) GetShapeType(index int) ShapeType {
 	result := ShapeType(-1)
-	if index < bodiesCount {
-		if bodies[index] != nil {
-			result = bodies[index].Shape.Type
+	if index >= 0 && index < len(w.bodies) {
+		if w.bodies[index] != nil {
+			result = w.bodies[index].Shape.Type
 		}
 	}
 	return result
 }
 
 // GetShapeVerticesCount - Returns the amount of vertices of a physics body shape
-func GetShapeVerticesCount(index int) int {
+func (w *World) GetShapeVerticesCount(index int) int {
 	var result int = 0
-	if index < bodiesCount {
-		if bodies[index] != nil {
-			switch bodies[index].Shape.Type {
+	if index >= 0 && index < len(w.bodies) {
+		if w.bodies[index] != nil {
+			switch w.bodies[index].Shape.Type {
 			case CircleShape:
 				result = circleVertices
-			case PolygonShape:
-				result = bodies[index].Shape.VertexData.VertexCount
+			case PolygonShape, EdgeShape:
+				result = w.bodies[index].Shape.VertexData.VertexCount
 			default:
 			}
 		}
@@ -569,36 +720,74 @@ func GetShapeVerticesCount(index int) int {
 
 // GetShapeVertex - Returns transformed position of a body shape (body position + vertex transformed position)
 func (b *Body) GetShapeVertex(vertex int) rl.Vector2 {
+	return b.Shape.GetVertex(vertex)
+}
+
+// GetVertex - Returns transformed position of a shape vertex (shape position + vertex transformed position)
+func (s *Shape) GetVertex(vertex int) rl.Vector2 {
 	var position rl.Vector2
+	center := s.GetPosition()
 
-	switch b.Shape.Type {
+	switch s.Type {
 	case CircleShape:
 		angle := 360.0 / circleVertices * float64(vertex) * (degToRad)
-		position.X = b.Position.X + float32(math.Cos(angle))*b.Shape.Radius
-		position.Y = b.Position.Y + float32(math.Sin(angle))*b.Shape.Radius
-	case PolygonShape:
+		position.X = center.X + float32(math.Cos(angle))*s.Radius
+		position.Y = center.Y + float32(math.Sin(angle))*s.Radius
+	case PolygonShape, EdgeShape:
 		position = rl.Vector2Add(
-			b.Position,
-			rl.Mat2MultiplyVector2(b.Shape.Transform, b.Shape.VertexData.Positions[vertex]),
+			center,
+			rl.Mat2MultiplyVector2(s.Transform, s.VertexData.Positions[vertex]),
 		)
 	}
 	return position
 }
 
-// SetBodyRotation - Sets physics body shape transform based on radians parameter
+// SetBodyRotation - Sets physics body shapes transform based on radians parameter
 func (b *Body) SetRotation(radians float32) {
+	b.Wake()
 	b.Orient = radians
-	if b.Shape.Type == PolygonShape {
-		b.Shape.Transform = rl.Mat2Radians(radians)
+	for _, shape := range b.GetFixtures() {
+		shape.Transform = rl.Mat2Radians(radians)
+	}
+}
+
+// SetType - Sets physics body type, static bodies also lose their velocity
+func (b *Body) SetType(bodyType BodyType) {
+	b.Wake()
+	b.Type = bodyType
+	if bodyType == StaticBody {
+		b.Velocity = rl.Vector2{}
+		b.AngularVelocity = 0
 	}
 }
 
+// invMass - Returns the inverse mass used by the solver, zero for static, kinematic and disabled bodies
+func (b *Body) invMass() float32 {
+	if !b.Enabled || b.Type != DynamicBody {
+		return 0
+	}
+	return b.InverseMass
+}
+
+// invInertia - Returns the inverse inertia used by the solver, zero for bodies that can not rotate
+func (b *Body) invInertia() float32 {
+	if !b.Enabled || b.Type != DynamicBody || b.FreezeOrient {
+		return 0
+	}
+	return b.InverseInertia
+}
+
 // Destroy - Unitializes and destroy a physics body
 func (b *Body) Destroy() {
+	w := b.world
+	if w == nil {
+		return
+	}
+
 	id := b.ID
 	index := -1
-	for i := 0; i < bodiesCount; i++ {
-		if bodies[i].ID == id {
+	for i := 0; i < len(w.bodies); i++ {
+		if w.bodies[i].ID == id {
 			index = i
 			break
 		}
@@ -607,51 +796,52 @@ func (b *Body) Destroy() {
 		return
 	}
 
-	bodies[index] = nil
+	// Notify listeners the body stopped touching other bodies and forget its collisions
+	w.endBodyContacts(b)
+	w.destroyBodyManifolds(b)
 
-	// Reorder physics bodies pointers array and its catched index
-	for i := index; i+1 < bodiesCount; i++ {
-		bodies[i] = bodies[i+1]
+	// Destroy joints attached to the body
+	for len(b.joints) > 0 {
+		b.joints[len(b.joints)-1].Destroy()
 	}
 
-	// Update physics bodies count
-	bodiesCount--
+	// Reorder physics bodies pointers slice and its catched index
+	copy(w.bodies[index:], w.bodies[index+1:])
+	w.bodies[len(w.bodies)-1] = nil
+	w.bodies = w.bodies[:len(w.bodies)-1]
+
+	// Release body identifier so it can be reused by new bodies
+	w.bodyIDs.release(id)
+	b.world = nil
 }
 
 // Close - Unitializes physics pointers
-func Close() {
-	// Unitialize physics manifolds dynamic memory allocations
-	for i := manifoldsCount - 1; i >= 0; i-- {
-		destroyManifold(manifolds[i])
+func (w *World) Close() {
+	// Forget touching bodies pairs without notifying listeners
+	w.clearContacts()
+
+	// Unitialize physics joints
+	for i := len(w.joints) - 1; i >= 0; i-- {
+		w.joints[i].Destroy()
 	}
 
-	// Unitialize physics bodies dynamic memory allocations
-	for i := bodiesCount - 1; i >= 0; i-- {
-		bodies[i].Destroy()
+	// Unitialize force fields
+	for i := len(w.forceFields) - 1; i >= 0; i-- {
+		w.forceFields[i].Destroy()
 	}
-}
 
-// findAvailableBodyIndex - Finds a valid index for a new physics body initialization
-func findAvailableBodyIndex() int {
-	index := -1
-	for i := 0; i < maxBodies; i++ {
-		currentID := i
-
-		// Check if current id already exist in other physics body
-		for k := 0; k < bodiesCount; k++ {
-			if bodies[k].ID == currentID {
-				currentID++
-				break
-			}
-		}
+	// Unitialize physics manifolds dynamic memory allocations
+	for i := len(w.manifolds) - 1; i >= 0; i-- {
+		w.destroyManifold(w.manifolds[i])
+	}
 
-		// If it is not used, use it as new physics body id
-		if currentID == i {
-			index = i
-			break
-		}
+	// Unitialize physics bodies dynamic memory allocations
+	for i := len(w.bodies) - 1; i >= 0; i-- {
+		w.bodies[i].Destroy()
 	}
-	return index
+
+	w.bodyIDs.reset()
+	w.manifoldIDs.reset()
 }
 
 // createRandomPolygon - Creates a random polygon shape with max vertex distance from polygon pivot
@@ -701,157 +891,210 @@ func createRectanglePolygon(pos rl.Vector2, size rl.Vector2) Polygon {
 }
 
 // step - Does physics steps calculations (dynamics, collisions and position corrections)
-func step() {
-	// Clear previous generated collisions information
-	for i := manifoldsCount - 1; i >= 0; i-- {
-		if manifold := manifolds[i]; manifold != nil {
-			destroyManifold(manifold)
-		}
+func (w *World) step(dt float32) {
+	// Keep previous generated collisions information to match it with the new one
+	previous := w.manifolds
+	if w.manifoldCache == nil {
+		w.manifoldCache = make(map[manifoldKey]*Manifold, len(previous))
 	}
+	for _, manifold := range previous {
+		w.manifoldCache[manifoldKey{manifold.ShapeA, manifold.ShapeB}] = manifold
+	}
+	w.manifolds = w.manifoldsBuffer[:0]
 
 	// Reset physics bodies grounded state
-	for i := 0; i < bodiesCount; i++ {
-		bodies[i].IsGrounded = false
+	for i := 0; i < len(w.bodies); i++ {
+		w.bodies[i].IsGrounded = false
+	}
+
+	// Find potentially colliding pairs
+	w.bounds = w.bounds[:0]
+	for i := 0; i < len(w.bodies); i++ {
+		w.bounds = append(w.bounds, w.bodies[i].GetAABB())
 	}
+	w.pairs = w.broadphase.findPairs(w.bounds, w.pairs[:0])
 
 	// Generate new collision information
-	for i := 0; i < bodiesCount; i++ {
-		bodyA := bodies[i]
-		if bodyA == nil {
+	for _, pair := range w.pairs {
+		bodyA, bodyB := w.bodies[pair.a], w.bodies[pair.b]
+		if !w.shouldCollide(bodyA, bodyB) {
 			continue
 		}
 
-		for j := i + 1; j < bodiesCount; j++ {
-			var bodyB *Body = bodies[j]
-			if bodyB == nil || bodyA.InverseMass == 0 && bodyB.InverseMass == 0 {
-				continue
-			}
+		// Resting pairs can not start or stop touching, they keep their manifolds until one of them wakes up
+		if bodyA.isResting() && bodyB.isResting() {
+			w.keepManifolds(bodyA, bodyB)
+			continue
+		}
 
-			manifold := createManifold(bodyA, bodyB)
-			solveManifold(manifold)
-
-			if manifold.ContactsCount > 0 {
-				// Create a new manifold with same information as previously solved manifold and add it to the manifolds pool last slot
-				newManifold := createManifold(bodyA, bodyB)
-				newManifold.Penetration = manifold.Penetration
-				newManifold.Normal = manifold.Normal
-				newManifold.Contacts[0] = manifold.Contacts[0]
-				newManifold.Contacts[1] = manifold.Contacts[1]
-				newManifold.ContactsCount = manifold.ContactsCount
-				newManifold.Restitution = manifold.Restitution
-				newManifold.DynamicFriction = manifold.DynamicFriction
-				newManifold.StaticFriction = manifold.StaticFriction
+		// Compound bodies generate a manifold for every pair of touching shapes
+		fixturesA, fixturesB := bodyA.GetFixtures(), bodyB.GetFixtures()
+		compound := len(fixturesA) > 1 || len(fixturesB) > 1
+		for _, shapeA := range fixturesA {
+			for _, shapeB := range fixturesB {
+				if compound && !shapeA.GetAABB().Overlaps(shapeB.GetAABB()) {
+					continue
+				}
+
+				// Shapes touching in the previous step keep their manifold and contact impulses
+				key := manifoldKey{shapeA, shapeB}
+				if manifold, ok := w.manifoldCache[key]; ok {
+					manifold.IsSensor = bodyA.IsSensor || bodyB.IsSensor
+					updateManifold(manifold)
+					if manifold.ContactsCount > 0 {
+						delete(w.manifoldCache, key)
+						w.manifolds = append(w.manifolds, manifold)
+					}
+					continue
+				}
+
+				manifold := w.createManifold(shapeA, shapeB)
+				manifold.IsSensor = bodyA.IsSensor || bodyB.IsSensor
+				solveManifold(manifold)
+
+				// Only keep manifolds with contacts so the pool does not grow with every tested pair
+				if manifold.ContactsCount == 0 {
+					w.destroyManifold(manifold)
+				}
 			}
 		}
 	}
 
+	// Release the manifolds of shapes that stopped touching
+	for i, manifold := range previous {
+		key := manifoldKey{manifold.ShapeA, manifold.ShapeB}
+		if w.manifoldCache[key] == manifold {
+			delete(w.manifoldCache, key)
+			w.manifoldIDs.release(manifold.ID)
+		}
+		previous[i] = nil
+	}
+	w.manifoldsBuffer = previous[:0]
+
+	// Wake islands touching or jointed to awake bodies
+	w.updateIslands()
+
+	// Wake bodies reached by new or changed force fields
+	w.wakeForceFieldBodies()
+
 	// Integrate forces to physics bodies
-	for i := 0; i < bodiesCount; i++ {
-		if body := bodies[i]; body != nil {
-			integrateForces(body)
+	for i := 0; i < len(w.bodies); i++ {
+		if body := w.bodies[i]; body != nil {
+			w.integrateForces(body, dt)
 		}
 	}
 
 	// Initialize physics manifolds to solve collisions
-	for i := 0; i < manifoldsCount; i++ {
-		if manifold := manifolds[i]; manifold != nil {
-			initializeManifolds(manifold)
+	for i := 0; i < len(w.manifolds); i++ {
+		if manifold := w.manifolds[i]; manifold != nil && !manifold.IsSensor && manifold.isAwake() {
+			w.initializeManifolds(manifold, dt)
 		}
 	}
 
-	// Integrate physics collisions impulses to solve collisions
-	for i := 0; i < collisionIterations; i++ {
-		for j := 0; j < manifoldsCount; j++ {
-			if manifold := manifolds[i]; manifold != nil {
+	// Initialize physics joints to solve constraints
+	for _, joint := range w.joints {
+		if isJointAwake(joint) {
+			joint.initialize(dt)
+		}
+	}
+
+	// Integrate physics collisions and joints impulses to solve collisions and constraints
+	for i := 0; i < w.velocityIterations; i++ {
+		for j := 0; j < len(w.manifolds); j++ {
+			if manifold := w.manifolds[j]; manifold != nil && manifold.isSolved() {
 				integrateImpulses(manifold)
 			}
 		}
+
+		for _, joint := range w.joints {
+			if isJointAwake(joint) {
+				joint.solveVelocity(dt)
+			}
+		}
 	}
 
-	// Integrate velocity to physics bodies
-	for i := 0; i < bodiesCount; i++ {
-		if body := bodies[i]; body != nil {
-			integrateVelocity(body)
+	// Put islands resting long enough to sleep, solved velocities do not include the next half step forces yet
+	w.updateSleep(dt)
+
+	// Integrate velocity to physics bodies, sweeping bullets to their first time of impact
+	w.beginBullets()
+	for i := 0; i < len(w.bodies); i++ {
+		if body := w.bodies[i]; body != nil {
+			w.integrateVelocity(body, dt)
 		}
 	}
+	w.solveBullets()
 
 	// Correct physics bodies positions based on manifolds collision information
-	for i := 0; i < manifoldsCount; i++ {
-		if manifold := manifolds[i]; manifold != nil {
-			correctPositions(manifold)
+	for _, manifold := range w.manifolds {
+		manifold.positionA, manifold.positionB = manifold.BodyA.Position, manifold.BodyB.Position
+	}
+	for i := 0; i < w.positionIterations; i++ {
+		for j := 0; j < len(w.manifolds); j++ {
+			if manifold := w.manifolds[j]; manifold != nil && manifold.isSolved() {
+				correctPositions(manifold)
+			}
 		}
 	}
 
 	// Clear physics bodies forces
-	for i := 0; i < bodiesCount; i++ {
-		if body := bodies[i]; body != nil {
+	for i := 0; i < len(w.bodies); i++ {
+		if body := w.bodies[i]; body != nil {
 			body.Force = rl.Vector2{}
 			body.Torque = 0
 		}
 	}
+
+	// Notify contact listeners of bodies that started, kept or stopped touching
+	w.updateContacts()
 }
 
 // Update - Runs physics step
-func Update() {
+func (w *World) Update() {
 	// Calculate current time
-	currentTime = getCurrentTime()
+	w.currentTime = getCurrentTime()
 
 	// Calculate current delta time
-	var delta float32 = currentTime - startTime
+	var delta float32 = w.currentTime - w.startTime
 
 	// Store the time elapsed since the last frame began
-	accumulator += delta
+	w.accumulator += delta
 
 	// Fixed time stepping loop
-	for accumulator >= deltaTime {
-		step()
-		accumulator -= deltaTime
+	for w.accumulator >= w.deltaTime {
+		w.step(w.deltaTime)
+		w.accumulator -= w.deltaTime
 	}
 
 	// Record the starting of this frame
-	startTime = currentTime
+	w.startTime = w.currentTime
 }
 
-// SetTimeStep - Sets physics fixed time step in milliseconds. 1.666666 by default
-func SetTimeStep(delta float32) {
-	deltaTime = delta
+// Step - Runs a single physics step of dt milliseconds without reading the clock. The result only depends on
+// the world state and dt, so running the same steps on the same inputs is reproducible bit for bit on a given platform
+func (w *World) Step(dt float32) {
+	if dt <= 0 {
+		return
+	}
+	w.step(dt)
 }
 
-// findAvailableManifoldIndex - Finds a valid index for a new manifold initialization
-func findAvailableManifoldIndex() int {
-	index := -1
-	for i := 0; i < maxManifolds; i++ {
-		var currentId int = i
-
-		// Check if current id already exist in other physics body
-		for k := 0; k < manifoldsCount; k++ {
-			if manifolds[k].ID == currentId {
-				currentId++
-				break
-			}
-		}
-
-		// If it is not used, use it as new physics body id
-		if currentId == i {
-			index = i
-			break
-		}
-	}
-	return index
+// SetTimeStep - Sets physics fixed time step in milliseconds. 1.666666 by default
+func (w *World) SetTimeStep(delta float32) {
+	w.deltaTime = delta
 }
 
 // createManifold - Creates a new physics manifold to solve collision
-func createManifold(a *Body, b *Body) *Manifold {
-	newID := findAvailableManifoldIndex()
-	if newID < 0 {
-		return nil
-	}
+func (w *World) createManifold(a *Shape, b *Shape) *Manifold {
+	newID := w.manifoldIDs.acquire()
 
 	// Initialize new manifold with generic values
 	newManifold := &Manifold{
 		ID:              newID,
-		BodyA:           a,
-		BodyB:           b,
+		BodyA:           a.Body,
+		BodyB:           b.Body,
+		ShapeA:          a,
+		ShapeB:          b,
 		Penetration:     0,
 		Normal:          rl.Vector2{},
 		Contacts:        [2]rl.Vector2{},
@@ -859,25 +1102,26 @@ func createManifold(a *Body, b *Body) *Manifold {
 		Restitution:     0.0,
 		DynamicFriction: 0.0,
 		StaticFriction:  0.0,
+		Enabled:         true,
 	}
 
 	// Add new contact to conctas pointers array and update contacts count
-	manifolds[manifoldsCount] = newManifold
-	manifoldsCount++
+	w.manifolds = append(w.manifolds, newManifold)
 
 	return newManifold
 }
 
 // destroyManifold - Unitializes and destroys a physics manifold
-func destroyManifold(manifold *Manifold) {
+func (w *World) destroyManifold(manifold *Manifold) {
 	if manifold == nil {
 		return
 	}
 
 	id := manifold.ID
 	index := -1
-	for i := 0; i < manifoldsCount; i++ {
-		if manifolds[i].ID == id {
+	// Search from the end since the most recently created manifolds are usually destroyed first
+	for i := len(w.manifolds) - 1; i >= 0; i-- {
+		if w.manifolds[i].ID == id {
 			index = i
 			break
 		}
@@ -886,41 +1130,92 @@ func destroyManifold(manifold *Manifold) {
 		return
 	}
 
-	manifolds[index] = nil
+	// Reorder physics manifolds pointers slice and its catched index
+	copy(w.manifolds[index:], w.manifolds[index+1:])
+	w.manifolds[len(w.manifolds)-1] = nil
+	w.manifolds = w.manifolds[:len(w.manifolds)-1]
+
+	// Release manifold identifier so it can be reused by new manifolds
+	w.manifoldIDs.release(id)
+}
+
+// destroyBodyManifolds - Destroys the manifolds of a body, including the ones kept to match the next step collisions
+func (w *World) destroyBodyManifolds(body *Body) {
+	manifolds := w.manifolds[:0]
+	for _, manifold := range w.manifolds {
+		if manifold.BodyA == body || manifold.BodyB == body {
+			w.manifoldIDs.release(manifold.ID)
+			continue
+		}
+		manifolds = append(manifolds, manifold)
+	}
+	clear(w.manifolds[len(manifolds):])
+	w.manifolds = manifolds
 
-	// Reorder physics manifolds pointers array and its catched index
-	for i := index; i < manifoldsCount; i++ {
-		if (i + 1) < manifoldsCount {
-			manifolds[i] = manifolds[i+1]
+	for key, manifold := range w.manifoldCache {
+		if manifold.BodyA == body || manifold.BodyB == body {
+			delete(w.manifoldCache, key)
+			w.manifoldIDs.release(manifold.ID)
 		}
 	}
+}
+
+// updateManifold - Solves the collision of a manifold kept from the previous step, new points of contact close to
+// previous ones keep their accumulated impulses
+func updateManifold(manifold *Manifold) {
+	contacts, count, normal := manifold.Contacts, manifold.ContactsCount, manifold.Normal
+	normalImpulses, tangentImpulses := manifold.NormalImpulses, manifold.TangentImpulses
+
+	solveManifold(manifold)
+
+	// Contacts whose normal turned too much start over
+	if rl.Vector2DotProduct(normal, manifold.Normal) < contactMatchCos {
+		count = 0
+	}
 
-	// Update physics manifolds count
-	manifoldsCount--
+	for i := 0; i < manifold.ContactsCount; i++ {
+		manifold.NormalImpulses[i] = 0
+		manifold.TangentImpulses[i] = 0
+
+		closest := float32(contactMatchDistance * contactMatchDistance)
+		for j := 0; j < count; j++ {
+			if distance := rl.Vector2DistanceSqr(manifold.Contacts[i], contacts[j]); distance <= closest {
+				closest = distance
+				manifold.NormalImpulses[i] = normalImpulses[j]
+				manifold.TangentImpulses[i] = tangentImpulses[j]
+			}
+		}
+	}
 }
 
 // solveManifold - Solves a created physics manifold between two physics bodies
 func solveManifold(manifold *Manifold) {
-	switch manifold.BodyA.Shape.Type {
+	switch manifold.ShapeA.Type {
 	case CircleShape:
-		switch manifold.BodyB.Shape.Type {
+		switch manifold.ShapeB.Type {
 		case CircleShape:
 			solveCircleToCircle(manifold)
 		case PolygonShape:
 			solveCircleToPolygon(manifold)
+		case EdgeShape:
+			solveEdgeManifold(manifold)
 		}
 	case PolygonShape:
-		switch manifold.BodyB.Shape.Type {
+		switch manifold.ShapeB.Type {
 		case CircleShape:
 			solvePolygonToCircle(manifold)
 		case PolygonShape:
 			solvePolygonToPolygon(manifold)
+		case EdgeShape:
+			solveEdgeManifold(manifold)
 		}
+	case EdgeShape:
+		solveEdgeManifold(manifold)
 	}
 
 	// Update physics body grounded state if normal direction is down and grounded state
-	// is not set yet in previous manifolds
-	if !manifold.BodyB.IsGrounded {
+	// is not set yet in previous manifolds, sensors never ground bodies
+	if !manifold.BodyB.IsGrounded && !manifold.IsSensor {
 		manifold.BodyB.IsGrounded = manifold.Normal.Y < 0
 	}
 }
@@ -931,12 +1226,14 @@ func solveCircleToCircle(manifold *Manifold) {
 	if bodyA == nil || bodyB == nil {
 		return
 	}
+	shapeA, shapeB := manifold.ShapeA, manifold.ShapeB
+	positionA := shapeA.GetPosition()
 
 	// Calculate translational vector, which is normal
-	var normal rl.Vector2 = rl.Vector2Subtract(bodyB.Position, bodyA.Position)
+	var normal rl.Vector2 = rl.Vector2Subtract(shapeB.GetPosition(), positionA)
 
 	distSqr := rl.Vector2LengthSqr(normal)
-	radius := bodyA.Shape.Radius + bodyB.Shape.Radius
+	radius := shapeA.Radius + shapeB.Radius
 
 	// Check if circles are not in contact
 	if distSqr >= radius*radius {
@@ -947,9 +1244,9 @@ func solveCircleToCircle(manifold *Manifold) {
 	distance := float32(math.Sqrt(float64(distSqr)))
 	manifold.ContactsCount = 1
 	if distance == 0 {
-		manifold.Penetration = bodyA.Shape.Radius
+		manifold.Penetration = shapeA.Radius
 		manifold.Normal = rl.NewVector2(1, 0)
-		manifold.Contacts[0] = bodyA.Position
+		manifold.Contacts[0] = positionA
 	} else {
 		manifold.Penetration = radius - distance
 		// Faster than using normalize() due to sqrt is already performed
@@ -958,13 +1255,13 @@ func solveCircleToCircle(manifold *Manifold) {
 			normal.Y/distance,
 		)
 		manifold.Contacts[0] = rl.NewVector2(
-			manifold.Normal.X*bodyA.Shape.Radius+bodyA.Position.X,
-			manifold.Normal.Y*bodyA.Shape.Radius+bodyA.Position.Y,
+			manifold.Normal.X*shapeA.Radius+positionA.X,
+			manifold.Normal.Y*shapeA.Radius+positionA.Y,
 		)
 	}
 
 	// Update physics body grounded state if normal direction is down
-	if !bodyA.IsGrounded {
+	if !bodyA.IsGrounded && !manifold.IsSensor {
 		bodyA.IsGrounded = manifold.Normal.Y < 0
 	}
 }
@@ -975,7 +1272,7 @@ func solveCircleToPolygon(manifold *Manifold) {
 	if bodyA == nil || bodyB == nil {
 		return
 	}
-	solveDifferentShapes(manifold, bodyA, bodyB)
+	solveDifferentShapes(manifold, manifold.ShapeA, manifold.ShapeB)
 }
 
 // solvePolygonToCircle - Solves collision between a polygon to a circle shape physics bodies
@@ -984,26 +1281,27 @@ func solvePolygonToCircle(manifold *Manifold) {
 	if bodyA == nil || bodyB == nil {
 		return
 	}
-	solveDifferentShapes(manifold, bodyB, bodyA)
+	solveDifferentShapes(manifold, manifold.ShapeB, manifold.ShapeA)
 	manifold.Normal.X *= -1.0
 	manifold.Normal.Y *= -1.0
 }
 
 // solveDifferentShapes - Solves collision between two different types of shapes
-func solveDifferentShapes(manifold *Manifold, bodyA *Body, bodyB *Body) {
+func solveDifferentShapes(manifold *Manifold, shapeA *Shape, shapeB *Shape) {
 	manifold.ContactsCount = 0
+	positionA, positionB := shapeA.GetPosition(), shapeB.GetPosition()
 
 	// Transform circle center to polygon transform space
 	center := rl.Mat2MultiplyVector2(
-		rl.Mat2Transpose(bodyB.Shape.Transform),
-		rl.Vector2Subtract(bodyA.Position, bodyB.Position),
+		rl.Mat2Transpose(shapeB.Transform),
+		rl.Vector2Subtract(positionA, positionB),
 	)
 
 	// Find edge with minimum penetration
 	// It is the same concept as using support points in SolvePolygonToPolygon
 	separation := float32(-math.MaxFloat32)
 	faceNormal := 0
-	vertexData := bodyB.Shape.VertexData
+	vertexData := shapeB.VertexData
 
 	for i := 0; i < vertexData.VertexCount; i++ {
 		currentSeparation := rl.Vector2DotProduct(
@@ -1011,7 +1309,7 @@ func solveDifferentShapes(manifold *Manifold, bodyA *Body, bodyB *Body) {
 			rl.Vector2Subtract(center, vertexData.Positions[i]),
 		)
 
-		if currentSeparation > bodyA.Shape.Radius {
+		if currentSeparation > shapeA.Radius {
 			return
 		}
 
@@ -1029,62 +1327,62 @@ func solveDifferentShapes(manifold *Manifold, bodyA *Body, bodyB *Body) {
 	// Check to see if center is within polygon
 	if separation < epsilon {
 		manifold.ContactsCount = 1
-		var normal rl.Vector2 = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, vertexData.Normals[faceNormal])
+		var normal rl.Vector2 = rl.Mat2MultiplyVector2(shapeB.Transform, vertexData.Normals[faceNormal])
 		manifold.Normal = rl.NewVector2(-normal.X, -normal.Y)
 		manifold.Contacts[0] = rl.NewVector2(
-			manifold.Normal.X*bodyA.Shape.Radius+bodyA.Position.X,
-			manifold.Normal.Y*bodyA.Shape.Radius+bodyA.Position.Y,
+			manifold.Normal.X*shapeA.Radius+positionA.X,
+			manifold.Normal.Y*shapeA.Radius+positionA.Y,
 		)
-		manifold.Penetration = bodyA.Shape.Radius
+		manifold.Penetration = shapeA.Radius
 		return
 	}
 
 	// Determine which voronoi region of the edge center of circle lies within
 	dot1 := rl.Vector2DotProduct(rl.Vector2Subtract(center, v1), rl.Vector2Subtract(v2, v1))
 	dot2 := rl.Vector2DotProduct(rl.Vector2Subtract(center, v2), rl.Vector2Subtract(v1, v2))
-	manifold.Penetration = bodyA.Shape.Radius - separation
+	manifold.Penetration = shapeA.Radius - separation
 
 	switch {
 	case dot1 <= 0: // Closest to v1
-		if rl.Vector2Distance(center, v1) > bodyA.Shape.Radius*bodyA.Shape.Radius {
+		if rl.Vector2Distance(center, v1) > shapeA.Radius*shapeA.Radius {
 			return
 		}
 
 		manifold.ContactsCount = 1
 		var normal rl.Vector2 = rl.Vector2Subtract(v1, center)
-		normal = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, normal)
+		normal = rl.Mat2MultiplyVector2(shapeB.Transform, normal)
 		normalize(&normal)
 		manifold.Normal = normal
-		v1 = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, v1)
-		v1 = rl.Vector2Add(v1, bodyB.Position)
+		v1 = rl.Mat2MultiplyVector2(shapeB.Transform, v1)
+		v1 = rl.Vector2Add(v1, positionB)
 		manifold.Contacts[0] = v1
 
 	case dot2 <= 0: // Closest to v2
-		if rl.Vector2Distance(center, v2) > bodyA.Shape.Radius*bodyA.Shape.Radius {
+		if rl.Vector2Distance(center, v2) > shapeA.Radius*shapeA.Radius {
 			return
 		}
 
 		manifold.ContactsCount = 1
 		var normal rl.Vector2 = rl.Vector2Subtract(v2, center)
-		v2 = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, v2)
-		v2 = rl.Vector2Add(v2, bodyB.Position)
+		v2 = rl.Mat2MultiplyVector2(shapeB.Transform, v2)
+		v2 = rl.Vector2Add(v2, positionB)
 		manifold.Contacts[0] = v2
-		normal = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, normal)
+		normal = rl.Mat2MultiplyVector2(shapeB.Transform, normal)
 		normalize(&normal)
 		manifold.Normal = normal
 
 	default: // Closest to face
 		var normal rl.Vector2 = vertexData.Normals[faceNormal]
 
-		if rl.Vector2DotProduct(rl.Vector2Subtract(center, v1), normal) > bodyA.Shape.Radius {
+		if rl.Vector2DotProduct(rl.Vector2Subtract(center, v1), normal) > shapeA.Radius {
 			return
 		}
 
-		normal = rl.Mat2MultiplyVector2(bodyB.Shape.Transform, normal)
+		normal = rl.Mat2MultiplyVector2(shapeB.Transform, normal)
 		manifold.Normal = rl.NewVector2(-normal.X, -normal.Y)
 		manifold.Contacts[0] = rl.NewVector2(
-			manifold.Normal.X*bodyA.Shape.Radius+bodyA.Position.X,
-			manifold.Normal.Y*bodyA.Shape.Radius+bodyA.Position.Y,
+			manifold.Normal.X*shapeA.Radius+positionA.X,
+			manifold.Normal.Y*shapeA.Radius+positionA.Y,
 		)
 		manifold.ContactsCount = 1
 	}
@@ -1097,7 +1395,7 @@ func solvePolygonToPolygon(manifold *Manifold) {
 		return
 	}
 
-	shapeA, shapeB := bodyA.Shape, bodyB.Shape
+	shapeA, shapeB := *manifold.ShapeA, *manifold.ShapeB
 	manifold.ContactsCount = 0
 
 	// Check for separating axis with A shape's face planes
@@ -1112,24 +1410,17 @@ func solvePolygonToPolygon(manifold *Manifold) {
 		return
 	}
 
-	referenceIndex := 0
-	flip := false // Always point from A shape to B shape
-
-	var refPoly Shape // Reference
-	var incPoly Shape // Incident
-
 	// Determine which shape contains reference face
 	if biasGreaterThan(penetrationA, penetrationB) {
-		refPoly = shapeA
-		incPoly = shapeB
-		referenceIndex = faceA
+		clipIncidentFace(manifold, shapeA, shapeB, faceA, false)
 	} else {
-		refPoly = shapeB
-		incPoly = shapeA
-		referenceIndex = faceB
-		flip = true
+		clipIncidentFace(manifold, shapeB, shapeA, faceB, true)
 	}
+}
 
+// clipIncidentFace - Generates the contacts of two overlapping polygon shapes clipping the incident polygon face
+// against the reference polygon face. Flip is set when the reference polygon is the manifold second shape
+func clipIncidentFace(manifold *Manifold, refPoly Shape, incPoly Shape, referenceIndex int, flip bool) {
 	// World space incident face
 	var incidentFace [2]rl.Vector2
 	findIncidentFace(&incidentFace[0], &incidentFace[1], refPoly, incPoly, referenceIndex)
@@ -1142,9 +1433,9 @@ func solvePolygonToPolygon(manifold *Manifold) {
 
 	// Transform vertices to world space
 	v1 = rl.Mat2MultiplyVector2(refPoly.Transform, v1)
-	v1 = rl.Vector2Add(v1, refPoly.Body.Position)
+	v1 = rl.Vector2Add(v1, refPoly.GetPosition())
 	v2 = rl.Mat2MultiplyVector2(refPoly.Transform, v2)
-	v2 = rl.Vector2Add(v2, refPoly.Body.Position)
+	v2 = rl.Vector2Add(v2, refPoly.GetPosition())
 
 	// Calculate reference face side normal in world space
 	sidePlaneNormal := rl.Vector2Subtract(v2, v1)
@@ -1199,224 +1490,203 @@ func solvePolygonToPolygon(manifold *Manifold) {
 }
 
 // integrateForces - Integrates physics forces into velocity
-func integrateForces(body *Body) {
-	if body == nil || body.InverseMass == 0 || !body.Enabled {
+func (w *World) integrateForces(body *Body, dt float32) {
+	if body == nil || body.invMass() == 0 || body.IsSleeping {
 		return
 	}
 
-	body.Velocity.X += body.Force.X * body.InverseMass * (deltaTime / 2.0)
-	body.Velocity.Y += body.Force.Y * body.InverseMass * (deltaTime / 2.0)
+	body.Velocity.X += body.Force.X * body.InverseMass * (dt / 2.0)
+	body.Velocity.Y += body.Force.Y * body.InverseMass * (dt / 2.0)
 
 	if body.UseGravity {
-		body.Velocity.X += gravityForce.X * (deltaTime / 1000 / 2.0)
-		body.Velocity.Y += gravityForce.Y * (deltaTime / 1000 / 2.0)
+		body.Velocity.X += w.gravityForce.X * (dt / 1000 / 2.0)
+		body.Velocity.Y += w.gravityForce.Y * (dt / 1000 / 2.0)
 	}
 
+	w.applyForceFields(body, dt)
+
 	if !body.FreezeOrient {
-		body.AngularVelocity += body.Torque * body.InverseInertia * (deltaTime / 2.0)
+		body.AngularVelocity += body.Torque * body.InverseInertia * (dt / 2.0)
 	}
 }
 
-// initializeManifolds - Initializes physics manifolds to solve collisions
-func initializeManifolds(manifold *Manifold) {
+// initializeManifolds - Initializes physics manifolds to solve collisions, applying the impulses carried over from
+// the previous step
+func (w *World) initializeManifolds(manifold *Manifold, dt float32) {
 	bodyA, bodyB := manifold.BodyA, manifold.BodyB
 
 	if bodyA == nil || bodyB == nil {
 		return
 	}
 
-	// // Calculate average restitution, static and dynamic friction
-	manifold.Restitution = float32(math.Sqrt(float64(bodyA.Restitution * bodyB.Restitution)))
-	manifold.StaticFriction = float32(math.Sqrt(float64(bodyA.StaticFriction * bodyB.StaticFriction)))
-	manifold.DynamicFriction = float32(math.Sqrt(float64(bodyA.DynamicFriction * bodyB.DynamicFriction)))
+	// Calculate average restitution, static and dynamic friction
+	staticA, dynamicA, restitutionA := manifold.ShapeA.getMaterial()
+	staticB, dynamicB, restitutionB := manifold.ShapeB.getMaterial()
+	manifold.Restitution = float32(math.Sqrt(float64(restitutionA * restitutionB)))
+	manifold.StaticFriction = float32(math.Sqrt(float64(staticA * staticB)))
+	manifold.DynamicFriction = float32(math.Sqrt(float64(dynamicA * dynamicB)))
+
+	// Let the user disable the collision or override its materials
+	manifold.Enabled = true
+	manifold.TangentSpeed = 0
+	if w.preSolve != nil {
+		w.preSolve(manifold)
+	}
+	if !manifold.Enabled {
+		manifold.NormalImpulses = [2]float32{}
+		manifold.TangentImpulses = [2]float32{}
+		return
+	}
 
 	for i := 0; i < manifold.ContactsCount; i++ {
 		// Caculate radius from center of mass to contact
 		radiusA := rl.Vector2Subtract(manifold.Contacts[i], bodyA.Position)
 		radiusB := rl.Vector2Subtract(manifold.Contacts[i], bodyB.Position)
-
-		crossA := rl.Vector2Cross(bodyA.AngularVelocity, radiusA)
-		crossB := rl.Vector2Cross(bodyB.AngularVelocity, radiusB)
-		radiusV := rl.NewVector2(
-			bodyB.Velocity.X+crossB.X-bodyA.Velocity.X-crossA.X,
-			bodyB.Velocity.Y+crossB.Y-bodyA.Velocity.Y-crossA.Y,
-		)
+		radiusV := relativeVelocity(bodyA, bodyB, radiusA, radiusB)
 
 		// Determine if we should perform a resting collision or not;
 		// The idea is if the only thing moving this object is gravity, then the collision should be
 		// performed without any restitution
-		rad := rl.NewVector2(gravityForce.X*deltaTime/1000, gravityForce.Y*deltaTime/1000)
+		rad := rl.NewVector2(w.gravityForce.X*dt/1000, w.gravityForce.Y*dt/1000)
 		if rl.Vector2LengthSqr(radiusV) < (rl.Vector2LengthSqr(rad) + epsilon) {
 			manifold.Restitution = 0
 		}
 	}
-}
-
-// integrateImpulses - Integrates physics collisions impulses to solve collisions
-func integrateImpulses(manifold *Manifold) {
-	bodyA, bodyB := manifold.BodyA, manifold.BodyB
-
-	if bodyA == nil || bodyB == nil {
-		return
-	}
-
-	// Early out and positional correct if both objects have infinite mass
-	if math.Abs(float64(bodyA.InverseMass+bodyB.InverseMass)) <= epsilon {
-		bodyA.Velocity = rl.Vector2{}
-		bodyB.Velocity = rl.Vector2{}
-		return
-	}
 
+	tangent := rl.NewVector2(manifold.Normal.Y, -manifold.Normal.X)
 	for i := 0; i < manifold.ContactsCount; i++ {
-		// Calculate radius from center of mass to contact
 		radiusA := rl.Vector2Subtract(manifold.Contacts[i], bodyA.Position)
 		radiusB := rl.Vector2Subtract(manifold.Contacts[i], bodyB.Position)
 
-		// Calculate relative velocity
-		radiusV := rl.NewVector2(
-			bodyB.Velocity.X+rl.Vector2Cross(bodyB.AngularVelocity, radiusB).X-
-				bodyA.Velocity.X-rl.Vector2Cross(bodyA.AngularVelocity, radiusA).X,
-			bodyB.Velocity.Y+rl.Vector2Cross(bodyB.AngularVelocity, radiusB).Y-
-				bodyA.Velocity.Y-rl.Vector2Cross(bodyA.AngularVelocity, radiusA).Y,
-		)
-
-		// Relative velocity along the normal
-		contactVelocity := rl.Vector2DotProduct(radiusV, manifold.Normal)
-
-		// Do not resolve if velocities are separating
-		if contactVelocity > 0 {
-			return
-		}
-
-		raCrossN := rl.Vector2CrossProduct(radiusA, manifold.Normal)
-		rbCrossN := rl.Vector2CrossProduct(radiusB, manifold.Normal)
-
-		inverseMassSum := bodyA.InverseMass + bodyB.InverseMass +
-			(raCrossN*raCrossN)*bodyA.InverseInertia + (rbCrossN*rbCrossN)*bodyB.InverseInertia
-
-		// Calculate impulse scalar value
-		impulse := -(manifold.Restitution + 1.0) * contactVelocity
-		impulse /= inverseMassSum
-		impulse /= float32(manifold.ContactsCount)
-
-		// Apply impulse to each physics body
-		impulseV := rl.NewVector2(manifold.Normal.X*impulse, manifold.Normal.Y*impulse)
-
-		if bodyA.Enabled {
-			bodyA.Velocity.X += bodyA.InverseMass * (-impulseV.X)
-			bodyA.Velocity.Y += bodyA.InverseMass * (-impulseV.Y)
-
-			if !bodyA.FreezeOrient {
-				bodyA.AngularVelocity += bodyA.InverseInertia *
-					rl.Vector2CrossProduct(radiusA, rl.NewVector2(-impulseV.X, -impulseV.Y))
-			}
+		// Bounce back with the restitution of the approaching velocity
+		manifold.velocityBias[i] = 0
+		contactVelocity := rl.Vector2DotProduct(relativeVelocity(bodyA, bodyB, radiusA, radiusB), manifold.Normal)
+		if contactVelocity < 0 {
+			manifold.velocityBias[i] = -manifold.Restitution * contactVelocity
 		}
 
-		if bodyB.Enabled {
-			bodyB.Velocity.X += bodyB.InverseMass * impulseV.X
-			bodyB.Velocity.Y += bodyB.InverseMass * impulseV.Y
-
-			if !bodyB.FreezeOrient {
-				bodyB.AngularVelocity += bodyB.InverseInertia * rl.Vector2CrossProduct(radiusB, impulseV)
-			}
+		if w.warmStartingDisabled {
+			manifold.NormalImpulses[i] = 0
+			manifold.TangentImpulses[i] = 0
+			continue
 		}
 
-		// Apply friction impulse to each physics body
-		radiusV.X = 0 +
-			bodyB.Velocity.X + rl.Vector2Cross(bodyB.AngularVelocity, radiusB).X -
-			bodyA.Velocity.X - rl.Vector2Cross(bodyA.AngularVelocity, radiusA).X
-		radiusV.Y = 0 +
-			bodyB.Velocity.Y + rl.Vector2Cross(bodyB.AngularVelocity, radiusB).Y -
-			bodyA.Velocity.Y - rl.Vector2Cross(bodyA.AngularVelocity, radiusA).Y
-
-		tangent := rl.NewVector2(
-			radiusV.X-manifold.Normal.X*rl.Vector2DotProduct(radiusV, manifold.Normal),
-			radiusV.Y-manifold.Normal.Y*rl.Vector2DotProduct(radiusV, manifold.Normal),
+		// Warm start with the previous step accumulated impulses
+		impulse := rl.Vector2Add(
+			rl.Vector2Scale(manifold.Normal, manifold.NormalImpulses[i]),
+			rl.Vector2Scale(tangent, manifold.TangentImpulses[i]),
 		)
-		normalize(&tangent)
+		applyImpulsePair(bodyA, bodyB, radiusA, radiusB, impulse)
+	}
+}
 
-		// Calculate impulse tangent magnitude
-		impulseTangent := -rl.Vector2DotProduct(radiusV, tangent)
-		impulseTangent /= inverseMassSum
-		impulseTangent /= float32(manifold.ContactsCount)
+// integrateImpulses - Integrates physics collisions impulses to solve collisions. Impulses are accumulated along
+// the step, normal impulses can only push bodies apart and friction impulses are bounded by coulomb's law
+func integrateImpulses(manifold *Manifold) {
+	bodyA, bodyB := manifold.BodyA, manifold.BodyB
 
-		absImpulseTangent := float32(math.Abs(float64(impulseTangent)))
+	if bodyA == nil || bodyB == nil {
+		return
+	}
 
-		// Don't apply tiny friction impulses
-		if absImpulseTangent <= epsilon {
-			return
+	// Early out if both objects have infinite mass, kinematic bodies keep their velocity
+	if math.Abs(float64(bodyA.invMass()+bodyB.invMass())) <= epsilon {
+		if bodyA.Type != KinematicBody {
+			bodyA.Velocity = rl.Vector2{}
 		}
-
-		// Apply coulumb's law
-		var tangentImpulse rl.Vector2
-		if absImpulseTangent < impulse*manifold.StaticFriction {
-			tangentImpulse = rl.NewVector2(tangent.X*impulseTangent, tangent.Y*impulseTangent)
-		} else {
-			tangentImpulse = rl.NewVector2(
-				tangent.X*(-impulse)*manifold.DynamicFriction,
-				tangent.Y*(-impulse)*manifold.DynamicFriction,
-			)
+		if bodyB.Type != KinematicBody {
+			bodyB.Velocity = rl.Vector2{}
 		}
+		return
+	}
 
-		// Apply friction impulse
-		if bodyA.Enabled {
-			bodyA.Velocity.X += bodyA.InverseMass * (-tangentImpulse.X)
-			bodyA.Velocity.Y += bodyA.InverseMass * (-tangentImpulse.Y)
-
-			if !bodyA.FreezeOrient {
-				bodyA.AngularVelocity += bodyA.InverseInertia *
-					rl.Vector2CrossProduct(radiusA, rl.NewVector2(-tangentImpulse.X, -tangentImpulse.Y))
-			}
-		}
+	normal := manifold.Normal
+	tangent := rl.NewVector2(normal.Y, -normal.X)
 
-		if bodyB.Enabled {
-			bodyB.Velocity.X += bodyB.InverseMass * tangentImpulse.X
-			bodyB.Velocity.Y += bodyB.InverseMass * tangentImpulse.Y
+	for i := 0; i < manifold.ContactsCount; i++ {
+		// Calculate radius from center of mass to contact
+		radiusA := rl.Vector2Subtract(manifold.Contacts[i], bodyA.Position)
+		radiusB := rl.Vector2Subtract(manifold.Contacts[i], bodyB.Position)
 
-			if !bodyB.FreezeOrient {
-				bodyB.AngularVelocity += bodyB.InverseInertia * rl.Vector2CrossProduct(radiusB, tangentImpulse)
-			}
+		// Relative velocity along the normal
+		contactVelocity := rl.Vector2DotProduct(relativeVelocity(bodyA, bodyB, radiusA, radiusB), normal)
+
+		// Calculate impulse scalar value, clamping the accumulated impulse so bodies are never pulled together
+		impulse := -safeDiv(contactVelocity-manifold.velocityBias[i], contactMass(bodyA, bodyB, radiusA, radiusB, normal))
+		accumulated := max(manifold.NormalImpulses[i]+impulse, 0)
+		impulse = accumulated - manifold.NormalImpulses[i]
+		manifold.NormalImpulses[i] = accumulated
+		applyImpulsePair(bodyA, bodyB, radiusA, radiusB, rl.Vector2Scale(normal, impulse))
+
+		// Calculate friction impulse scalar value, moving surfaces drag bodies along at their tangent speed
+		tangentVelocity := rl.Vector2DotProduct(relativeVelocity(bodyA, bodyB, radiusA, radiusB), tangent) - manifold.TangentSpeed
+		impulseTangent := -safeDiv(tangentVelocity, contactMass(bodyA, bodyB, radiusA, radiusB, tangent))
+
+		// Apply coulumb's law, sliding contacts use the dynamic friction
+		accumulated = manifold.TangentImpulses[i] + impulseTangent
+		if math.Abs(float64(accumulated)) > float64(manifold.StaticFriction*manifold.NormalImpulses[i]) {
+			maxFriction := manifold.DynamicFriction * manifold.NormalImpulses[i]
+			accumulated = clamp(accumulated, -maxFriction, maxFriction)
 		}
+		impulseTangent = accumulated - manifold.TangentImpulses[i]
+		manifold.TangentImpulses[i] = accumulated
+		applyImpulsePair(bodyA, bodyB, radiusA, radiusB, rl.Vector2Scale(tangent, impulseTangent))
 	}
 }
 
+// contactMass - Returns the inverse of the effective mass of two bodies at a point of contact along a direction
+func contactMass(bodyA, bodyB *Body, radiusA, radiusB, direction rl.Vector2) float32 {
+	raCrossN := rl.Vector2CrossProduct(radiusA, direction)
+	rbCrossN := rl.Vector2CrossProduct(radiusB, direction)
+	return bodyA.invMass() + bodyB.invMass() + raCrossN*raCrossN*bodyA.invInertia() + rbCrossN*rbCrossN*bodyB.invInertia()
+}
+
 // integrateVelocity - Integrates physics velocity into position and forces
-func integrateVelocity(body *Body) {
-	if body == nil || !body.Enabled {
+func (w *World) integrateVelocity(body *Body, dt float32) {
+	if body == nil || !body.Enabled || body.Type == StaticBody || body.IsSleeping {
 		return
 	}
 
-	body.Position.X += body.Velocity.X * deltaTime
-	body.Position.Y += body.Velocity.Y * deltaTime
+	body.Position.X += body.Velocity.X * dt
+	body.Position.Y += body.Velocity.Y * dt
 
 	if !body.FreezeOrient {
-		body.Orient += body.AngularVelocity * deltaTime
+		body.Orient += body.AngularVelocity * dt
 	}
 
-	rl.Mat2Set(&body.Shape.Transform, body.Orient)
+	for _, shape := range body.GetFixtures() {
+		rl.Mat2Set(&shape.Transform, body.Orient)
+	}
 
-	integrateForces(body)
+	w.integrateForces(body, dt)
 }
 
-// correctPositions - Corrects physics bodies positions based on manifolds collision information
+// correctPositions - Corrects physics bodies positions based on manifolds collision information, the penetration
+// is reduced by the corrections of previous position iterations
 func correctPositions(manifold *Manifold) {
 	bodyA, bodyB := manifold.BodyA, manifold.BodyB
 	if bodyA == nil || bodyB == nil {
 		return
 	}
 
-	corrCoeff := float32(math.Max(float64(manifold.Penetration-penetrationAllowance), 0)) /
-		(bodyA.InverseMass + bodyB.InverseMass) * penetrationCorrection
+	moved := rl.Vector2Subtract(
+		rl.Vector2Subtract(bodyB.Position, manifold.positionB),
+		rl.Vector2Subtract(bodyA.Position, manifold.positionA),
+	)
+	penetration := manifold.Penetration - rl.Vector2DotProduct(moved, manifold.Normal)
+
+	corrCoeff := safeDiv(float32(math.Max(float64(penetration-penetrationAllowance), 0)),
+		bodyA.invMass()+bodyB.invMass()) * penetrationCorrection
 	correction := rl.NewVector2(corrCoeff*manifold.Normal.X, corrCoeff*manifold.Normal.Y)
 
 	if bodyA.Enabled {
-		bodyA.Position.X -= correction.X * bodyA.InverseMass
-		bodyA.Position.Y -= correction.Y * bodyA.InverseMass
+		bodyA.Position.X -= correction.X * bodyA.invMass()
+		bodyA.Position.Y -= correction.Y * bodyA.invMass()
 	}
 
 	if bodyB.Enabled {
-		bodyB.Position.X += correction.X * bodyB.InverseMass
-		bodyB.Position.Y += correction.Y * bodyB.InverseMass
+		bodyB.Position.X += correction.X * bodyB.invMass()
+		bodyB.Position.Y += correction.Y * bodyB.invMass()
 	}
 }
 
@@ -1460,8 +1730,8 @@ func findAxisLeastPenetration(shapeA Shape, shapeB Shape) (int, float32) {
 		// Retrieve vertex on face from A shape, transform into B shape's model space
 		vertex := dataA.Positions[i]
 		vertex = rl.Mat2MultiplyVector2(shapeA.Transform, vertex)
-		vertex = rl.Vector2Add(vertex, shapeA.Body.Position)
-		vertex = rl.Vector2Subtract(vertex, shapeB.Body.Position)
+		vertex = rl.Vector2Add(vertex, shapeA.GetPosition())
+		vertex = rl.Vector2Subtract(vertex, shapeB.GetPosition())
 		vertex = rl.Mat2MultiplyVector2(buT, vertex)
 
 		// Compute penetration distance in B shape's model space
@@ -1501,10 +1771,10 @@ func findIncidentFace(v0 *rl.Vector2, v1 *rl.Vector2, ref Shape, inc Shape, inde
 
 	// Assign face vertices for incident face
 	*v0 = rl.Mat2MultiplyVector2(inc.Transform, incData.Positions[incidentFace])
-	*v0 = rl.Vector2Add(*v0, inc.Body.Position)
+	*v0 = rl.Vector2Add(*v0, inc.GetPosition())
 	incidentFace = getNextIndex(incidentFace, incData.VertexCount)
 	*v1 = rl.Mat2MultiplyVector2(inc.Transform, incData.Positions[incidentFace])
-	*v1 = rl.Vector2Add(*v1, inc.Body.Position)
+	*v1 = rl.Vector2Add(*v1, inc.GetPosition())
 }
 
 // clip - Calculates clipping based on a normal and two faces
@@ -1550,19 +1820,10 @@ func biasGreaterThan(valueA float32, valueB float32) bool {
 	return valueA >= (valueB*0.95 + valueA*0.01)
 }
 
-// triangleBarycenter - Returns the barycenter of a triangle given by 3 points
-func triangleBarycenter(v1 rl.Vector2, v2 rl.Vector2, v3 rl.Vector2) rl.Vector2 {
-	return rl.NewVector2(
-		(v1.X+v2.X+v3.X)/3,
-		(v1.Y+v2.Y+v3.Y)/3,
-	)
-}
-
 // initTimer - Initializes hi-resolution MONOTONIC timer
 func initTimer() {
 	rand.Seed(getTimeCount())
 	frequency = 1000000000
-	startTime = getCurrentTime() // Get current time
 }
 
 // getTimeCount - Gets hi-res MONOTONIC time measure in nanoseconds
@@ -1600,3 +1861,53 @@ func safeDiv(a, b float32) float32 {
 	}
 	return a / b
 }
+
+// idPool - Hands out the lowest unused identifier and recycles released ones
+type idPool struct {
+	// Next never used identifier
+	next int
+	// Released identifiers sorted in ascending order
+	free []int
+}
+
+// acquire - Returns an identifier not used by any other live object
+func (p *idPool) acquire() int {
+	if len(p.free) > 0 {
+		id := p.free[0]
+		p.free = p.free[1:]
+		return id
+	}
+	id := p.next
+	p.next++
+	return id
+}
+
+// release - Returns an identifier to the pool so it can be reused
+func (p *idPool) release(id int) {
+	index := sort.SearchInts(p.free, id)
+	if index < len(p.free) && p.free[index] == id {
+		return
+	}
+	p.free = append(p.free, 0)
+	copy(p.free[index+1:], p.free[index:])
+	p.free[index] = id
+}
+
+// reset - Releases every identifier
+func (p *idPool) reset() {
+	p.next = 0
+	p.free = p.free[:0]
+}
+
+// rebuild - Marks the given identifiers as used and every other lower identifier as free
+func (p *idPool) rebuild(used []int) {
+	p.reset()
+	sorted := append([]int(nil), used...)
+	sort.Ints(sorted)
+	for _, id := range sorted {
+		for ; p.next < id; p.next++ {
+			p.free = append(p.free, p.next)
+		}
+		p.next = id + 1
+	}
+}
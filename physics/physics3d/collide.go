@@ -0,0 +1,609 @@
+package physics3d
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// narrowphase - Collision detection buffers reused every step
+type narrowphase struct {
+	// Incident face polygon being clipped and its clipped copy
+	polygon, clipped []rl.Vector3
+	// Points of contact found for the current pair
+	candidates []contactCandidate
+	// Mesh triangle being tested, in local and world space
+	triangle      *hull
+	triangleWorld worldHull
+}
+
+// contactCandidate - Point of contact found by the narrowphase
+type contactCandidate struct {
+	position    rl.Vector3
+	penetration float32
+}
+
+// collider - Body shape in world space, hulls or rounded segments (spheres and capsules)
+type collider struct {
+	hull       *worldHull
+	start, end rl.Vector3
+	radius     float32
+}
+
+// Collision detection tolerances
+const (
+	// Separation an edges axis must win over faces axes by to be used, faces give more stable contacts
+	edgeAxisTolerance = 0.1 * linearSlop
+	// Separation the second hull face must win over the first hull face by to be used
+	faceAxisTolerance = 0.1 * linearSlop
+	// Greatest cosine between a capsule and a face normal for the capsule to lie on the face
+	lyingCapsuleCos = 0.2
+	// Smallest cosine between capsules axes to be parallel
+	parallelCapsulesCos = 0.995
+)
+
+// collide - Finds the touching pairs of bodies and updates their manifolds
+func (w *World) collide() {
+	// Keep previous manifolds to match them with the new ones
+	previous := w.manifolds
+	for _, manifold := range previous {
+		w.manifoldCache[manifoldKey{manifold.BodyA, manifold.BodyB, manifold.Triangle}] = manifold
+	}
+	w.manifolds = w.manifoldsBuffer[:0]
+
+	// Sort bodies bounding boxes along the x axis and sweep them to find overlapping pairs
+	w.bounds = w.bounds[:0]
+	w.order = w.order[:0]
+	for i, body := range w.bodies {
+		body.IsGrounded = false
+		box := body.GetBoundingBox()
+		margin := rl.NewVector3(speculativeDistance, speculativeDistance, speculativeDistance)
+		w.bounds = append(w.bounds, rl.NewBoundingBox(rl.Vector3Subtract(box.Min, margin), rl.Vector3Add(box.Max, margin)))
+		w.order = append(w.order, i)
+	}
+	sort.SliceStable(w.order, func(i, j int) bool {
+		return w.bounds[w.order[i]].Min.X < w.bounds[w.order[j]].Min.X
+	})
+
+	for i, indexA := range w.order {
+		for _, indexB := range w.order[i+1:] {
+			if w.bounds[indexB].Min.X > w.bounds[indexA].Max.X {
+				break
+			}
+			if !boundingBoxesOverlap(w.bounds[indexA], w.bounds[indexB]) {
+				continue
+			}
+
+			// Bodies keep the world order inside their pair
+			bodyA, bodyB := w.bodies[indexA], w.bodies[indexB]
+			if indexB < indexA {
+				bodyA, bodyB = bodyB, bodyA
+			}
+			w.collidePair(bodyA, bodyB)
+		}
+	}
+
+	// Forget the manifolds of bodies that stopped touching
+	clear(w.manifoldCache)
+	clear(previous)
+	w.manifoldsBuffer = previous[:0]
+
+	// Update physics bodies grounded state from contacts normals
+	for _, manifold := range w.manifolds {
+		if manifold.Normal.Y >= groundNormalY {
+			manifold.BodyB.IsGrounded = true
+		} else if manifold.Normal.Y <= -groundNormalY {
+			manifold.BodyA.IsGrounded = true
+		}
+	}
+}
+
+// collidePair - Solves the collision between two bodies, adding their manifolds if they touch
+func (w *World) collidePair(bodyA, bodyB *Body) {
+	// Bodies with infinite mass never respond to collisions
+	if bodyA.invMass() == 0 && bodyB.invMass() == 0 {
+		return
+	}
+
+	if bodyA.Shape.Type != MeshShape && bodyB.Shape.Type != MeshShape {
+		normal, ok := w.narrowphase.collide(bodyA.collider(), bodyB.collider())
+		w.addManifold(manifoldKey{bodyA, bodyB, 0}, normal, ok)
+		return
+	}
+
+	// Mesh bodies collide with every triangle overlapping the other body
+	mesh, other := bodyA, bodyB
+	if bodyB.Shape.Type == MeshShape {
+		mesh, other = bodyB, bodyA
+	}
+	box := other.GetBoundingBox()
+	margin := rl.NewVector3(speculativeDistance, speculativeDistance, speculativeDistance)
+	box = rl.NewBoundingBox(rl.Vector3Subtract(box.Min, margin), rl.Vector3Add(box.Max, margin))
+
+	inverse := rl.NewQuaternion(-mesh.Rotation.X, -mesh.Rotation.Y, -mesh.Rotation.Z, mesh.Rotation.W)
+	local := transformBoundingBox(box, inverseRotateVector(mesh.Rotation, rl.Vector3Negate(mesh.Position)), inverse)
+	mesh.Shape.mesh.query(local, func(triangle int) {
+		triangleCollider := w.narrowphase.setTriangle(mesh, triangle)
+		var normal rl.Vector3
+		var ok bool
+		if mesh == bodyA {
+			normal, ok = w.narrowphase.collide(triangleCollider, other.collider())
+		} else {
+			normal, ok = w.narrowphase.collide(other.collider(), triangleCollider)
+		}
+		w.addManifold(manifoldKey{bodyA, bodyB, triangle}, normal, ok)
+	})
+}
+
+// addManifold - Adds the manifold of a touching pair, reusing the one of the previous step if they touched
+func (w *World) addManifold(key manifoldKey, normal rl.Vector3, ok bool) {
+	if !ok || len(w.narrowphase.candidates) == 0 {
+		return
+	}
+
+	manifold, found := w.manifoldCache[key]
+	if found {
+		delete(w.manifoldCache, key)
+	} else {
+		manifold = &Manifold{BodyA: key.a, BodyB: key.b, Triangle: key.triangle}
+	}
+
+	manifold.setPoints(normal, reduceCandidates(w.narrowphase.candidates, normal))
+	w.manifolds = append(w.manifolds, manifold)
+}
+
+// collider - Returns the body shape in world space
+func (b *Body) collider() collider {
+	if b.Shape.hull != nil {
+		return collider{hull: &b.worldHull}
+	}
+
+	start, end := b.capsuleSegment()
+	return collider{start: start, end: end, radius: b.Shape.Radius}
+}
+
+// setTriangle - Transforms a mesh triangle to world space, returning its collider
+func (n *narrowphase) setTriangle(body *Body, index int) collider {
+	a, b, c := body.Shape.mesh.triangle(index)
+	if n.triangle == nil {
+		n.triangle = newTriangleHull(a, b, c)
+	} else {
+		n.triangle.vertices[0], n.triangle.vertices[1], n.triangle.vertices[2] = a, b, c
+		for i := range n.triangle.faces {
+			n.triangle.faces[i] = newHullFace(n.triangle.vertices, n.triangle.faces[i].vertices)
+		}
+	}
+
+	n.triangleWorld.set(n.triangle, body.Position, body.Rotation)
+	center := rl.Vector3Add(rl.Vector3Add(n.triangleWorld.vertices[0], n.triangleWorld.vertices[1]), n.triangleWorld.vertices[2])
+	n.triangleWorld.center = rl.Vector3Scale(center, 1.0/3.0)
+	return collider{hull: &n.triangleWorld}
+}
+
+// collide - Finds the points of contact between two colliders, returning the normal from a to b
+func (n *narrowphase) collide(a, b collider) (rl.Vector3, bool) {
+	n.candidates = n.candidates[:0]
+	switch {
+	case a.hull != nil && b.hull != nil:
+		return n.collideHulls(a.hull, b.hull)
+	case a.hull != nil:
+		return n.collideHullRound(a.hull, b)
+	case b.hull != nil:
+		normal, ok := n.collideHullRound(b.hull, a)
+		return rl.Vector3Negate(normal), ok
+	default:
+		return n.collideRounds(a, b)
+	}
+}
+
+// collideHulls - Finds the points of contact between two hulls with the separating axis test. Faces axes clip
+// the incident face against the reference face, edges axes touch at the edges closest points
+func (n *narrowphase) collideHulls(a, b *worldHull) (rl.Vector3, bool) {
+	faceA, separationA := queryFaces(a, b)
+	if separationA > speculativeDistance {
+		return rl.Vector3{}, false
+	}
+
+	faceB, separationB := queryFaces(b, a)
+	if separationB > speculativeDistance {
+		return rl.Vector3{}, false
+	}
+
+	edgeA, edgeB, axis, separationEdges := queryEdges(a, b)
+	if separationEdges > speculativeDistance {
+		return rl.Vector3{}, false
+	}
+
+	if separationEdges > max(separationA, separationB)+edgeAxisTolerance {
+		closestA, closestB := closestPointsSegments(a.vertices[edgeA.a], a.vertices[edgeA.b], b.vertices[edgeB.a], b.vertices[edgeB.b])
+		n.candidates = append(n.candidates, contactCandidate{
+			position:    rl.Vector3Scale(rl.Vector3Add(closestA, closestB), 0.5),
+			penetration: -separationEdges,
+		})
+		return axis, true
+	}
+
+	if separationB > separationA+faceAxisTolerance {
+		return rl.Vector3Negate(n.clipFaces(b, faceB, a)), true
+	}
+	return n.clipFaces(a, faceA, b), true
+}
+
+// queryFaces - Returns the face of a hull whose plane separates it the most from another hull
+func queryFaces(a, b *worldHull) (int, float32) {
+	face, separation := 0, float32(-math.MaxFloat32)
+	for i, normal := range a.normals {
+		low, _ := b.support(normal)
+		if distance := low - a.offsets[i]; distance > separation {
+			face, separation = i, distance
+		}
+	}
+	return face, separation
+}
+
+// queryEdges - Returns the pair of edges whose axis separates two hulls the most. Only edges both supporting
+// their hull along the axis are tested, as the others can not touch
+func queryEdges(a, b *worldHull) (hullEdge, hullEdge, rl.Vector3, float32) {
+	var edgeA, edgeB hullEdge
+	var axis rl.Vector3
+	separation := float32(-math.MaxFloat32)
+
+	for _, ea := range a.source.edges {
+		startA := a.vertices[ea.a]
+		directionA := rl.Vector3Subtract(a.vertices[ea.b], startA)
+		for _, eb := range b.source.edges {
+			startB := b.vertices[eb.a]
+			directionB := rl.Vector3Subtract(b.vertices[eb.b], startB)
+
+			// Parallel edges axes are covered by the faces axes
+			direction := rl.Vector3CrossProduct(directionA, directionB)
+			length := rl.Vector3Length(direction)
+			if length <= 0.001*rl.Vector3Length(directionA)*rl.Vector3Length(directionB) {
+				continue
+			}
+			direction = rl.Vector3Scale(direction, 1/length)
+
+			for _, sign := range [2]float32{1, -1} {
+				direction := rl.Vector3Scale(direction, sign)
+				_, highA := a.support(direction)
+				lowB, _ := b.support(direction)
+				if rl.Vector3DotProduct(direction, startA) < highA-linearSlop ||
+					rl.Vector3DotProduct(direction, startB) > lowB+linearSlop {
+					continue
+				}
+
+				if distance := lowB - highA; distance > separation {
+					edgeA, edgeB, axis, separation = ea, eb, direction, distance
+				}
+			}
+		}
+	}
+	return edgeA, edgeB, axis, separation
+}
+
+// clipFaces - Clips the incident face of a hull against the side planes of the reference face of another hull,
+// keeping the points below the reference face. Returns the reference face normal
+func (n *narrowphase) clipFaces(reference *worldHull, referenceFace int, incident *worldHull) rl.Vector3 {
+	normal := reference.normals[referenceFace]
+
+	// The incident face is the most anti parallel to the reference face
+	incidentFace, lowest := 0, float32(math.MaxFloat32)
+	for i, incidentNormal := range incident.normals {
+		if dot := rl.Vector3DotProduct(incidentNormal, normal); dot < lowest {
+			incidentFace, lowest = i, dot
+		}
+	}
+
+	n.polygon = n.polygon[:0]
+	for _, index := range incident.source.faces[incidentFace].vertices {
+		n.polygon = append(n.polygon, incident.vertices[index])
+	}
+
+	face := reference.source.faces[referenceFace].vertices
+	for i := range face {
+		start, end := reference.vertices[face[i]], reference.vertices[face[(i+1)%len(face)]]
+		inward := rl.Vector3CrossProduct(normal, rl.Vector3Subtract(end, start))
+		n.clipped = clipPolygon(n.polygon, inward, rl.Vector3DotProduct(inward, start), n.clipped[:0])
+		n.polygon, n.clipped = n.clipped, n.polygon
+	}
+
+	for _, point := range n.polygon {
+		separation := rl.Vector3DotProduct(normal, point) - reference.offsets[referenceFace]
+		if separation <= speculativeDistance {
+			n.candidates = append(n.candidates, contactCandidate{
+				position:    rl.Vector3Subtract(point, rl.Vector3Scale(normal, separation/2)),
+				penetration: -separation,
+			})
+		}
+	}
+	return normal
+}
+
+// clipPolygon - Clips a polygon keeping the part in front of a plane, appending it to out
+func clipPolygon(polygon []rl.Vector3, normal rl.Vector3, offset float32, out []rl.Vector3) []rl.Vector3 {
+	for i, current := range polygon {
+		next := polygon[(i+1)%len(polygon)]
+		distanceCurrent := rl.Vector3DotProduct(normal, current) - offset
+		distanceNext := rl.Vector3DotProduct(normal, next) - offset
+
+		if distanceCurrent >= 0 {
+			out = append(out, current)
+		}
+		if distanceCurrent*distanceNext < 0 {
+			t := distanceCurrent / (distanceCurrent - distanceNext)
+			out = append(out, rl.Vector3Lerp(current, next, t))
+		}
+	}
+	return out
+}
+
+// collideHullRound - Finds the points of contact between a hull and a sphere or capsule, returning the normal
+// from the hull to the rounded shape
+func (n *narrowphase) collideHullRound(h *worldHull, round collider) (rl.Vector3, bool) {
+	start, end, radius := round.start, round.end, round.radius
+
+	// Closest points between the rounded shape core segment and the hull surface
+	if !h.containsPoint(start) && !h.containsPoint(end) {
+		face, distance := -1, float32(math.MaxFloat32)
+		var closestSegment, closestHull rl.Vector3
+		for i, normal := range h.normals {
+			distanceStart := rl.Vector3DotProduct(normal, start) - h.offsets[i]
+			distanceEnd := rl.Vector3DotProduct(normal, end) - h.offsets[i]
+			if distanceStart < 0 && distanceEnd < 0 {
+				continue
+			}
+
+			pointSegment, pointHull, faceDistance := closestPointsSegmentFace(h, i, start, end, distanceStart, distanceEnd)
+			if faceDistance < distance {
+				face, distance = i, faceDistance
+				closestSegment, closestHull = pointSegment, pointHull
+			}
+		}
+
+		if face >= 0 && distance > epsilon {
+			if distance-radius > speculativeDistance {
+				return rl.Vector3{}, false
+			}
+
+			// Capsules lying on a face touch it along a segment
+			normal := rl.Vector3Scale(rl.Vector3Subtract(closestSegment, closestHull), 1/distance)
+			axis := rl.Vector3Subtract(end, start)
+			if rl.Vector3LengthSqr(axis) > epsilon && rl.Vector3DotProduct(normal, h.normals[face]) > 1-epsilon*1000 &&
+				abs(rl.Vector3DotProduct(rl.Vector3Normalize(axis), normal)) < lyingCapsuleCos &&
+				n.clipSegmentToFace(h, face, start, end, radius) {
+				return h.normals[face], true
+			}
+
+			surface := rl.Vector3Subtract(closestSegment, rl.Vector3Scale(normal, radius))
+			n.candidates = append(n.candidates, contactCandidate{
+				position:    rl.Vector3Scale(rl.Vector3Add(closestHull, surface), 0.5),
+				penetration: radius - distance,
+			})
+			return normal, true
+		}
+	}
+
+	// The core is inside the hull or crosses it, push it out along the face of least penetration
+	face, separation := 0, float32(-math.MaxFloat32)
+	for i, normal := range h.normals {
+		distance := min(rl.Vector3DotProduct(normal, start), rl.Vector3DotProduct(normal, end)) - h.offsets[i] - radius
+		if distance > separation {
+			face, separation = i, distance
+		}
+	}
+	if separation > speculativeDistance {
+		return rl.Vector3{}, false
+	}
+
+	normal := h.normals[face]
+	if !n.clipSegmentToFace(h, face, start, end, radius) {
+		deepest := start
+		if rl.Vector3DotProduct(normal, end) < rl.Vector3DotProduct(normal, start) {
+			deepest = end
+		}
+		n.candidates = append(n.candidates, contactCandidate{
+			position:    rl.Vector3Subtract(deepest, rl.Vector3Scale(normal, radius+separation/2)),
+			penetration: -separation,
+		})
+	}
+	return normal, true
+}
+
+// clipSegmentToFace - Clips a rounded segment against the side planes of a hull face, adding the clipped ends
+// close enough to the face as points of contact
+func (n *narrowphase) clipSegmentToFace(h *worldHull, face int, start, end rl.Vector3, radius float32) bool {
+	normal := h.normals[face]
+	vertices := h.source.faces[face].vertices
+
+	low, high := float32(0), float32(1)
+	for i := range vertices {
+		edgeStart, edgeEnd := h.vertices[vertices[i]], h.vertices[vertices[(i+1)%len(vertices)]]
+		inward := rl.Vector3CrossProduct(normal, rl.Vector3Subtract(edgeEnd, edgeStart))
+		distanceStart := rl.Vector3DotProduct(inward, rl.Vector3Subtract(start, edgeStart))
+		distanceEnd := rl.Vector3DotProduct(inward, rl.Vector3Subtract(end, edgeStart))
+
+		switch {
+		case distanceStart < 0 && distanceEnd < 0:
+			return false
+		case distanceStart < 0:
+			low = max(low, distanceStart/(distanceStart-distanceEnd))
+		case distanceEnd < 0:
+			high = min(high, distanceStart/(distanceStart-distanceEnd))
+		}
+	}
+	if low > high {
+		return false
+	}
+
+	added := false
+	ends := [2]float32{low, high}
+	count := 2
+	if rl.Vector3DistanceSqr(start, end) <= epsilon {
+		count = 1
+	}
+	for _, t := range ends[:count] {
+		point := rl.Vector3Lerp(start, end, t)
+		separation := rl.Vector3DotProduct(normal, point) - h.offsets[face] - radius
+		if separation <= speculativeDistance {
+			n.candidates = append(n.candidates, contactCandidate{
+				position:    rl.Vector3Subtract(point, rl.Vector3Scale(normal, radius+separation/2)),
+				penetration: -separation,
+			})
+			added = true
+		}
+	}
+	return added
+}
+
+// closestPointsSegmentFace - Returns the closest points between a segment and a hull face, and their distance.
+// The segment ends distances to the face plane are given
+func closestPointsSegmentFace(h *worldHull, face int, start, end rl.Vector3, distanceStart, distanceEnd float32) (rl.Vector3, rl.Vector3, float32) {
+	normal := h.normals[face]
+
+	// Segment crossing the face
+	if (distanceStart <= 0) != (distanceEnd <= 0) {
+		point := rl.Vector3Lerp(start, end, distanceStart/(distanceStart-distanceEnd))
+		if faceContainsPoint(h, face, point) {
+			return point, point, 0
+		}
+	}
+
+	var closestSegment, closestFace rl.Vector3
+	distance := float32(math.MaxFloat32)
+	for _, candidate := range [2]struct {
+		point    rl.Vector3
+		distance float32
+	}{{start, distanceStart}, {end, distanceEnd}} {
+		projection := rl.Vector3Subtract(candidate.point, rl.Vector3Scale(normal, candidate.distance))
+		if candidate.distance >= 0 && candidate.distance < distance && faceContainsPoint(h, face, projection) {
+			closestSegment, closestFace, distance = candidate.point, projection, candidate.distance
+		}
+	}
+
+	vertices := h.source.faces[face].vertices
+	for i := range vertices {
+		edgeStart, edgeEnd := h.vertices[vertices[i]], h.vertices[vertices[(i+1)%len(vertices)]]
+		pointSegment, pointEdge := closestPointsSegments(start, end, edgeStart, edgeEnd)
+		if edgeDistance := rl.Vector3Distance(pointSegment, pointEdge); edgeDistance < distance {
+			closestSegment, closestFace, distance = pointSegment, pointEdge, edgeDistance
+		}
+	}
+	return closestSegment, closestFace, distance
+}
+
+// faceContainsPoint - Checks if a point on a hull face plane is inside the face
+func faceContainsPoint(h *worldHull, face int, point rl.Vector3) bool {
+	normal := h.normals[face]
+	vertices := h.source.faces[face].vertices
+	for i := range vertices {
+		edgeStart, edgeEnd := h.vertices[vertices[i]], h.vertices[vertices[(i+1)%len(vertices)]]
+		inward := rl.Vector3CrossProduct(normal, rl.Vector3Subtract(edgeEnd, edgeStart))
+		if rl.Vector3DotProduct(inward, rl.Vector3Subtract(point, edgeStart)) < -epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// collideRounds - Finds the points of contact between two spheres or capsules
+func (n *narrowphase) collideRounds(a, b collider) (rl.Vector3, bool) {
+	closestA, closestB := closestPointsSegments(a.start, a.end, b.start, b.end)
+	delta := rl.Vector3Subtract(closestB, closestA)
+	distance := rl.Vector3Length(delta)
+	if distance-a.radius-b.radius > speculativeDistance {
+		return rl.Vector3{}, false
+	}
+
+	normal := rl.NewVector3(0, 1, 0)
+	if distance > epsilon {
+		normal = rl.Vector3Scale(delta, 1/distance)
+	}
+
+	// Parallel capsules touch along a segment
+	axisA := rl.Vector3Subtract(a.end, a.start)
+	axisB := rl.Vector3Subtract(b.end, b.start)
+	lengthA, lengthB := rl.Vector3Length(axisA), rl.Vector3Length(axisB)
+	if lengthA > epsilon && lengthB > epsilon && abs(rl.Vector3DotProduct(axisA, axisB)) > parallelCapsulesCos*lengthA*lengthB {
+		axisA = rl.Vector3Scale(axisA, 1/lengthA)
+		t0 := rl.Vector3DotProduct(rl.Vector3Subtract(b.start, a.start), axisA)
+		t1 := rl.Vector3DotProduct(rl.Vector3Subtract(b.end, a.start), axisA)
+		low, high := max(min(t0, t1), 0), min(max(t0, t1), lengthA)
+
+		if high-low > linearSlop {
+			for _, t := range [2]float32{low, high} {
+				pointA := rl.Vector3Add(a.start, rl.Vector3Scale(axisA, t))
+				pointB := closestPointOnSegment(b.start, b.end, pointA)
+				separation := rl.Vector3DotProduct(rl.Vector3Subtract(pointB, pointA), normal) - a.radius - b.radius
+				n.candidates = append(n.candidates, contactCandidate{
+					position:    roundsMidpoint(pointA, pointB, a.radius, b.radius, normal),
+					penetration: -separation,
+				})
+			}
+			return normal, true
+		}
+	}
+
+	n.candidates = append(n.candidates, contactCandidate{
+		position:    roundsMidpoint(closestA, closestB, a.radius, b.radius, normal),
+		penetration: a.radius + b.radius - distance,
+	})
+	return normal, true
+}
+
+// roundsMidpoint - Returns the point halfway between two rounded shapes surfaces
+func roundsMidpoint(pointA, pointB rl.Vector3, radiusA, radiusB float32, normal rl.Vector3) rl.Vector3 {
+	surfaceA := rl.Vector3Add(pointA, rl.Vector3Scale(normal, radiusA))
+	surfaceB := rl.Vector3Subtract(pointB, rl.Vector3Scale(normal, radiusB))
+	return rl.Vector3Scale(rl.Vector3Add(surfaceA, surfaceB), 0.5)
+}
+
+// reduceCandidates - Keeps at most maxManifoldPoints points of contact: the deepest, the farthest from it and the
+// two spanning the greatest area on both sides of them
+func reduceCandidates(candidates []contactCandidate, normal rl.Vector3) []contactCandidate {
+	if len(candidates) <= maxManifoldPoints {
+		return candidates
+	}
+
+	deepest := 0
+	for i, candidate := range candidates {
+		if candidate.penetration > candidates[deepest].penetration {
+			deepest = i
+		}
+	}
+	origin := candidates[deepest].position
+
+	farthest := deepest
+	for i, candidate := range candidates {
+		if rl.Vector3DistanceSqr(candidate.position, origin) > rl.Vector3DistanceSqr(candidates[farthest].position, origin) {
+			farthest = i
+		}
+	}
+	axis := rl.Vector3Subtract(candidates[farthest].position, origin)
+
+	left, right := deepest, deepest
+	var leftArea, rightArea float32
+	for i, candidate := range candidates {
+		area := rl.Vector3DotProduct(rl.Vector3CrossProduct(axis, rl.Vector3Subtract(candidate.position, origin)), normal)
+		if area > leftArea {
+			left, leftArea = i, area
+		}
+		if area < rightArea {
+			right, rightArea = i, area
+		}
+	}
+
+	// Copy the kept points first, as they are moved to the front of candidates
+	indices := []int{deepest}
+	for _, index := range [3]int{farthest, left, right} {
+		if !slices.Contains(indices, index) {
+			indices = append(indices, index)
+		}
+	}
+	var reduced [maxManifoldPoints]contactCandidate
+	for i, index := range indices {
+		reduced[i] = candidates[index]
+	}
+	return append(candidates[:0], reduced[:len(indices)]...)
+}
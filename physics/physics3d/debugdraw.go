@@ -0,0 +1,151 @@
+package physics3d
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// DebugDrawFlags type
+type DebugDrawFlags uint32
+
+// Debug draw layers
+const (
+	// Draw bodies shapes wireframes
+	DrawShapes DebugDrawFlags = 1 << iota
+	// Draw bodies axis aligned bounding boxes
+	DrawBoundingBoxes
+	// Draw manifolds points of contact
+	DrawContacts
+	// Draw manifolds normals at their points of contact
+	DrawNormals
+
+	// Draw every layer
+	DrawAll = DrawShapes | DrawBoundingBoxes | DrawContacts | DrawNormals
+)
+
+// Debug draw sizes
+const (
+	// Length of the drawn contact normals, in world units
+	debugNormalLength = 0.25
+	// Size of the drawn points of contact, in world units
+	debugPointSize = 0.04
+	// Rings and slices of the drawn spheres and capsules
+	debugSphereRings  = 8
+	debugSphereSlices = 12
+)
+
+// DebugRenderer - Renderer used to draw the physics world debug information, positions are in world space
+type DebugRenderer interface {
+	// DrawSegment - Draws a line between two points
+	DrawSegment(start, end rl.Vector3, color rl.Color)
+	// DrawSphere - Draws a sphere wireframe
+	DrawSphere(center rl.Vector3, radius float32, color rl.Color)
+	// DrawCapsule - Draws a capsule wireframe between its hemispheres centers
+	DrawCapsule(start, end rl.Vector3, radius float32, color rl.Color)
+	// DrawBox - Draws an axis aligned box wireframe
+	DrawBox(center, size rl.Vector3, color rl.Color)
+	// DrawPoint - Draws a point
+	DrawPoint(point rl.Vector3, size float32, color rl.Color)
+}
+
+// RaylibRenderer - Debug renderer drawing with raylib, use it between rl.BeginMode3D and rl.EndMode3D
+type RaylibRenderer struct{}
+
+// DrawSegment - Draws a line between two points
+func (RaylibRenderer) DrawSegment(start, end rl.Vector3, color rl.Color) {
+	rl.DrawLine3D(start, end, color)
+}
+
+// DrawSphere - Draws a sphere wireframe
+func (RaylibRenderer) DrawSphere(center rl.Vector3, radius float32, color rl.Color) {
+	rl.DrawSphereWires(center, radius, debugSphereRings, debugSphereSlices, color)
+}
+
+// DrawCapsule - Draws a capsule wireframe between its hemispheres centers
+func (RaylibRenderer) DrawCapsule(start, end rl.Vector3, radius float32, color rl.Color) {
+	rl.DrawCapsuleWires(start, end, radius, debugSphereSlices, debugSphereRings, color)
+}
+
+// DrawBox - Draws an axis aligned box wireframe
+func (RaylibRenderer) DrawBox(center, size rl.Vector3, color rl.Color) {
+	rl.DrawCubeWiresV(center, size, color)
+}
+
+// DrawPoint - Draws a point
+func (RaylibRenderer) DrawPoint(point rl.Vector3, size float32, color rl.Color) {
+	rl.DrawCubeWires(point, size, size, size, color)
+}
+
+// DebugDraw - Draws the physics world layers enabled by flags, a nil renderer draws with raylib
+func (w *World) DebugDraw(renderer DebugRenderer, flags DebugDrawFlags) {
+	if renderer == nil {
+		renderer = RaylibRenderer{}
+	}
+
+	if flags&DrawShapes != 0 {
+		for _, body := range w.bodies {
+			drawBody(renderer, body, debugBodyColor(body))
+		}
+	}
+
+	if flags&DrawBoundingBoxes != 0 {
+		for _, body := range w.bodies {
+			box := body.GetBoundingBox()
+			center := rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), 0.5)
+			renderer.DrawBox(center, rl.Vector3Subtract(box.Max, box.Min), rl.Magenta)
+		}
+	}
+
+	if flags&(DrawContacts|DrawNormals) != 0 {
+		for _, manifold := range w.manifolds {
+			for i := 0; i < manifold.PointsCount; i++ {
+				point := manifold.Points[i].Position
+				if flags&DrawContacts != 0 {
+					renderer.DrawPoint(point, debugPointSize, rl.Red)
+				}
+				if flags&DrawNormals != 0 {
+					end := rl.Vector3Add(point, rl.Vector3Scale(manifold.Normal, debugNormalLength))
+					renderer.DrawSegment(point, end, rl.Orange)
+				}
+			}
+		}
+	}
+}
+
+// debugBodyColor - Returns the color used to draw a body shape
+func debugBodyColor(body *Body) rl.Color {
+	switch {
+	case !body.Enabled || body.Type == StaticBody:
+		return rl.Gray
+	case body.Type == KinematicBody:
+		return rl.SkyBlue
+	default:
+		return rl.Green
+	}
+}
+
+// drawBody - Draws a body shape wireframe, hulls and meshes are drawn edge by edge
+func drawBody(renderer DebugRenderer, body *Body, color rl.Color) {
+	shape := &body.Shape
+	switch shape.Type {
+	case SphereShape:
+		renderer.DrawSphere(body.Position, shape.Radius, color)
+	case CapsuleShape:
+		start, end := body.capsuleSegment()
+		renderer.DrawCapsule(start, end, shape.Radius, color)
+	case BoxShape, ConvexHullShape:
+		body.updateTransform()
+		for _, edge := range shape.hull.edges {
+			renderer.DrawSegment(body.worldHull.vertices[edge.a], body.worldHull.vertices[edge.b], color)
+		}
+	case MeshShape:
+		for i := range shape.mesh.triangles {
+			a, b, c := shape.mesh.triangle(i)
+			a = rl.Vector3Add(body.Position, rotateVector(body.Rotation, a))
+			b = rl.Vector3Add(body.Position, rotateVector(body.Rotation, b))
+			c = rl.Vector3Add(body.Position, rotateVector(body.Rotation, c))
+			renderer.DrawSegment(a, b, color)
+			renderer.DrawSegment(b, c, color)
+			renderer.DrawSegment(c, a, color)
+		}
+	}
+}
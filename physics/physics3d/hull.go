@@ -0,0 +1,434 @@
+package physics3d
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// hull - Convex polyhedron in body local space, used for boxes, convex hulls and mesh triangles
+type hull struct {
+	// Vertices positions
+	vertices []rl.Vector3
+	// Planar faces, wound counter clockwise seen from outside
+	faces []hullFace
+	// Edges shared by two faces, every edge stored once
+	edges []hullEdge
+}
+
+// hullFace - Convex hull planar face
+type hullFace struct {
+	// Outward unit normal
+	normal rl.Vector3
+	// Plane distance to the origin along the normal
+	offset float32
+	// Vertices indices wound counter clockwise seen from outside
+	vertices []int
+}
+
+// hullEdge - Convex hull edge between two vertices
+type hullEdge struct {
+	a, b int
+}
+
+// hullTriangle - Triangle face used while building a convex hull
+type hullTriangle struct {
+	a, b, c int
+	normal  rl.Vector3
+	offset  float32
+}
+
+// Convex hull building tolerances
+const (
+	// Greatest number of vertices of a convex hull
+	maxHullVertices = 256
+	// Distance tolerance relative to the points extent
+	hullTolerance = 1e-4
+	// Cosine of the greatest angle between coplanar triangles merged into a face
+	hullCoplanarCos = 0.9999
+)
+
+// newBoxHull - Returns the hull of a box centered at the origin
+func newBoxHull(halfExtents rl.Vector3) *hull {
+	h := &hull{vertices: make([]rl.Vector3, 0, 8)}
+	for i := 0; i < 8; i++ {
+		vertex := halfExtents
+		if i&1 != 0 {
+			vertex.X = -vertex.X
+		}
+		if i&2 != 0 {
+			vertex.Y = -vertex.Y
+		}
+		if i&4 != 0 {
+			vertex.Z = -vertex.Z
+		}
+		h.vertices = append(h.vertices, vertex)
+	}
+
+	// Faces by axis, positive side first, wound counter clockwise seen from outside
+	faces := [6][4]int{
+		{0, 2, 6, 4}, {1, 5, 7, 3},
+		{0, 4, 5, 1}, {2, 3, 7, 6},
+		{0, 1, 3, 2}, {4, 6, 7, 5},
+	}
+	for _, face := range faces {
+		h.faces = append(h.faces, newHullFace(h.vertices, face[:]))
+	}
+	h.edges = hullEdges(h.faces)
+	return h
+}
+
+// newTriangleHull - Returns a two sided hull made of a single triangle
+func newTriangleHull(a, b, c rl.Vector3) *hull {
+	h := &hull{vertices: []rl.Vector3{a, b, c}}
+	h.faces = []hullFace{
+		newHullFace(h.vertices, []int{0, 1, 2}),
+		newHullFace(h.vertices, []int{0, 2, 1}),
+	}
+	h.edges = []hullEdge{{0, 1}, {1, 2}, {2, 0}}
+	return h
+}
+
+// newConvexHull - Returns the convex hull of a set of points, failing if they are coplanar
+func newConvexHull(points []rl.Vector3) (*hull, error) {
+	if len(points) < 4 {
+		return nil, errors.New("physics3d: convex hull needs at least 4 points")
+	}
+
+	// Tolerance relative to the points extent
+	low, high := points[0], points[0]
+	for _, point := range points {
+		low = rl.Vector3Min(low, point)
+		high = rl.Vector3Max(high, point)
+	}
+	extent := rl.Vector3Subtract(high, low)
+	tolerance := float32(math.Max(float64(extent.X), math.Max(float64(extent.Y), float64(extent.Z)))) * hullTolerance
+	if tolerance <= 0 {
+		return nil, errors.New("physics3d: convex hull points are coincident")
+	}
+
+	triangles, err := initialTetrahedron(points, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add points one by one, replacing the triangles they see by a fan joining the point to the horizon
+	for i, point := range points {
+		visible := make([]bool, len(triangles))
+		anyVisible := false
+		for j, triangle := range triangles {
+			if rl.Vector3DotProduct(triangle.normal, point)-triangle.offset > tolerance {
+				visible[j] = true
+				anyVisible = true
+			}
+		}
+		if !anyVisible {
+			continue
+		}
+
+		// Horizon edges belong to a visible triangle and to a hidden one
+		edges := make(map[hullEdge]bool)
+		for j, triangle := range triangles {
+			if visible[j] {
+				edges[hullEdge{triangle.a, triangle.b}] = true
+				edges[hullEdge{triangle.b, triangle.c}] = true
+				edges[hullEdge{triangle.c, triangle.a}] = true
+			}
+		}
+
+		kept := triangles[:0]
+		var horizon []hullEdge
+		for j, triangle := range triangles {
+			if visible[j] {
+				for _, edge := range [3]hullEdge{{triangle.a, triangle.b}, {triangle.b, triangle.c}, {triangle.c, triangle.a}} {
+					if !edges[hullEdge{edge.b, edge.a}] {
+						horizon = append(horizon, edge)
+					}
+				}
+				continue
+			}
+			kept = append(kept, triangle)
+		}
+
+		triangles = kept
+		for _, edge := range horizon {
+			triangles = append(triangles, newHullTriangle(points, edge.a, edge.b, i))
+		}
+	}
+
+	return mergeHullTriangles(points, triangles)
+}
+
+// initialTetrahedron - Returns the four triangles of a tetrahedron made of extreme points
+func initialTetrahedron(points []rl.Vector3, tolerance float32) ([]hullTriangle, error) {
+	// Extreme points along the x axis
+	a, b := 0, 0
+	for i, point := range points {
+		if point.X < points[a].X {
+			a = i
+		}
+		if point.X > points[b].X {
+			b = i
+		}
+	}
+	if rl.Vector3Distance(points[a], points[b]) <= tolerance {
+		// Points share their x coordinate, use the farthest point from the first one
+		for i, point := range points {
+			if rl.Vector3Distance(point, points[a]) > rl.Vector3Distance(points[b], points[a]) {
+				b = i
+			}
+		}
+	}
+
+	// Farthest point from the line
+	c, best := -1, tolerance
+	axis := rl.Vector3Normalize(rl.Vector3Subtract(points[b], points[a]))
+	for i, point := range points {
+		offset := rl.Vector3Subtract(point, points[a])
+		if distance := rl.Vector3Length(rl.Vector3CrossProduct(axis, offset)); distance > best {
+			c, best = i, distance
+		}
+	}
+	if c < 0 {
+		return nil, errors.New("physics3d: convex hull points are collinear")
+	}
+
+	// Farthest point from the plane
+	d, best := -1, tolerance
+	normal := rl.Vector3Normalize(rl.Vector3CrossProduct(
+		rl.Vector3Subtract(points[b], points[a]),
+		rl.Vector3Subtract(points[c], points[a]),
+	))
+	for i, point := range points {
+		distance := float32(math.Abs(float64(rl.Vector3DotProduct(normal, rl.Vector3Subtract(point, points[a])))))
+		if distance > best {
+			d, best = i, distance
+		}
+	}
+	if d < 0 {
+		return nil, errors.New("physics3d: convex hull points are coplanar")
+	}
+
+	// Wind the triangles so their normals point away from the fourth point
+	if rl.Vector3DotProduct(normal, rl.Vector3Subtract(points[d], points[a])) > 0 {
+		b, c = c, b
+	}
+	return []hullTriangle{
+		newHullTriangle(points, a, b, c),
+		newHullTriangle(points, a, d, b),
+		newHullTriangle(points, b, d, c),
+		newHullTriangle(points, c, d, a),
+	}, nil
+}
+
+// newHullTriangle - Returns a hull triangle with its plane
+func newHullTriangle(points []rl.Vector3, a, b, c int) hullTriangle {
+	normal := rl.Vector3Normalize(rl.Vector3CrossProduct(
+		rl.Vector3Subtract(points[b], points[a]),
+		rl.Vector3Subtract(points[c], points[a]),
+	))
+	return hullTriangle{a, b, c, normal, rl.Vector3DotProduct(normal, points[a])}
+}
+
+// mergeHullTriangles - Merges neighbour coplanar triangles into polygonal faces and keeps the vertices used by them
+func mergeHullTriangles(points []rl.Vector3, triangles []hullTriangle) (*hull, error) {
+	// Group triangles by shared edge when coplanar
+	parents := make([]int, len(triangles))
+	for i := range parents {
+		parents[i] = i
+	}
+	find := func(i int) int {
+		for parents[i] != i {
+			parents[i] = parents[parents[i]]
+			i = parents[i]
+		}
+		return i
+	}
+
+	owners := make(map[hullEdge]int, len(triangles)*3)
+	for i, triangle := range triangles {
+		for _, edge := range [3]hullEdge{{triangle.a, triangle.b}, {triangle.b, triangle.c}, {triangle.c, triangle.a}} {
+			owners[edge] = i
+		}
+	}
+	for i, triangle := range triangles {
+		for _, edge := range [3]hullEdge{{triangle.a, triangle.b}, {triangle.b, triangle.c}, {triangle.c, triangle.a}} {
+			j, ok := owners[hullEdge{edge.b, edge.a}]
+			if ok && rl.Vector3DotProduct(triangle.normal, triangles[j].normal) >= hullCoplanarCos {
+				parents[find(i)] = find(j)
+			}
+		}
+	}
+
+	// Collect every group vertices, reindexing the used points
+	indices := make(map[int]int)
+	h := &hull{}
+	groups := make(map[int]int)
+	var faceVertices [][]int
+	for i, triangle := range triangles {
+		root := find(i)
+		group, ok := groups[root]
+		if !ok {
+			group = len(faceVertices)
+			groups[root] = group
+			faceVertices = append(faceVertices, nil)
+		}
+
+		for _, point := range [3]int{triangle.a, triangle.b, triangle.c} {
+			index, ok := indices[point]
+			if !ok {
+				index = len(h.vertices)
+				indices[point] = index
+				h.vertices = append(h.vertices, points[point])
+			}
+
+			found := false
+			for _, vertex := range faceVertices[group] {
+				found = found || vertex == index
+			}
+			if !found {
+				faceVertices[group] = append(faceVertices[group], index)
+			}
+		}
+	}
+	if len(h.vertices) > maxHullVertices {
+		return nil, errors.New("physics3d: convex hull has too many vertices")
+	}
+
+	for _, vertices := range faceVertices {
+		h.faces = append(h.faces, newHullFace(h.vertices, sortFaceVertices(h.vertices, vertices)))
+	}
+	h.edges = hullEdges(h.faces)
+	return h, nil
+}
+
+// sortFaceVertices - Sorts the vertices of a convex planar face counter clockwise around its center, dropping the
+// vertices that are not corners of the face
+func sortFaceVertices(vertices []rl.Vector3, face []int) []int {
+	var center rl.Vector3
+	for _, index := range face {
+		center = rl.Vector3Add(center, vertices[index])
+	}
+	center = rl.Vector3Scale(center, 1/float32(len(face)))
+
+	// Any triangle of the face gives its normal, the first one with area is used
+	var normal rl.Vector3
+	for i := 1; i+1 < len(face) && rl.Vector3LengthSqr(normal) < epsilon*epsilon; i++ {
+		normal = rl.Vector3CrossProduct(
+			rl.Vector3Subtract(vertices[face[i]], vertices[face[0]]),
+			rl.Vector3Subtract(vertices[face[i+1]], vertices[face[0]]),
+		)
+	}
+	u, v := tangentBasis(rl.Vector3Normalize(normal))
+
+	angles := make(map[int]float64, len(face))
+	for _, index := range face {
+		offset := rl.Vector3Subtract(vertices[index], center)
+		angles[index] = math.Atan2(float64(rl.Vector3DotProduct(offset, v)), float64(rl.Vector3DotProduct(offset, u)))
+	}
+	sort.Slice(face, func(i, j int) bool {
+		return angles[face[i]] < angles[face[j]]
+	})
+
+	// Points added before the face grew may lie inside it or along its sides
+	normal = rl.Vector3Normalize(normal)
+	for removed := true; removed && len(face) > 3; {
+		removed = false
+		for i := range face {
+			previous := vertices[face[(i+len(face)-1)%len(face)]]
+			next := vertices[face[(i+1)%len(face)]]
+			a := rl.Vector3Subtract(vertices[face[i]], previous)
+			b := rl.Vector3Subtract(next, vertices[face[i]])
+			turn := rl.Vector3DotProduct(rl.Vector3CrossProduct(a, b), normal)
+			if turn <= hullTolerance*rl.Vector3Length(a)*rl.Vector3Length(b) {
+				face = append(face[:i], face[i+1:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	return face
+}
+
+// newHullFace - Returns a face from its counter clockwise vertices, the normal is averaged with Newell's method
+func newHullFace(vertices []rl.Vector3, indices []int) hullFace {
+	var normal, center rl.Vector3
+	for i, index := range indices {
+		current, next := vertices[index], vertices[indices[(i+1)%len(indices)]]
+		normal.X += (current.Y - next.Y) * (current.Z + next.Z)
+		normal.Y += (current.Z - next.Z) * (current.X + next.X)
+		normal.Z += (current.X - next.X) * (current.Y + next.Y)
+		center = rl.Vector3Add(center, current)
+	}
+	normal = rl.Vector3Normalize(normal)
+	center = rl.Vector3Scale(center, 1/float32(len(indices)))
+
+	return hullFace{normal: normal, offset: rl.Vector3DotProduct(normal, center), vertices: indices}
+}
+
+// hullEdges - Returns the edges of a closed set of faces, every edge once
+func hullEdges(faces []hullFace) []hullEdge {
+	var edges []hullEdge
+	for _, face := range faces {
+		for i, a := range face.vertices {
+			b := face.vertices[(i+1)%len(face.vertices)]
+			if a < b {
+				edges = append(edges, hullEdge{a, b})
+			}
+		}
+	}
+	return edges
+}
+
+// massProperties - Returns the hull volume, center of mass and inertia tensor about its center of mass for a
+// unit density. The hull is split into tetrahedra joining its faces triangles to an inner point
+func (h *hull) massProperties() (float32, rl.Vector3, mat3) {
+	var reference rl.Vector3
+	for _, vertex := range h.vertices {
+		reference = rl.Vector3Add(reference, vertex)
+	}
+	reference = rl.Vector3Scale(reference, 1/float32(len(h.vertices)))
+
+	var volume float32
+	var center rl.Vector3
+	var covariance mat3
+	for _, face := range h.faces {
+		a := rl.Vector3Subtract(h.vertices[face.vertices[0]], reference)
+		for i := 1; i+1 < len(face.vertices); i++ {
+			b := rl.Vector3Subtract(h.vertices[face.vertices[i]], reference)
+			c := rl.Vector3Subtract(h.vertices[face.vertices[i+1]], reference)
+
+			det := rl.Vector3DotProduct(a, rl.Vector3CrossProduct(b, c))
+			volume += det / 6
+			sum := rl.Vector3Add(rl.Vector3Add(a, b), c)
+			center = rl.Vector3Add(center, rl.Vector3Scale(sum, det/24))
+
+			// Second moments of the tetrahedron about the reference point
+			moments := outerProduct(a, a).add(outerProduct(b, b)).add(outerProduct(c, c)).add(outerProduct(sum, sum))
+			covariance = covariance.add(moments.scale(det / 120))
+		}
+	}
+	if volume <= epsilon {
+		return 0, reference, mat3{}
+	}
+	center = rl.Vector3Scale(center, 1/volume)
+
+	// Move the second moments to the center of mass and turn them into the inertia tensor
+	covariance = covariance.add(outerProduct(center, center).scale(-volume))
+	trace := covariance[0].X + covariance[1].Y + covariance[2].Z
+	inertia := mat3Diagonal(trace, trace, trace).add(covariance.scale(-1))
+
+	return volume, rl.Vector3Add(center, reference), inertia
+}
+
+// translate - Moves the hull vertices and faces planes by an offset
+func (h *hull) translate(offset rl.Vector3) {
+	for i := range h.vertices {
+		h.vertices[i] = rl.Vector3Add(h.vertices[i], offset)
+	}
+	for i := range h.faces {
+		h.faces[i].offset += rl.Vector3DotProduct(h.faces[i].normal, offset)
+	}
+}
@@ -0,0 +1,205 @@
+package physics3d
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Manifold - Collision information between two touching physics bodies
+type Manifold struct {
+	// Manifold first physics body reference
+	BodyA *Body
+	// Manifold second physics body reference
+	BodyB *Body
+	// Normal direction vector from 'a' to 'b'
+	Normal rl.Vector3
+	// Points of contact during collision
+	Points [maxManifoldPoints]ContactPoint
+	// Current collision number of points of contact
+	PointsCount int
+	// Mixed restitution during collision
+	Restitution float32
+	// Mixed dynamic friction during collision
+	DynamicFriction float32
+	// Mixed static friction during collision
+	StaticFriction float32
+	// Mesh triangle index touched by the other body (used for mesh bodies)
+	Triangle int
+	// Friction directions orthogonal to the normal
+	tangents [2]rl.Vector3
+}
+
+// ContactPoint - Point of contact of a manifold
+type ContactPoint struct {
+	// Position in world space, halfway between both shapes surfaces
+	Position rl.Vector3
+	// Depth of penetration, negative while the shapes are close but not touching yet
+	Penetration float32
+	// Normal impulse accumulated during the step, carried over to the next step
+	NormalImpulse float32
+	// Friction impulses accumulated along the manifold tangents, carried over to the next step
+	TangentImpulses [2]float32
+	// Position relative to the first body in its local space, used to match points between steps
+	localA rl.Vector3
+	// Radius from the bodies centers of mass to the point
+	radiusA, radiusB rl.Vector3
+	// Inverse of the bodies effective mass along the normal and tangents
+	normalMass  float32
+	tangentMass [2]float32
+	// Relative normal velocity targeted by the solver, used for restitution and penetration recovery
+	velocityBias float32
+}
+
+// manifoldKey - Identifies the manifold of a pair of bodies, mesh bodies get one per triangle
+type manifoldKey struct {
+	a, b     *Body
+	triangle int
+}
+
+// Manifold constants
+const (
+	// Greatest number of points of contact of a manifold
+	maxManifoldPoints = 4
+	// Greatest distance between points of contact of consecutive steps sharing their impulses
+	contactMatchDistance = 0.05
+	// Smallest cosine between consecutive normals for the points of contact to share their impulses
+	contactMatchCos = 0.95
+)
+
+// setPoints - Replaces the manifold points of contact, keeping the accumulated impulses of previous points
+// close to the new ones
+func (m *Manifold) setPoints(normal rl.Vector3, candidates []contactCandidate) {
+	previous, count := m.Points, m.PointsCount
+	if rl.Vector3DotProduct(m.Normal, normal) < contactMatchCos {
+		count = 0
+	}
+
+	m.Normal = normal
+	m.PointsCount = len(candidates)
+	for i, candidate := range candidates {
+		point := ContactPoint{
+			Position:    candidate.position,
+			Penetration: candidate.penetration,
+			localA:      inverseRotateVector(m.BodyA.Rotation, rl.Vector3Subtract(candidate.position, m.BodyA.Position)),
+		}
+
+		closest := float32(contactMatchDistance * contactMatchDistance)
+		for j := 0; j < count; j++ {
+			if distance := rl.Vector3DistanceSqr(point.localA, previous[j].localA); distance <= closest {
+				closest = distance
+				point.NormalImpulse = previous[j].NormalImpulse
+				point.TangentImpulses = previous[j].TangentImpulses
+			}
+		}
+		m.Points[i] = point
+	}
+}
+
+// initialize - Initializes the manifold to solve the collision, applying the impulses carried over from the
+// previous step
+func (m *Manifold) initialize(dt float32) {
+	bodyA, bodyB := m.BodyA, m.BodyB
+
+	// Calculate average restitution, static and dynamic friction
+	m.Restitution = float32(math.Sqrt(float64(bodyA.Restitution * bodyB.Restitution)))
+	m.StaticFriction = float32(math.Sqrt(float64(bodyA.StaticFriction * bodyB.StaticFriction)))
+	m.DynamicFriction = float32(math.Sqrt(float64(bodyA.DynamicFriction * bodyB.DynamicFriction)))
+	m.tangents[0], m.tangents[1] = tangentBasis(m.Normal)
+
+	for i := 0; i < m.PointsCount; i++ {
+		point := &m.Points[i]
+		point.radiusA = rl.Vector3Subtract(point.Position, bodyA.Position)
+		point.radiusB = rl.Vector3Subtract(point.Position, bodyB.Position)
+		point.normalMass = safeDiv(1, effectiveMass(bodyA, bodyB, point.radiusA, point.radiusB, m.Normal))
+		point.tangentMass[0] = safeDiv(1, effectiveMass(bodyA, bodyB, point.radiusA, point.radiusB, m.tangents[0]))
+		point.tangentMass[1] = safeDiv(1, effectiveMass(bodyA, bodyB, point.radiusA, point.radiusB, m.tangents[1]))
+
+		// Separated points let the bodies approach until they touch, penetrating ones push them apart
+		if point.Penetration < 0 {
+			point.velocityBias = point.Penetration / dt
+		} else {
+			point.velocityBias = min(baumgarte/dt*max(point.Penetration-linearSlop, 0), maxCorrectionSpeed)
+		}
+
+		// Bounce back with the restitution of the approaching velocity
+		velocity := rl.Vector3DotProduct(relativeVelocity(bodyA, bodyB, point.radiusA, point.radiusB), m.Normal)
+		if velocity < -restitutionThreshold {
+			point.velocityBias = max(point.velocityBias, -m.Restitution*velocity)
+		}
+
+		// Warm start with the previous step accumulated impulses
+		impulse := rl.Vector3Scale(m.Normal, point.NormalImpulse)
+		impulse = rl.Vector3Add(impulse, rl.Vector3Scale(m.tangents[0], point.TangentImpulses[0]))
+		impulse = rl.Vector3Add(impulse, rl.Vector3Scale(m.tangents[1], point.TangentImpulses[1]))
+		applyImpulsePair(bodyA, bodyB, point.radiusA, point.radiusB, impulse)
+	}
+}
+
+// solveVelocity - Integrates the manifold impulses. Impulses are accumulated along the step, normal impulses can
+// only push bodies apart and friction impulses are bounded by coulomb's law
+func (m *Manifold) solveVelocity() {
+	bodyA, bodyB := m.BodyA, m.BodyB
+
+	for i := 0; i < m.PointsCount; i++ {
+		point := &m.Points[i]
+
+		// Friction first, so non penetration is the last constraint solved
+		velocity := relativeVelocity(bodyA, bodyB, point.radiusA, point.radiusB)
+		previous := point.TangentImpulses
+		for j, tangent := range m.tangents {
+			point.TangentImpulses[j] -= rl.Vector3DotProduct(velocity, tangent) * point.tangentMass[j]
+		}
+
+		// Sliding contacts use the dynamic friction
+		friction := float32(math.Hypot(float64(point.TangentImpulses[0]), float64(point.TangentImpulses[1])))
+		if friction > m.StaticFriction*point.NormalImpulse {
+			scale := safeDiv(m.DynamicFriction*point.NormalImpulse, friction)
+			point.TangentImpulses[0] *= scale
+			point.TangentImpulses[1] *= scale
+		}
+
+		impulse := rl.Vector3Scale(m.tangents[0], point.TangentImpulses[0]-previous[0])
+		impulse = rl.Vector3Add(impulse, rl.Vector3Scale(m.tangents[1], point.TangentImpulses[1]-previous[1]))
+		applyImpulsePair(bodyA, bodyB, point.radiusA, point.radiusB, impulse)
+
+		// Normal impulse, clamping the accumulated impulse so bodies are never pulled together
+		velocity = relativeVelocity(bodyA, bodyB, point.radiusA, point.radiusB)
+		normalVelocity := rl.Vector3DotProduct(velocity, m.Normal)
+		accumulated := max(point.NormalImpulse-point.normalMass*(normalVelocity-point.velocityBias), 0)
+		normalImpulse := accumulated - point.NormalImpulse
+		point.NormalImpulse = accumulated
+		applyImpulsePair(bodyA, bodyB, point.radiusA, point.radiusB, rl.Vector3Scale(m.Normal, normalImpulse))
+	}
+}
+
+// effectiveMass - Returns the inverse of the effective mass of two bodies at a point of contact along a direction
+func effectiveMass(bodyA, bodyB *Body, radiusA, radiusB, direction rl.Vector3) float32 {
+	crossA := rl.Vector3CrossProduct(radiusA, direction)
+	crossB := rl.Vector3CrossProduct(radiusB, direction)
+	return bodyA.invMass() + bodyB.invMass() +
+		rl.Vector3DotProduct(crossA, bodyA.worldInverseInertia.mulVector(crossA)) +
+		rl.Vector3DotProduct(crossB, bodyB.worldInverseInertia.mulVector(crossB))
+}
+
+// relativeVelocity - Returns the velocity of the second body relative to the first one at a point
+func relativeVelocity(bodyA, bodyB *Body, radiusA, radiusB rl.Vector3) rl.Vector3 {
+	velocityA := rl.Vector3Add(bodyA.Velocity, rl.Vector3CrossProduct(bodyA.AngularVelocity, radiusA))
+	velocityB := rl.Vector3Add(bodyB.Velocity, rl.Vector3CrossProduct(bodyB.AngularVelocity, radiusB))
+	return rl.Vector3Subtract(velocityB, velocityA)
+}
+
+// applyImpulsePair - Applies an impulse to the second body and the opposite one to the first body at a point
+func applyImpulsePair(bodyA, bodyB *Body, radiusA, radiusB, impulse rl.Vector3) {
+	if invMass := bodyA.invMass(); invMass > 0 {
+		bodyA.Velocity = rl.Vector3Subtract(bodyA.Velocity, rl.Vector3Scale(impulse, invMass))
+		angular := bodyA.worldInverseInertia.mulVector(rl.Vector3CrossProduct(radiusA, impulse))
+		bodyA.AngularVelocity = rl.Vector3Subtract(bodyA.AngularVelocity, angular)
+	}
+
+	if invMass := bodyB.invMass(); invMass > 0 {
+		bodyB.Velocity = rl.Vector3Add(bodyB.Velocity, rl.Vector3Scale(impulse, invMass))
+		angular := bodyB.worldInverseInertia.mulVector(rl.Vector3CrossProduct(radiusB, impulse))
+		bodyB.AngularVelocity = rl.Vector3Add(bodyB.AngularVelocity, angular)
+	}
+}
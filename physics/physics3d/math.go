@@ -0,0 +1,186 @@
+package physics3d
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// mat3 - 3x3 matrix stored by rows, used for rotations and inertia tensors
+type mat3 [3]rl.Vector3
+
+// mat3Diagonal - Returns a diagonal matrix
+func mat3Diagonal(x, y, z float32) mat3 {
+	return mat3{{X: x}, {Y: y}, {Z: z}}
+}
+
+// mat3FromQuaternion - Returns the rotation matrix of a unit quaternion
+func mat3FromQuaternion(q rl.Quaternion) mat3 {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return mat3{
+		{X: 1 - 2*(yy+zz), Y: 2 * (xy - wz), Z: 2 * (xz + wy)},
+		{X: 2 * (xy + wz), Y: 1 - 2*(xx+zz), Z: 2 * (yz - wx)},
+		{X: 2 * (xz - wy), Y: 2 * (yz + wx), Z: 1 - 2*(xx+yy)},
+	}
+}
+
+// mulVector - Returns the matrix multiplied by a column vector
+func (m mat3) mulVector(v rl.Vector3) rl.Vector3 {
+	return rl.NewVector3(rl.Vector3DotProduct(m[0], v), rl.Vector3DotProduct(m[1], v), rl.Vector3DotProduct(m[2], v))
+}
+
+// transpose - Returns the transposed matrix
+func (m mat3) transpose() mat3 {
+	return mat3{
+		{X: m[0].X, Y: m[1].X, Z: m[2].X},
+		{X: m[0].Y, Y: m[1].Y, Z: m[2].Y},
+		{X: m[0].Z, Y: m[1].Z, Z: m[2].Z},
+	}
+}
+
+// mul - Returns the product of two matrices
+func (m mat3) mul(n mat3) mat3 {
+	t := n.transpose()
+	var result mat3
+	for i := range result {
+		result[i] = t.mulVector(m[i])
+	}
+	return result
+}
+
+// add - Returns the sum of two matrices
+func (m mat3) add(n mat3) mat3 {
+	return mat3{rl.Vector3Add(m[0], n[0]), rl.Vector3Add(m[1], n[1]), rl.Vector3Add(m[2], n[2])}
+}
+
+// scale - Returns the matrix scaled by a value
+func (m mat3) scale(s float32) mat3 {
+	return mat3{rl.Vector3Scale(m[0], s), rl.Vector3Scale(m[1], s), rl.Vector3Scale(m[2], s)}
+}
+
+// inverse - Returns the inverse matrix, or a zero matrix if it is singular
+func (m mat3) inverse() mat3 {
+	// Rows of the inverse are the cross products of the columns over the determinant
+	c := m.transpose()
+	r0 := rl.Vector3CrossProduct(c[1], c[2])
+	r1 := rl.Vector3CrossProduct(c[2], c[0])
+	r2 := rl.Vector3CrossProduct(c[0], c[1])
+
+	det := rl.Vector3DotProduct(c[0], r0)
+	if math.Abs(float64(det)) < epsilon*epsilon {
+		return mat3{}
+	}
+	return mat3{r0, r1, r2}.scale(1 / det)
+}
+
+// outerProduct - Returns the matrix a * b^T
+func outerProduct(a, b rl.Vector3) mat3 {
+	return mat3{rl.Vector3Scale(b, a.X), rl.Vector3Scale(b, a.Y), rl.Vector3Scale(b, a.Z)}
+}
+
+// rotateVector - Rotates a vector by a unit quaternion
+func rotateVector(q rl.Quaternion, v rl.Vector3) rl.Vector3 {
+	return rl.Vector3RotateByQuaternion(v, q)
+}
+
+// inverseRotateVector - Rotates a vector by the inverse of a unit quaternion
+func inverseRotateVector(q rl.Quaternion, v rl.Vector3) rl.Vector3 {
+	return rl.Vector3RotateByQuaternion(v, rl.NewQuaternion(-q.X, -q.Y, -q.Z, q.W))
+}
+
+// integrateRotation - Returns a unit quaternion rotated by an angular velocity during dt
+func integrateRotation(q rl.Quaternion, angularVelocity rl.Vector3, dt float32) rl.Quaternion {
+	spin := rl.QuaternionMultiply(rl.NewQuaternion(angularVelocity.X, angularVelocity.Y, angularVelocity.Z, 0), q)
+	q = rl.QuaternionAdd(q, rl.QuaternionScale(spin, dt/2))
+	return rl.QuaternionNormalize(q)
+}
+
+// tangentBasis - Returns two unit vectors orthogonal to a unit normal and to each other
+func tangentBasis(normal rl.Vector3) (rl.Vector3, rl.Vector3) {
+	var tangent rl.Vector3
+	if math.Abs(float64(normal.X)) >= 0.57735 {
+		tangent = rl.NewVector3(normal.Y, -normal.X, 0)
+	} else {
+		tangent = rl.NewVector3(0, normal.Z, -normal.Y)
+	}
+	tangent = rl.Vector3Normalize(tangent)
+	return tangent, rl.Vector3CrossProduct(normal, tangent)
+}
+
+// closestPointOnSegment - Returns the point of a segment closest to a position
+func closestPointOnSegment(a, b, position rl.Vector3) rl.Vector3 {
+	ab := rl.Vector3Subtract(b, a)
+	length := rl.Vector3LengthSqr(ab)
+	if length < epsilon*epsilon {
+		return a
+	}
+
+	t := clamp(rl.Vector3DotProduct(rl.Vector3Subtract(position, a), ab)/length, 0, 1)
+	return rl.Vector3Add(a, rl.Vector3Scale(ab, t))
+}
+
+// closestPointsSegments - Returns the closest points between segments p1 q1 and p2 q2
+func closestPointsSegments(p1, q1, p2, q2 rl.Vector3) (rl.Vector3, rl.Vector3) {
+	d1 := rl.Vector3Subtract(q1, p1)
+	d2 := rl.Vector3Subtract(q2, p2)
+	r := rl.Vector3Subtract(p1, p2)
+	a := rl.Vector3DotProduct(d1, d1)
+	e := rl.Vector3DotProduct(d2, d2)
+	f := rl.Vector3DotProduct(d2, r)
+
+	// Both segments degenerate into points
+	if a <= epsilon*epsilon && e <= epsilon*epsilon {
+		return p1, p2
+	}
+
+	var s, t float32
+	if a <= epsilon*epsilon {
+		t = clamp(f/e, 0, 1)
+	} else {
+		c := rl.Vector3DotProduct(d1, r)
+		if e <= epsilon*epsilon {
+			s = clamp(-c/a, 0, 1)
+		} else {
+			b := rl.Vector3DotProduct(d1, d2)
+			denom := a*e - b*b
+
+			// Parallel segments pick any point of the first one
+			if denom > epsilon*epsilon {
+				s = clamp((b*f-c*e)/denom, 0, 1)
+			}
+
+			t = (b*s + f) / e
+			if t < 0 {
+				t = 0
+				s = clamp(-c/a, 0, 1)
+			} else if t > 1 {
+				t = 1
+				s = clamp((b-c)/a, 0, 1)
+			}
+		}
+	}
+
+	return rl.Vector3Add(p1, rl.Vector3Scale(d1, s)), rl.Vector3Add(p2, rl.Vector3Scale(d2, t))
+}
+
+// clamp - Clamps a value between min and max
+func clamp(value, min, max float32) float32 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// safeDiv - Divides a by b, returning 0 when b is 0
+func safeDiv(a, b float32) float32 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
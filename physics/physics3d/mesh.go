@@ -0,0 +1,173 @@
+package physics3d
+
+import (
+	"errors"
+	"sort"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// triangleMesh - Static triangle soup in body local space with a bounding volume tree to find triangles quickly
+type triangleMesh struct {
+	// Vertices positions
+	vertices []rl.Vector3
+	// Triangles vertices indices, wound counter clockwise seen from their front side
+	triangles [][3]int
+	// Bounding volume tree nodes, the root first
+	nodes []meshNode
+}
+
+// meshNode - Bounding volume tree node, leaves hold a range of triangles
+type meshNode struct {
+	// Bounding box of the node triangles
+	bounds rl.BoundingBox
+	// Children nodes indices of inner nodes
+	left, right int
+	// Triangles range of leaf nodes, inner nodes have no triangles
+	start, count int
+}
+
+// Greatest number of triangles in a mesh tree leaf
+const meshLeafTriangles = 4
+
+// newTriangleMesh - Returns the triangles of a set of meshes transformed by a matrix, degenerate triangles are
+// dropped
+func newTriangleMesh(meshes []rl.Mesh, transform rl.Matrix) (*triangleMesh, error) {
+	m := &triangleMesh{}
+	for _, mesh := range meshes {
+		if mesh.Vertices == nil || mesh.VertexCount <= 0 {
+			continue
+		}
+
+		base := len(m.vertices)
+		positions := unsafe.Slice(mesh.Vertices, mesh.VertexCount*3)
+		for i := 0; i < len(positions); i += 3 {
+			vertex := rl.NewVector3(positions[i], positions[i+1], positions[i+2])
+			m.vertices = append(m.vertices, rl.Vector3Transform(vertex, transform))
+		}
+
+		// Non indexed meshes store every triangle vertices in order
+		count := int(mesh.TriangleCount)
+		var indices []uint16
+		if mesh.Indices != nil {
+			indices = unsafe.Slice(mesh.Indices, count*3)
+		} else {
+			count = int(mesh.VertexCount) / 3
+		}
+
+		for i := 0; i < count; i++ {
+			triangle := [3]int{base + i*3, base + i*3 + 1, base + i*3 + 2}
+			if indices != nil {
+				triangle = [3]int{base + int(indices[i*3]), base + int(indices[i*3+1]), base + int(indices[i*3+2])}
+			}
+			if triangle[0] >= len(m.vertices) || triangle[1] >= len(m.vertices) || triangle[2] >= len(m.vertices) {
+				return nil, errors.New("physics3d: mesh triangle references a missing vertex")
+			}
+
+			a, b, c := m.vertices[triangle[0]], m.vertices[triangle[1]], m.vertices[triangle[2]]
+			normal := rl.Vector3CrossProduct(rl.Vector3Subtract(b, a), rl.Vector3Subtract(c, a))
+			if rl.Vector3LengthSqr(normal) > epsilon*epsilon {
+				m.triangles = append(m.triangles, triangle)
+			}
+		}
+	}
+
+	if len(m.triangles) == 0 {
+		return nil, errors.New("physics3d: mesh has no triangles")
+	}
+
+	m.nodes = make([]meshNode, 0, 2*len(m.triangles)/meshLeafTriangles+1)
+	m.build(0, len(m.triangles))
+	return m, nil
+}
+
+// build - Builds the tree node of a range of triangles splitting them at the median of their longest axis,
+// returning the node index
+func (m *triangleMesh) build(start, count int) int {
+	index := len(m.nodes)
+	m.nodes = append(m.nodes, meshNode{start: start, count: count})
+
+	bounds := m.triangleBounds(start)
+	centersBounds := rl.NewBoundingBox(m.triangleCenter(start), m.triangleCenter(start))
+	for i := start + 1; i < start+count; i++ {
+		box := m.triangleBounds(i)
+		bounds.Min = rl.Vector3Min(bounds.Min, box.Min)
+		bounds.Max = rl.Vector3Max(bounds.Max, box.Max)
+		centersBounds.Min = rl.Vector3Min(centersBounds.Min, m.triangleCenter(i))
+		centersBounds.Max = rl.Vector3Max(centersBounds.Max, m.triangleCenter(i))
+	}
+	m.nodes[index].bounds = bounds
+	if count <= meshLeafTriangles {
+		return index
+	}
+
+	// Sort the triangles along the axis their centers spread the most
+	extent := rl.Vector3Subtract(centersBounds.Max, centersBounds.Min)
+	axis := func(v rl.Vector3) float32 { return v.X }
+	if extent.Y > extent.X && extent.Y >= extent.Z {
+		axis = func(v rl.Vector3) float32 { return v.Y }
+	} else if extent.Z > extent.X && extent.Z > extent.Y {
+		axis = func(v rl.Vector3) float32 { return v.Z }
+	}
+	triangles := m.triangles[start : start+count]
+	sort.SliceStable(triangles, func(i, j int) bool {
+		return axis(m.centerOf(triangles[i])) < axis(m.centerOf(triangles[j]))
+	})
+
+	half := count / 2
+	left := m.build(start, half)
+	right := m.build(start+half, count-half)
+	m.nodes[index] = meshNode{bounds: bounds, left: left, right: right}
+	return index
+}
+
+// bounds - Returns the bounding box of every triangle
+func (m *triangleMesh) bounds() rl.BoundingBox {
+	return m.nodes[0].bounds
+}
+
+// query - Calls fn with every triangle whose bounding box overlaps a box
+func (m *triangleMesh) query(box rl.BoundingBox, fn func(triangle int)) {
+	stack := []int{0}
+	for len(stack) > 0 {
+		node := m.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !boundingBoxesOverlap(node.bounds, box) {
+			continue
+		}
+
+		if node.count == 0 {
+			stack = append(stack, node.left, node.right)
+			continue
+		}
+		for i := node.start; i < node.start+node.count; i++ {
+			if boundingBoxesOverlap(m.triangleBounds(i), box) {
+				fn(i)
+			}
+		}
+	}
+}
+
+// triangle - Returns a triangle vertices
+func (m *triangleMesh) triangle(index int) (rl.Vector3, rl.Vector3, rl.Vector3) {
+	triangle := m.triangles[index]
+	return m.vertices[triangle[0]], m.vertices[triangle[1]], m.vertices[triangle[2]]
+}
+
+// triangleBounds - Returns a triangle bounding box
+func (m *triangleMesh) triangleBounds(index int) rl.BoundingBox {
+	a, b, c := m.triangle(index)
+	return rl.NewBoundingBox(rl.Vector3Min(a, rl.Vector3Min(b, c)), rl.Vector3Max(a, rl.Vector3Max(b, c)))
+}
+
+// triangleCenter - Returns a triangle centroid
+func (m *triangleMesh) triangleCenter(index int) rl.Vector3 {
+	return m.centerOf(m.triangles[index])
+}
+
+// centerOf - Returns the centroid of triangle vertices indices
+func (m *triangleMesh) centerOf(triangle [3]int) rl.Vector3 {
+	sum := rl.Vector3Add(rl.Vector3Add(m.vertices[triangle[0]], m.vertices[triangle[1]]), m.vertices[triangle[2]])
+	return rl.Vector3Scale(sum, 1.0/3.0)
+}
@@ -0,0 +1,422 @@
+// Package physics3d - 3D rigid body physics library for videogames
+//
+// Bodies are spheres, boxes, capsules, convex hulls or static triangle meshes simulated with sequential impulses.
+// Distances are in world units, times in seconds and the Y axis points up like raylib 3D cameras.
+package physics3d
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BodyType type
+type BodyType int
+
+// Physics body types
+const (
+	// Never moves, behaves as if it had infinite mass
+	StaticBody BodyType = iota
+	// Moved by its velocity only, unaffected by forces and collisions but pushes dynamic bodies
+	KinematicBody
+	// Moved by forces, gravity and collisions
+	DynamicBody
+)
+
+// Body type
+type Body struct {
+	// Reference unique identifier
+	ID int
+	// Physics body type (static, kinematic or dynamic)
+	Type BodyType
+	// Enabled dynamics state (collisions are calculated anyway)
+	Enabled bool
+	// Center of mass position
+	Position rl.Vector3
+	// Orientation unit quaternion
+	Rotation rl.Quaternion
+	// Current linear velocity applied to position
+	Velocity rl.Vector3
+	// Current angular velocity in world space, in radians per second
+	AngularVelocity rl.Vector3
+	// Current linear force (reset to 0 every step)
+	Force rl.Vector3
+	// Current torque in world space (reset to 0 every step)
+	Torque rl.Vector3
+	// Physics body mass
+	Mass float32
+	// Inverse value of mass
+	InverseMass float32
+	// Friction when the body has not movement (0 to 1)
+	StaticFriction float32
+	// Friction when the body has movement (0 to 1)
+	DynamicFriction float32
+	// Restitution coefficient of the body (0 to 1)
+	Restitution float32
+	// Fraction of the linear velocity lost every second
+	LinearDamping float32
+	// Fraction of the angular velocity lost every second
+	AngularDamping float32
+	// Apply gravity force to dynamics
+	UseGravity bool
+	// Physics grounded on other body state, set when a contact normal is steeper than groundNormalY
+	IsGrounded bool
+	// Physics rotation constraint
+	FreezeOrient bool
+	// Physics body shape information
+	Shape Shape
+	// Inverse inertia tensor in body local space
+	inverseInertia mat3
+	// Inverse inertia tensor in world space used to solve collisions, updated every step
+	worldInverseInertia mat3
+	// Shape hull in world space, updated every step
+	worldHull worldHull
+	// Physics world the body belongs to
+	world *World
+}
+
+// World - Physics world holding bodies and their collisions
+type World struct {
+	// Physics bodies pointers slice
+	bodies []*Body
+	// Identifier given to the next body
+	nextID int
+	// Gravity acceleration
+	gravity rl.Vector3
+	// Fixed time step used by Update, in seconds
+	timeStep float32
+	// Time elapsed not simulated yet by Update, in seconds
+	accumulator float32
+	// Number of velocity iterations of the contact solver
+	velocityIterations int
+	// Physics manifolds pointers slice
+	manifolds []*Manifold
+	// Previous step manifolds slice, reused to avoid allocations
+	manifoldsBuffer []*Manifold
+	// Manifolds of the previous step by shapes pair, reused by pairs that keep touching
+	manifoldCache map[manifoldKey]*Manifold
+	// Bodies bounding boxes computed every step
+	bounds []rl.BoundingBox
+	// Bodies indices sorted along the x axis, reused to avoid allocations
+	order []int
+	// Collision detection buffers
+	narrowphase narrowphase
+}
+
+// Constants
+const (
+	epsilon = 0.000001
+
+	defaultTimeStep           = 1.0 / 60.0
+	defaultVelocityIterations = 8
+
+	// Greatest time Update simulates in one call, slow frames beyond it slow the simulation down
+	maxUpdateTime = 0.25
+	// Distance shapes may overlap without being pushed apart, avoids resting contacts jitter
+	linearSlop = 0.005
+	// Distance under which contacts are created before shapes touch, so fast bodies stop on time
+	speculativeDistance = 4 * linearSlop
+	// Fraction of the penetration corrected every step
+	baumgarte = 0.2
+	// Greatest speed used to push overlapping bodies apart
+	maxCorrectionSpeed = 4.0
+	// Relative normal speed under which collisions do not bounce
+	restitutionThreshold = 1.0
+	// Smallest contact normal Y component grounding the body on top
+	groundNormalY = 0.7
+)
+
+// NewWorld - Creates a new physics world with default gravity and time step
+func NewWorld() *World {
+	return &World{
+		gravity:            rl.NewVector3(0, -9.81, 0),
+		timeStep:           defaultTimeStep,
+		velocityIterations: defaultVelocityIterations,
+		manifoldCache:      make(map[manifoldKey]*Manifold),
+	}
+}
+
+// SetGravity - Sets physics global gravity force
+func (w *World) SetGravity(x, y, z float32) {
+	w.gravity = rl.NewVector3(x, y, z)
+}
+
+// SetTimeStep - Sets physics fixed time step used by Update, in seconds
+func (w *World) SetTimeStep(delta float32) {
+	if delta > 0 {
+		w.timeStep = delta
+	}
+}
+
+// SetIterations - Sets the number of velocity iterations of the contact solver, more iterations make stacks
+// stiffer at a higher cost
+func (w *World) SetIterations(velocityIterations int) {
+	w.velocityIterations = max(velocityIterations, 1)
+}
+
+// GetBodies - Returns the physics bodies of the world
+func (w *World) GetBodies() []*Body {
+	return w.bodies
+}
+
+// GetManifolds - Returns the collisions found in the last step
+func (w *World) GetManifolds() []*Manifold {
+	return w.manifolds
+}
+
+// NewBodySphere - Creates a new sphere physics body with generic parameters
+func (w *World) NewBodySphere(pos rl.Vector3, radius, density float32) *Body {
+	return w.newBody(pos, Shape{Type: SphereShape, Radius: radius}, density)
+}
+
+// NewBodyBox - Creates a new box physics body with generic parameters, size is the full box size
+func (w *World) NewBodyBox(pos, size rl.Vector3, density float32) *Body {
+	halfExtents := rl.Vector3Scale(size, 0.5)
+	return w.newBody(pos, Shape{Type: BoxShape, HalfExtents: halfExtents, hull: newBoxHull(halfExtents)}, density)
+}
+
+// NewBodyCapsule - Creates a new capsule physics body standing along the Y axis with generic parameters, height
+// is the distance between its hemispheres centers
+func (w *World) NewBodyCapsule(pos rl.Vector3, radius, height, density float32) *Body {
+	return w.newBody(pos, Shape{Type: CapsuleShape, Radius: radius, HalfHeight: height / 2}, density)
+}
+
+// NewBodyConvexHull - Creates a new physics body from the convex hull of a set of points relative to pos. The
+// body position is placed at the hull center of mass
+func (w *World) NewBodyConvexHull(pos rl.Vector3, points []rl.Vector3, density float32) (*Body, error) {
+	h, err := newConvexHull(points)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move the hull so its center of mass is at the body position
+	_, center, _ := h.massProperties()
+	h.translate(rl.Vector3Negate(center))
+	return w.newBody(rl.Vector3Add(pos, center), Shape{Type: ConvexHullShape, hull: h}, density), nil
+}
+
+// NewBodyMesh - Creates a new static physics body from the triangles of a mesh placed at pos. Mesh bodies are
+// used for level geometry and can not be made dynamic
+func (w *World) NewBodyMesh(pos rl.Vector3, mesh rl.Mesh) (*Body, error) {
+	m, err := newTriangleMesh([]rl.Mesh{mesh}, rl.MatrixIdentity())
+	if err != nil {
+		return nil, err
+	}
+	return w.newBody(pos, Shape{Type: MeshShape, mesh: m}, 0), nil
+}
+
+// NewBodyModel - Creates a new static physics body from the triangles of every mesh of a model, transformed by
+// the model transform
+func (w *World) NewBodyModel(model rl.Model) (*Body, error) {
+	m, err := newTriangleMesh(model.GetMeshes(), model.Transform)
+	if err != nil {
+		return nil, err
+	}
+	return w.newBody(rl.Vector3{}, Shape{Type: MeshShape, mesh: m}, 0), nil
+}
+
+// newBody - Creates a new physics body with generic parameters and computes its mass from its shape
+func (w *World) newBody(pos rl.Vector3, shape Shape, density float32) *Body {
+	newBody := &Body{
+		ID:              w.nextID,
+		Type:            DynamicBody,
+		Enabled:         true,
+		Position:        pos,
+		Rotation:        rl.QuaternionIdentity(),
+		StaticFriction:  0.4,
+		DynamicFriction: 0.2,
+		Restitution:     0.0,
+		LinearDamping:   0.0,
+		AngularDamping:  0.05,
+		UseGravity:      true,
+		Shape:           shape,
+		world:           w,
+	}
+	w.nextID++
+
+	newBody.Shape.Body = newBody
+	if shape.Type == MeshShape {
+		newBody.Type = StaticBody
+	}
+
+	// Calculate mass and inertia from the shape volume
+	mass, inertia := shape.massProperties(density)
+	newBody.Mass = mass
+	newBody.InverseMass = safeDiv(1, mass)
+	newBody.inverseInertia = inertia.inverse()
+	newBody.updateTransform()
+
+	// Add new body to bodies pointers array
+	w.bodies = append(w.bodies, newBody)
+	return newBody
+}
+
+// AddForce - Adds a force to a physics body, applied at its center of mass
+func (b *Body) AddForce(force rl.Vector3) {
+	b.Force = rl.Vector3Add(b.Force, force)
+}
+
+// AddForceAtPosition - Adds a force to a physics body applied at a world position, adding its torque too
+func (b *Body) AddForceAtPosition(force, position rl.Vector3) {
+	b.Force = rl.Vector3Add(b.Force, force)
+	b.Torque = rl.Vector3Add(b.Torque, rl.Vector3CrossProduct(rl.Vector3Subtract(position, b.Position), force))
+}
+
+// AddTorque - Adds an angular force in world space to a physics body
+func (b *Body) AddTorque(torque rl.Vector3) {
+	b.Torque = rl.Vector3Add(b.Torque, torque)
+}
+
+// SetType - Sets physics body type, switching to static clears its velocity. Mesh bodies are always static
+func (b *Body) SetType(bodyType BodyType) {
+	if b.Shape.Type == MeshShape {
+		return
+	}
+
+	b.Type = bodyType
+	if bodyType == StaticBody {
+		b.Velocity = rl.Vector3{}
+		b.AngularVelocity = rl.Vector3{}
+	}
+}
+
+// GetTransform - Returns the physics body transform, usable as a model transform to draw it
+func (b *Body) GetTransform() rl.Matrix {
+	return rl.MatrixMultiply(rl.QuaternionToMatrix(b.Rotation), rl.MatrixTranslate(b.Position.X, b.Position.Y, b.Position.Z))
+}
+
+// Destroy - Unitializes and destroys a physics body
+func (b *Body) Destroy() {
+	w := b.world
+	if w == nil {
+		return
+	}
+
+	for i, body := range w.bodies {
+		if body == b {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			break
+		}
+	}
+
+	// Forget the collisions of the body
+	manifolds := w.manifolds[:0]
+	for _, manifold := range w.manifolds {
+		if manifold.BodyA != b && manifold.BodyB != b {
+			manifolds = append(manifolds, manifold)
+		}
+	}
+	clear(w.manifolds[len(manifolds):])
+	w.manifolds = manifolds
+	b.world = nil
+}
+
+// invMass - Returns the inverse mass used to solve collisions, bodies not moved by collisions have infinite mass
+func (b *Body) invMass() float32 {
+	if b.Type != DynamicBody || !b.Enabled {
+		return 0
+	}
+	return b.InverseMass
+}
+
+// invInertia - Returns the inverse inertia tensor in world space used to solve collisions
+func (b *Body) invInertia() mat3 {
+	if b.Type != DynamicBody || !b.Enabled || b.FreezeOrient {
+		return mat3{}
+	}
+	rotation := mat3FromQuaternion(b.Rotation)
+	return rotation.mul(b.inverseInertia).mul(rotation.transpose())
+}
+
+// updateTransform - Updates the shape data in world space after the body moved
+func (b *Body) updateTransform() {
+	if b.Shape.hull != nil {
+		b.worldHull.set(b.Shape.hull, b.Position, b.Rotation)
+	}
+}
+
+// Update - Runs as many fixed physics steps as fit in the elapsed time (usually rl.GetFrameTime), carrying the
+// remaining time over to the next call
+func (w *World) Update(elapsed float32) {
+	w.accumulator = min(w.accumulator+elapsed, maxUpdateTime)
+	for w.accumulator >= w.timeStep {
+		w.Step(w.timeStep)
+		w.accumulator -= w.timeStep
+	}
+}
+
+// Step - Runs a single physics step of dt seconds
+func (w *World) Step(dt float32) {
+	if dt <= 0 {
+		return
+	}
+
+	// Bodies may have been moved since the previous step
+	for _, body := range w.bodies {
+		body.updateTransform()
+	}
+
+	// Generate new collision information
+	w.collide()
+
+	// Integrate forces to physics bodies
+	for _, body := range w.bodies {
+		w.integrateForces(body, dt)
+	}
+
+	// Initialize physics manifolds to solve collisions
+	for _, manifold := range w.manifolds {
+		manifold.initialize(dt)
+	}
+
+	// Integrate physics collisions impulses to solve collisions
+	for i := 0; i < w.velocityIterations; i++ {
+		for _, manifold := range w.manifolds {
+			manifold.solveVelocity()
+		}
+	}
+
+	// Integrate velocity to physics bodies
+	for _, body := range w.bodies {
+		integrateVelocity(body, dt)
+	}
+}
+
+// integrateForces - Integrates physics forces into velocity
+func (w *World) integrateForces(body *Body, dt float32) {
+	body.worldInverseInertia = body.invInertia()
+	if body.Type != DynamicBody || !body.Enabled {
+		body.Force = rl.Vector3{}
+		body.Torque = rl.Vector3{}
+		return
+	}
+
+	acceleration := rl.Vector3Scale(body.Force, body.InverseMass)
+	if body.UseGravity {
+		acceleration = rl.Vector3Add(acceleration, w.gravity)
+	}
+	body.Velocity = rl.Vector3Add(body.Velocity, rl.Vector3Scale(acceleration, dt))
+	body.Velocity = rl.Vector3Scale(body.Velocity, 1/(1+dt*body.LinearDamping))
+
+	if body.FreezeOrient {
+		body.AngularVelocity = rl.Vector3{}
+	} else {
+		angularAcceleration := body.worldInverseInertia.mulVector(body.Torque)
+		body.AngularVelocity = rl.Vector3Add(body.AngularVelocity, rl.Vector3Scale(angularAcceleration, dt))
+		body.AngularVelocity = rl.Vector3Scale(body.AngularVelocity, 1/(1+dt*body.AngularDamping))
+	}
+
+	body.Force = rl.Vector3{}
+	body.Torque = rl.Vector3{}
+}
+
+// integrateVelocity - Integrates physics velocity into position and rotation
+func integrateVelocity(body *Body, dt float32) {
+	if body.Type == StaticBody || !body.Enabled {
+		return
+	}
+
+	body.Position = rl.Vector3Add(body.Position, rl.Vector3Scale(body.Velocity, dt))
+	if !body.FreezeOrient && rl.Vector3LengthSqr(body.AngularVelocity) > 0 {
+		body.Rotation = integrateRotation(body.Rotation, body.AngularVelocity, dt)
+	}
+	body.updateTransform()
+}
@@ -0,0 +1,296 @@
+package physics3d
+
+import (
+	"math"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Default physics time step in seconds
+const defaultTestStep = 1.0 / 60.0
+
+// newGroundMesh - Returns a flat indexed mesh of two triangles facing up, half size wide around the origin
+func newGroundMesh(half float32) rl.Mesh {
+	vertices := []float32{
+		-half, 0, -half,
+		-half, 0, half,
+		half, 0, half,
+		half, 0, -half,
+	}
+	indices := []uint16{0, 1, 2, 0, 2, 3}
+	return rl.Mesh{
+		VertexCount:   4,
+		TriangleCount: 2,
+		Vertices:      &vertices[0],
+		Indices:       &indices[0],
+	}
+}
+
+func step(w *World, count int) {
+	for i := 0; i < count; i++ {
+		w.Step(defaultTestStep)
+	}
+}
+
+func nearlyEqual(a, b, tolerance float32) bool {
+	return float32(math.Abs(float64(a-b))) <= tolerance
+}
+
+func TestConvexHull(t *testing.T) {
+	// Cube corners plus points inside and on its faces
+	var points []rl.Vector3
+	for i := 0; i < 8; i++ {
+		points = append(points, rl.NewVector3(float32(i&1)*2-1, float32(i>>1&1)*2-1, float32(i>>2&1)*2-1))
+	}
+	points = append(points, rl.NewVector3(0, 0, 0), rl.NewVector3(0.5, -0.2, 0.1), rl.NewVector3(1, 0, 0), rl.NewVector3(0, 0.5, -1))
+
+	h, err := newConvexHull(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.vertices) != 8 || len(h.faces) != 6 || len(h.edges) != 12 {
+		t.Fatalf("cube hull has %d vertices, %d faces and %d edges", len(h.vertices), len(h.faces), len(h.edges))
+	}
+
+	volume, center, inertia := h.massProperties()
+	if !nearlyEqual(volume, 8, 0.001) || rl.Vector3Length(center) > 0.001 {
+		t.Errorf("cube hull volume %v and center %v", volume, center)
+	}
+	box := newBoxHull(rl.NewVector3(1, 1, 1))
+	_, _, boxInertia := box.massProperties()
+	for i := range inertia {
+		if rl.Vector3Distance(inertia[i], boxInertia[i]) > 0.001 {
+			t.Errorf("cube hull inertia %v, want %v", inertia, boxInertia)
+			break
+		}
+	}
+
+	if _, err := newConvexHull(points[:3]); err == nil {
+		t.Error("hull of three points did not fail")
+	}
+	flat := []rl.Vector3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}}
+	if _, err := newConvexHull(flat); err == nil {
+		t.Error("hull of coplanar points did not fail")
+	}
+}
+
+func TestBoxRestsOnBox(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyBox(rl.NewVector3(0, -0.5, 0), rl.NewVector3(10, 1, 10), 1)
+	floor.SetType(StaticBody)
+	box := w.NewBodyBox(rl.NewVector3(0, 2, 0), rl.NewVector3(1, 1, 1), 1)
+
+	step(w, 300)
+	if !nearlyEqual(box.Position.Y, 0.5, 0.02) {
+		t.Errorf("box did not rest on the floor, position %v", box.Position)
+	}
+	if rl.Vector3Length(box.Velocity) > 0.01 || rl.Vector3Length(box.AngularVelocity) > 0.01 {
+		t.Errorf("resting box still moves, velocity %v and angular velocity %v", box.Velocity, box.AngularVelocity)
+	}
+	if !box.IsGrounded || floor.IsGrounded {
+		t.Error("only the box should be grounded")
+	}
+	if manifolds := w.GetManifolds(); len(manifolds) != 1 || manifolds[0].PointsCount != 4 {
+		t.Errorf("box face resting on the floor should have 4 points of contact")
+	}
+}
+
+func TestBoxStack(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyBox(rl.NewVector3(0, -0.5, 0), rl.NewVector3(10, 1, 10), 1)
+	floor.SetType(StaticBody)
+
+	var boxes []*Body
+	for i := 0; i < 4; i++ {
+		boxes = append(boxes, w.NewBodyBox(rl.NewVector3(0, 0.5+float32(i)*1.01, 0), rl.NewVector3(1, 1, 1), 1))
+	}
+
+	step(w, 600)
+	for i, box := range boxes {
+		if !nearlyEqual(box.Position.Y, 0.5+float32(i), 0.05) || math.Abs(float64(box.Position.X)) > 0.05 ||
+			math.Abs(float64(box.Position.Z)) > 0.05 {
+			t.Errorf("box %d fell off the stack, position %v", i, box.Position)
+		}
+	}
+}
+
+func TestShapesRestOnMesh(t *testing.T) {
+	w := NewWorld()
+	ground, err := w.NewBodyMesh(rl.NewVector3(0, 0, 0), newGroundMesh(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ground.Type != StaticBody {
+		t.Fatal("mesh body is not static")
+	}
+	ground.SetType(DynamicBody)
+	if ground.Type != StaticBody {
+		t.Error("mesh body was made dynamic")
+	}
+
+	sphere := w.NewBodySphere(rl.NewVector3(-3, 1, 0), 0.5, 1)
+	capsule := w.NewBodyCapsule(rl.NewVector3(0, 2, 0), 0.25, 1, 1)
+	hull, err := w.NewBodyConvexHull(rl.NewVector3(3, 1, 0), []rl.Vector3{
+		{X: -0.5, Y: 0, Z: -0.5}, {X: 0.5, Y: 0, Z: -0.5}, {X: 0, Y: 0, Z: 0.5}, {X: 0, Y: 0.8, Z: 0},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step(w, 300)
+	if !nearlyEqual(sphere.Position.Y, 0.5, 0.02) {
+		t.Errorf("sphere did not rest on the mesh, position %v", sphere.Position)
+	}
+	if !nearlyEqual(capsule.Position.Y, 0.75, 0.02) {
+		t.Errorf("capsule did not rest on the mesh, position %v", capsule.Position)
+	}
+	if low := hull.GetBoundingBox().Min.Y; !nearlyEqual(low, 0, 0.02) {
+		t.Errorf("hull did not rest on the mesh, lowest point %v", low)
+	}
+	for _, body := range []*Body{sphere, capsule, hull} {
+		if !body.IsGrounded {
+			t.Errorf("body %d is not grounded", body.ID)
+		}
+	}
+
+	// Capsules lying down touch the mesh along their length
+	w = NewWorld()
+	if _, err := w.NewBodyMesh(rl.NewVector3(0, 0, 0), newGroundMesh(10)); err != nil {
+		t.Fatal(err)
+	}
+	lying := w.NewBodyCapsule(rl.NewVector3(0, 1, 0), 0.25, 1, 1)
+	lying.Rotation = rl.QuaternionFromAxisAngle(rl.NewVector3(0, 0, 1), math.Pi/2)
+	step(w, 300)
+	if !nearlyEqual(lying.Position.Y, 0.25, 0.02) || math.Abs(float64(lying.AngularVelocity.Z)) > 0.01 {
+		t.Errorf("lying capsule did not rest on the mesh, position %v", lying.Position)
+	}
+
+	if _, err := w.NewBodyMesh(rl.Vector3{}, rl.Mesh{}); err == nil {
+		t.Error("empty mesh did not fail")
+	}
+}
+
+func TestSpheresCollide(t *testing.T) {
+	w := NewWorld()
+	w.SetGravity(0, 0, 0)
+	a := w.NewBodySphere(rl.NewVector3(-2, 0, 0), 0.5, 1)
+	b := w.NewBodySphere(rl.NewVector3(2, 0, 0), 0.5, 1)
+	a.Restitution, b.Restitution = 1, 1
+	a.Velocity = rl.NewVector3(2, 0, 0)
+	b.Velocity = rl.NewVector3(-2, 0, 0)
+
+	step(w, 120)
+	if !nearlyEqual(a.Velocity.X, -2, 0.05) || !nearlyEqual(b.Velocity.X, 2, 0.05) {
+		t.Errorf("elastic spheres did not bounce back, velocities %v and %v", a.Velocity, b.Velocity)
+	}
+	if momentum := a.Velocity.X*a.Mass + b.Velocity.X*b.Mass; !nearlyEqual(momentum, 0, 0.01) {
+		t.Errorf("collision changed the momentum to %v", momentum)
+	}
+}
+
+func TestRaycast(t *testing.T) {
+	w := NewWorld()
+	ground, err := w.NewBodyMesh(rl.NewVector3(0, 0, 0), newGroundMesh(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sphere := w.NewBodySphere(rl.NewVector3(0, 1, 0), 0.5, 1)
+	box := w.NewBodyBox(rl.NewVector3(3, 1, 0), rl.NewVector3(1, 1, 1), 1)
+	capsule := w.NewBodyCapsule(rl.NewVector3(-3, 1, 0), 0.25, 1, 1)
+
+	cases := []struct {
+		start, end rl.Vector3
+		body       *Body
+		point      rl.Vector3
+		normal     rl.Vector3
+	}{
+		{rl.NewVector3(0, 5, 0), rl.NewVector3(0, -5, 0), sphere, rl.NewVector3(0, 1.5, 0), rl.NewVector3(0, 1, 0)},
+		{rl.NewVector3(1, 5, 0), rl.NewVector3(1, -5, 0), ground, rl.NewVector3(1, 0, 0), rl.NewVector3(0, 1, 0)},
+		{rl.NewVector3(1, 1, 0), rl.NewVector3(5, 1, 0), box, rl.NewVector3(2.5, 1, 0), rl.NewVector3(-1, 0, 0)},
+		{rl.NewVector3(-3, 1, 5), rl.NewVector3(-3, 1, -5), capsule, rl.NewVector3(-3, 1, 0.25), rl.NewVector3(0, 0, 1)},
+		{rl.NewVector3(-3, 5, 0), rl.NewVector3(-3, -5, 0), capsule, rl.NewVector3(-3, 1.75, 0), rl.NewVector3(0, 1, 0)},
+		{rl.NewVector3(1, -5, 0), rl.NewVector3(1, 5, 0), ground, rl.NewVector3(1, 0, 0), rl.NewVector3(0, -1, 0)},
+	}
+	for i, c := range cases {
+		hit, ok := w.Raycast(c.start, c.end, nil)
+		if !ok || hit.Body != c.body {
+			t.Errorf("ray %d missed the body, hit %+v", i, hit)
+			continue
+		}
+		if rl.Vector3Distance(hit.Point, c.point) > 0.001 || rl.Vector3Distance(hit.Normal, c.normal) > 0.001 {
+			t.Errorf("ray %d hit at %v with normal %v, want %v and %v", i, hit.Point, hit.Normal, c.point, c.normal)
+		}
+	}
+
+	if hits := w.RaycastAll(rl.NewVector3(0, 5, 0), rl.NewVector3(0, -5, 0), nil); len(hits) != 2 ||
+		hits[0].Body != sphere || hits[1].Body != ground {
+		t.Errorf("ray through the sphere and the ground hit %d bodies", len(hits))
+	}
+	hit, ok := w.Raycast(rl.NewVector3(0, 5, 0), rl.NewVector3(0, -5, 0), func(body *Body) bool { return body != sphere })
+	if !ok || hit.Body != ground {
+		t.Error("filtered ray did not go through the sphere")
+	}
+	if _, ok := w.Raycast(rl.NewVector3(0, 1, 0), rl.NewVector3(0, 1, 5), nil); ok {
+		t.Error("ray starting inside the sphere hit something")
+	}
+
+	collision, body := w.GetRayCollision(rl.NewRay(rl.NewVector3(3, 5, 0), rl.NewVector3(0, -1, 0)), 10, nil)
+	if !collision.Hit || body != box || !nearlyEqual(collision.Distance, 3.5, 0.001) {
+		t.Errorf("raylib ray collision %+v with body %v", collision, body)
+	}
+
+	if bodies := w.QueryPoint(rl.NewVector3(3.2, 1.2, 0), nil); len(bodies) != 1 || bodies[0] != box {
+		t.Errorf("point inside the box found %d bodies", len(bodies))
+	}
+}
+
+type debugRecorder struct {
+	segments, spheres, capsules, boxes, points int
+}
+
+func (r *debugRecorder) DrawSegment(start, end rl.Vector3, color rl.Color) { r.segments++ }
+func (r *debugRecorder) DrawSphere(center rl.Vector3, radius float32, color rl.Color) {
+	r.spheres++
+}
+func (r *debugRecorder) DrawCapsule(start, end rl.Vector3, radius float32, color rl.Color) {
+	r.capsules++
+}
+func (r *debugRecorder) DrawBox(center, size rl.Vector3, color rl.Color)          { r.boxes++ }
+func (r *debugRecorder) DrawPoint(point rl.Vector3, size float32, color rl.Color) { r.points++ }
+
+func TestDebugDraw(t *testing.T) {
+	w := NewWorld()
+	if _, err := w.NewBodyMesh(rl.NewVector3(0, 0, 0), newGroundMesh(10)); err != nil {
+		t.Fatal(err)
+	}
+	w.NewBodyBox(rl.NewVector3(0, 0.5, 0), rl.NewVector3(1, 1, 1), 1)
+	w.NewBodySphere(rl.NewVector3(3, 0.5, 0), 0.5, 1)
+	w.NewBodyCapsule(rl.NewVector3(-3, 0.75, 0), 0.25, 1, 1)
+	step(w, 10)
+
+	draw := func(flags DebugDrawFlags) *debugRecorder {
+		recorder := &debugRecorder{}
+		w.DebugDraw(recorder, flags)
+		return recorder
+	}
+
+	// Mesh triangles draw their three sides and boxes their twelve edges
+	if shapes := draw(DrawShapes); shapes.segments != 18 || shapes.spheres != 1 || shapes.capsules != 1 {
+		t.Errorf("shapes drew %d segments, %d spheres and %d capsules", shapes.segments, shapes.spheres, shapes.capsules)
+	}
+	if boxes := draw(DrawBoundingBoxes); boxes.boxes != 4 {
+		t.Errorf("bounding boxes drew %d boxes", boxes.boxes)
+	}
+
+	contacts := 0
+	for _, manifold := range w.GetManifolds() {
+		contacts += manifold.PointsCount
+	}
+	if contacts == 0 {
+		t.Fatal("resting bodies have no points of contact")
+	}
+	if all := draw(DrawContacts | DrawNormals); all.points != contacts || all.segments != contacts {
+		t.Errorf("contacts drew %d points and %d normals, want %d", all.points, all.segments, contacts)
+	}
+}
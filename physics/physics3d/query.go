@@ -0,0 +1,347 @@
+package physics3d
+
+import (
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaycastHit - Information about the first point a ray hits on a physics body
+type RaycastHit struct {
+	// Physics body hit
+	Body *Body
+	// World space hit position
+	Point rl.Vector3
+	// Hit body surface normal at the hit position
+	Normal rl.Vector3
+	// Fraction of the ray where the hit happens (0 to 1)
+	Fraction float32
+	// Mesh triangle index hit (used for mesh bodies)
+	Triangle int
+}
+
+// QueryFilter - Decides if a physics body is considered by a world query, nil accepts every body
+type QueryFilter func(body *Body) bool
+
+// Raycast - Returns the closest body hit by a ray going from start to end
+func (w *World) Raycast(start, end rl.Vector3, filter QueryFilter) (RaycastHit, bool) {
+	var closest RaycastHit
+	found := false
+
+	w.raycast(start, end, filter, func(hit RaycastHit) {
+		if !found || hit.Fraction < closest.Fraction {
+			closest = hit
+			found = true
+		}
+	})
+
+	return closest, found
+}
+
+// RaycastAll - Returns every body hit by a ray going from start to end, sorted by distance
+func (w *World) RaycastAll(start, end rl.Vector3, filter QueryFilter) []RaycastHit {
+	var hits []RaycastHit
+
+	w.raycast(start, end, filter, func(hit RaycastHit) {
+		hits = append(hits, hit)
+	})
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Fraction < hits[j].Fraction
+	})
+	return hits
+}
+
+// GetRayCollision - Returns the closest body hit by a raylib ray within a distance, like rl.GetRayCollisionBox
+func (w *World) GetRayCollision(ray rl.Ray, distance float32, filter QueryFilter) (rl.RayCollision, *Body) {
+	end := rl.Vector3Add(ray.Position, rl.Vector3Scale(rl.Vector3Normalize(ray.Direction), distance))
+	hit, ok := w.Raycast(ray.Position, end, filter)
+	if !ok {
+		return rl.RayCollision{}, nil
+	}
+
+	return rl.RayCollision{
+		Hit:      true,
+		Distance: hit.Fraction * distance,
+		Point:    hit.Point,
+		Normal:   hit.Normal,
+	}, hit.Body
+}
+
+// QueryPoint - Returns the bodies whose shape contains a point, mesh bodies have no inside and are ignored
+func (w *World) QueryPoint(point rl.Vector3, filter QueryFilter) []*Body {
+	var result []*Body
+
+	for _, body := range w.bodies {
+		if filter != nil && !filter(body) {
+			continue
+		}
+
+		if body.ContainsPoint(point) {
+			result = append(result, body)
+		}
+	}
+
+	return result
+}
+
+// QueryBoundingBox - Returns the bodies whose bounding box overlaps a box
+func (w *World) QueryBoundingBox(box rl.BoundingBox, filter QueryFilter) []*Body {
+	var result []*Body
+
+	for _, body := range w.bodies {
+		if filter != nil && !filter(body) {
+			continue
+		}
+
+		if boundingBoxesOverlap(body.GetBoundingBox(), box) {
+			result = append(result, body)
+		}
+	}
+
+	return result
+}
+
+// ContainsPoint - Checks if a world space point is inside the physics body shape
+func (b *Body) ContainsPoint(point rl.Vector3) bool {
+	switch b.Shape.Type {
+	case SphereShape, CapsuleShape:
+		start, end := b.capsuleSegment()
+		closest := closestPointOnSegment(start, end, point)
+		return rl.Vector3DistanceSqr(closest, point) <= b.Shape.Radius*b.Shape.Radius
+	case BoxShape, ConvexHullShape:
+		b.updateTransform()
+		return b.worldHull.containsPoint(point)
+	}
+	return false
+}
+
+// raycast - Calls fn for every body hit by a ray going from start to end
+func (w *World) raycast(start, end rl.Vector3, filter QueryFilter, fn func(hit RaycastHit)) {
+	sweep := rl.NewBoundingBox(rl.Vector3Min(start, end), rl.Vector3Max(start, end))
+
+	for _, body := range w.bodies {
+		if filter != nil && !filter(body) {
+			continue
+		}
+		if !boundingBoxesOverlap(body.GetBoundingBox(), sweep) {
+			continue
+		}
+
+		if hit, ok := raycastBody(body, start, end); ok {
+			hit.Body = body
+			fn(hit)
+		}
+	}
+}
+
+// raycastBody - Returns the first point a ray going from start to end hits on a physics body
+func raycastBody(body *Body, start, end rl.Vector3) (RaycastHit, bool) {
+	switch body.Shape.Type {
+	case SphereShape:
+		return raycastSphere(start, end, body.Position, body.Shape.Radius)
+	case CapsuleShape:
+		segmentStart, segmentEnd := body.capsuleSegment()
+		return raycastCapsule(start, end, segmentStart, segmentEnd, body.Shape.Radius)
+	case BoxShape, ConvexHullShape:
+		body.updateTransform()
+		return raycastHull(start, end, &body.worldHull)
+	case MeshShape:
+		return raycastMesh(start, end, body)
+	}
+	return RaycastHit{}, false
+}
+
+// raycastSphere - Returns the first point a ray going from start to end hits on a sphere
+func raycastSphere(start, end, center rl.Vector3, radius float32) (RaycastHit, bool) {
+	d := rl.Vector3Subtract(end, start)
+	s := rl.Vector3Subtract(start, center)
+
+	// Rays starting inside the sphere do not hit it
+	c := rl.Vector3DotProduct(s, s) - radius*radius
+	if c < 0 {
+		return RaycastHit{}, false
+	}
+
+	rr := rl.Vector3DotProduct(d, d)
+	if rr < epsilon {
+		return RaycastHit{}, false
+	}
+
+	b := rl.Vector3DotProduct(s, d)
+	sigma := b*b - rr*c
+	if sigma < 0 {
+		return RaycastHit{}, false
+	}
+
+	fraction := (-b - float32(math.Sqrt(float64(sigma)))) / rr
+	if fraction < 0 || fraction > 1 {
+		return RaycastHit{}, false
+	}
+
+	point := rl.Vector3Add(start, rl.Vector3Scale(d, fraction))
+	normal := rl.Vector3Normalize(rl.Vector3Subtract(point, center))
+
+	return RaycastHit{Point: point, Normal: normal, Fraction: fraction}, true
+}
+
+// raycastCapsule - Returns the first point a ray going from start to end hits on a capsule, the closest of its
+// cylinder and hemispheres hits
+func raycastCapsule(start, end, segmentStart, segmentEnd rl.Vector3, radius float32) (RaycastHit, bool) {
+	// Rays starting inside the capsule do not hit it
+	if rl.Vector3DistanceSqr(closestPointOnSegment(segmentStart, segmentEnd, start), start) < radius*radius {
+		return RaycastHit{}, false
+	}
+
+	closest, found := raycastSphere(start, end, segmentStart, radius)
+	if hit, ok := raycastSphere(start, end, segmentEnd, radius); ok && (!found || hit.Fraction < closest.Fraction) {
+		closest, found = hit, true
+	}
+
+	axis := rl.Vector3Subtract(segmentEnd, segmentStart)
+	length := rl.Vector3Length(axis)
+	if length < epsilon {
+		return closest, found
+	}
+	axis = rl.Vector3Scale(axis, 1/length)
+
+	// Solve the ray against the infinite cylinder projected on the plane orthogonal to the axis
+	d := rl.Vector3Subtract(end, start)
+	s := rl.Vector3Subtract(start, segmentStart)
+	dPerpendicular := rl.Vector3Subtract(d, rl.Vector3Scale(axis, rl.Vector3DotProduct(d, axis)))
+	sPerpendicular := rl.Vector3Subtract(s, rl.Vector3Scale(axis, rl.Vector3DotProduct(s, axis)))
+
+	rr := rl.Vector3DotProduct(dPerpendicular, dPerpendicular)
+	if rr < epsilon {
+		return closest, found
+	}
+
+	b := rl.Vector3DotProduct(sPerpendicular, dPerpendicular)
+	c := rl.Vector3DotProduct(sPerpendicular, sPerpendicular) - radius*radius
+	sigma := b*b - rr*c
+	if sigma < 0 {
+		return closest, found
+	}
+
+	fraction := (-b - float32(math.Sqrt(float64(sigma)))) / rr
+	if fraction < 0 || fraction > 1 || found && fraction >= closest.Fraction {
+		return closest, found
+	}
+
+	// The cylinder is only hit between the hemispheres centers
+	point := rl.Vector3Add(start, rl.Vector3Scale(d, fraction))
+	height := rl.Vector3DotProduct(rl.Vector3Subtract(point, segmentStart), axis)
+	if height < 0 || height > length {
+		return closest, found
+	}
+
+	center := rl.Vector3Add(segmentStart, rl.Vector3Scale(axis, height))
+	normal := rl.Vector3Normalize(rl.Vector3Subtract(point, center))
+	return RaycastHit{Point: point, Normal: normal, Fraction: fraction}, true
+}
+
+// raycastHull - Returns the first point a ray going from start to end hits on a world space hull
+func raycastHull(start, end rl.Vector3, h *worldHull) (RaycastHit, bool) {
+	d := rl.Vector3Subtract(end, start)
+	lower, upper := float32(0), float32(1)
+	index := -1
+
+	for i, normal := range h.normals {
+		numerator := h.offsets[i] - rl.Vector3DotProduct(normal, start)
+		denominator := rl.Vector3DotProduct(normal, d)
+
+		if denominator == 0 {
+			// Parallel to the face and outside of it
+			if numerator < 0 {
+				return RaycastHit{}, false
+			}
+			continue
+		}
+
+		if denominator < 0 && numerator < lower*denominator {
+			// Entering the face half space
+			lower = numerator / denominator
+			index = i
+		} else if denominator > 0 && numerator < upper*denominator {
+			// Leaving the face half space
+			upper = numerator / denominator
+		}
+
+		if upper < lower {
+			return RaycastHit{}, false
+		}
+	}
+
+	// Rays starting inside the hull do not hit it
+	if index < 0 {
+		return RaycastHit{}, false
+	}
+
+	return RaycastHit{
+		Point:    rl.Vector3Add(start, rl.Vector3Scale(d, lower)),
+		Normal:   h.normals[index],
+		Fraction: lower,
+	}, true
+}
+
+// raycastMesh - Returns the first point a ray going from start to end hits on a mesh body. Triangles are hit on
+// both sides and the normal faces the ray
+func raycastMesh(start, end rl.Vector3, body *Body) (RaycastHit, bool) {
+	localStart := inverseRotateVector(body.Rotation, rl.Vector3Subtract(start, body.Position))
+	localEnd := inverseRotateVector(body.Rotation, rl.Vector3Subtract(end, body.Position))
+	d := rl.Vector3Subtract(localEnd, localStart)
+
+	var closest RaycastHit
+	found := false
+
+	mesh := body.Shape.mesh
+	sweep := rl.NewBoundingBox(rl.Vector3Min(localStart, localEnd), rl.Vector3Max(localStart, localEnd))
+	mesh.query(sweep, func(triangle int) {
+		a, b, c := mesh.triangle(triangle)
+		fraction, ok := raycastTriangle(localStart, d, a, b, c)
+		if !ok || found && fraction >= closest.Fraction {
+			return
+		}
+
+		normal := rl.Vector3Normalize(rl.Vector3CrossProduct(rl.Vector3Subtract(b, a), rl.Vector3Subtract(c, a)))
+		if rl.Vector3DotProduct(normal, d) > 0 {
+			normal = rl.Vector3Negate(normal)
+		}
+		closest = RaycastHit{Normal: normal, Fraction: fraction, Triangle: triangle}
+		found = true
+	})
+
+	if found {
+		closest.Point = rl.Vector3Lerp(start, end, closest.Fraction)
+		closest.Normal = rotateVector(body.Rotation, closest.Normal)
+	}
+	return closest, found
+}
+
+// raycastTriangle - Returns the fraction of a ray from origin along d where it hits a triangle (Möller–Trumbore)
+func raycastTriangle(origin, d, a, b, c rl.Vector3) (float32, bool) {
+	edge1 := rl.Vector3Subtract(b, a)
+	edge2 := rl.Vector3Subtract(c, a)
+	p := rl.Vector3CrossProduct(d, edge2)
+	determinant := rl.Vector3DotProduct(edge1, p)
+	if abs(determinant) < epsilon {
+		return 0, false
+	}
+	inverse := 1 / determinant
+
+	s := rl.Vector3Subtract(origin, a)
+	u := rl.Vector3DotProduct(s, p) * inverse
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+
+	q := rl.Vector3CrossProduct(s, edge1)
+	v := rl.Vector3DotProduct(d, q) * inverse
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+
+	fraction := rl.Vector3DotProduct(edge2, q) * inverse
+	return fraction, fraction >= 0 && fraction <= 1
+}
@@ -0,0 +1,205 @@
+package physics3d
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ShapeType type
+type ShapeType int
+
+// Physics shape types
+const (
+	// Sphere type
+	SphereShape ShapeType = iota
+	// Box type
+	BoxShape
+	// Capsule type, two hemispheres joined by a cylinder along the local Y axis
+	CapsuleShape
+	// Convex hull type
+	ConvexHullShape
+	// Static triangle mesh type
+	MeshShape
+)
+
+// Shape type
+type Shape struct {
+	// Physics shape type (sphere, box, capsule, convex hull or mesh)
+	Type ShapeType
+	// Shape physics body reference
+	Body *Body
+	// Sphere and capsule radius
+	Radius float32
+	// Half distance between the capsule hemispheres centers
+	HalfHeight float32
+	// Half size of the box along each local axis
+	HalfExtents rl.Vector3
+	// Box and convex hull polyhedron in body local space
+	hull *hull
+	// Mesh triangles in body local space
+	mesh *triangleMesh
+}
+
+// worldHull - Shape hull transformed to world space
+type worldHull struct {
+	// Hull in body local space
+	source *hull
+	// Vertices positions in world space
+	vertices []rl.Vector3
+	// Faces normals in world space
+	normals []rl.Vector3
+	// Faces planes distances to the origin in world space
+	offsets []float32
+	// Hull body position
+	center rl.Vector3
+}
+
+// GetVertices - Returns the shape vertices in body local space (used for box, convex hull and mesh shapes)
+func (s *Shape) GetVertices() []rl.Vector3 {
+	switch {
+	case s.hull != nil:
+		return s.hull.vertices
+	case s.mesh != nil:
+		return s.mesh.vertices
+	}
+	return nil
+}
+
+// GetBoundingBox - Returns the physics body axis aligned bounding box in world space
+func (b *Body) GetBoundingBox() rl.BoundingBox {
+	shape := &b.Shape
+	switch shape.Type {
+	case SphereShape:
+		radius := rl.NewVector3(shape.Radius, shape.Radius, shape.Radius)
+		return rl.NewBoundingBox(rl.Vector3Subtract(b.Position, radius), rl.Vector3Add(b.Position, radius))
+	case CapsuleShape:
+		start, end := b.capsuleSegment()
+		radius := rl.NewVector3(shape.Radius, shape.Radius, shape.Radius)
+		return rl.NewBoundingBox(
+			rl.Vector3Subtract(rl.Vector3Min(start, end), radius),
+			rl.Vector3Add(rl.Vector3Max(start, end), radius),
+		)
+	case MeshShape:
+		return transformBoundingBox(shape.mesh.bounds(), b.Position, b.Rotation)
+	}
+
+	if len(b.worldHull.vertices) == 0 {
+		b.updateTransform()
+	}
+	box := rl.NewBoundingBox(b.worldHull.vertices[0], b.worldHull.vertices[0])
+	for _, vertex := range b.worldHull.vertices[1:] {
+		box.Min = rl.Vector3Min(box.Min, vertex)
+		box.Max = rl.Vector3Max(box.Max, vertex)
+	}
+	return box
+}
+
+// capsuleSegment - Returns the capsule hemispheres centers in world space, sphere bodies return their position twice
+func (b *Body) capsuleSegment() (rl.Vector3, rl.Vector3) {
+	if b.Shape.Type != CapsuleShape {
+		return b.Position, b.Position
+	}
+
+	axis := rotateVector(b.Rotation, rl.NewVector3(0, b.Shape.HalfHeight, 0))
+	return rl.Vector3Subtract(b.Position, axis), rl.Vector3Add(b.Position, axis)
+}
+
+// massProperties - Returns the shape mass and inertia tensor about its center for a density, meshes are massless
+func (s *Shape) massProperties(density float32) (float32, mat3) {
+	switch s.Type {
+	case SphereShape:
+		mass := density * 4.0 / 3.0 * math.Pi * s.Radius * s.Radius * s.Radius
+		inertia := 0.4 * mass * s.Radius * s.Radius
+		return mass, mat3Diagonal(inertia, inertia, inertia)
+	case BoxShape:
+		size := s.HalfExtents
+		mass := density * 8 * size.X * size.Y * size.Z
+		return mass, mat3Diagonal(
+			mass/3*(size.Y*size.Y+size.Z*size.Z),
+			mass/3*(size.X*size.X+size.Z*size.Z),
+			mass/3*(size.X*size.X+size.Y*size.Y),
+		)
+	case CapsuleShape:
+		// Cylinder plus two hemispheres moved along the axis
+		radius, height := s.Radius, 2*s.HalfHeight
+		cylinder := density * math.Pi * radius * radius * height
+		hemisphere := density * 2.0 / 3.0 * math.Pi * radius * radius * radius
+		axial := cylinder*radius*radius/2 + 2*hemisphere*0.4*radius*radius
+		lateral := cylinder*(radius*radius/4+height*height/12) +
+			2*hemisphere*(0.4*radius*radius+height*height/4+3*height*radius/8)
+		return cylinder + 2*hemisphere, mat3Diagonal(lateral, axial, lateral)
+	case ConvexHullShape:
+		volume, _, inertia := s.hull.massProperties()
+		return density * volume, inertia.scale(density)
+	}
+	return 0, mat3{}
+}
+
+// set - Transforms a local hull to world space reusing the buffers
+func (h *worldHull) set(source *hull, position rl.Vector3, rotation rl.Quaternion) {
+	h.source = source
+	h.center = position
+	h.vertices = h.vertices[:0]
+	for _, vertex := range source.vertices {
+		h.vertices = append(h.vertices, rl.Vector3Add(position, rotateVector(rotation, vertex)))
+	}
+
+	h.normals = h.normals[:0]
+	h.offsets = h.offsets[:0]
+	for _, face := range source.faces {
+		normal := rotateVector(rotation, face.normal)
+		h.normals = append(h.normals, normal)
+		h.offsets = append(h.offsets, face.offset+rl.Vector3DotProduct(normal, position))
+	}
+}
+
+// support - Returns the smallest and greatest projections of the hull vertices along a direction
+func (h *worldHull) support(direction rl.Vector3) (float32, float32) {
+	low := float32(math.MaxFloat32)
+	high := float32(-math.MaxFloat32)
+	for _, vertex := range h.vertices {
+		projection := rl.Vector3DotProduct(direction, vertex)
+		low = min(low, projection)
+		high = max(high, projection)
+	}
+	return low, high
+}
+
+// containsPoint - Checks if a point is inside the hull
+func (h *worldHull) containsPoint(point rl.Vector3) bool {
+	for i, normal := range h.normals {
+		if rl.Vector3DotProduct(normal, point)-h.offsets[i] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// transformBoundingBox - Returns the world space bounding box of a local box moved by a position and rotation
+func transformBoundingBox(box rl.BoundingBox, position rl.Vector3, rotation rl.Quaternion) rl.BoundingBox {
+	center := rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), 0.5)
+	extents := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), 0.5)
+
+	// Rotated extents are the absolute rotation matrix applied to the local ones
+	rotation3 := mat3FromQuaternion(rotation)
+	var rotated rl.Vector3
+	rotated.X = abs(rotation3[0].X)*extents.X + abs(rotation3[0].Y)*extents.Y + abs(rotation3[0].Z)*extents.Z
+	rotated.Y = abs(rotation3[1].X)*extents.X + abs(rotation3[1].Y)*extents.Y + abs(rotation3[1].Z)*extents.Z
+	rotated.Z = abs(rotation3[2].X)*extents.X + abs(rotation3[2].Y)*extents.Y + abs(rotation3[2].Z)*extents.Z
+
+	center = rl.Vector3Add(position, rotation3.mulVector(center))
+	return rl.NewBoundingBox(rl.Vector3Subtract(center, rotated), rl.Vector3Add(center, rotated))
+}
+
+// boundingBoxesOverlap - Checks if two bounding boxes overlap
+func boundingBoxesOverlap(a, b rl.BoundingBox) bool {
+	return a.Min.X <= b.Max.X && a.Max.X >= b.Min.X &&
+		a.Min.Y <= b.Max.Y && a.Max.Y >= b.Min.Y &&
+		a.Min.Z <= b.Max.Z && a.Max.Z >= b.Min.Z
+}
+
+// abs - Returns the absolute value
+func abs(value float32) float32 {
+	return float32(math.Abs(float64(value)))
+}
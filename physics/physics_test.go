@@ -0,0 +1,1490 @@
+package physics
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Default physics time step in milliseconds
+const defaultTestStep = 1000.0 / 60.0 / 10.0
+
+func TestWorldsAreIndependent(t *testing.T) {
+	a := NewWorld()
+	b := NewWorld()
+	b.SetGravity(0, 0)
+
+	bodyA := a.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	bodyB := b.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+
+	if a.GetBodiesCount() != 1 || b.GetBodiesCount() != 1 {
+		t.Fatalf("expected one body per world, got %d and %d", a.GetBodiesCount(), b.GetBodiesCount())
+	}
+	if bodyA.ID != 0 || bodyB.ID != 0 {
+		t.Fatalf("expected body ids to start at 0 in each world, got %d and %d", bodyA.ID, bodyB.ID)
+	}
+
+	for i := 0; i < 10; i++ {
+		a.step(a.deltaTime)
+		b.step(b.deltaTime)
+	}
+
+	if bodyA.Position.Y <= 0 {
+		t.Errorf("body in world with gravity did not fall: %v", bodyA.Position)
+	}
+	if bodyB.Position.Y != 0 {
+		t.Errorf("body in world without gravity moved: %v", bodyB.Position)
+	}
+
+	bodyA.Destroy()
+	if a.GetBodiesCount() != 0 || b.GetBodiesCount() != 1 {
+		t.Errorf("destroying a body affected the wrong world")
+	}
+}
+
+func TestBodyIDsAreReused(t *testing.T) {
+	w := NewWorld()
+
+	bodies := make([]*Body, 200)
+	for i := range bodies {
+		bodies[i] = w.NewBodyCircle(rl.NewVector2(float32(i)*30, 0), 10, 1)
+		if bodies[i].ID != i {
+			t.Fatalf("body %d got id %d", i, bodies[i].ID)
+		}
+	}
+
+	bodies[150].Destroy()
+	bodies[7].Destroy()
+
+	if w.GetBodyByID(7) != nil {
+		t.Errorf("destroyed body still reachable by id")
+	}
+	if got := w.GetBodyByID(199); got != bodies[199] {
+		t.Errorf("GetBodyByID(199) = %v, want %v", got, bodies[199])
+	}
+
+	if id := w.NewBodyCircle(rl.Vector2{}, 10, 1).ID; id != 7 {
+		t.Errorf("expected lowest released id 7, got %d", id)
+	}
+	if id := w.NewBodyCircle(rl.Vector2{}, 10, 1).ID; id != 150 {
+		t.Errorf("expected released id 150, got %d", id)
+	}
+	if id := w.NewBodyCircle(rl.Vector2{}, 10, 1).ID; id != 200 {
+		t.Errorf("expected fresh id 200, got %d", id)
+	}
+}
+
+func TestJoints(t *testing.T) {
+	run := func(w *World, steps int, check func()) {
+		for i := 0; i < steps; i++ {
+			w.step(w.deltaTime)
+			if check != nil {
+				check()
+			}
+		}
+	}
+
+	// Distance joints keep their length while the body swings
+	w := NewWorld()
+	pivot := w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	pivot.Enabled = false
+	bob := w.NewBodyCircle(rl.NewVector2(50, 0), 10, 1)
+	distance := w.NewDistanceJoint(pivot, bob, pivot.Position, bob.Position)
+	run(w, 600, func() {
+		if !nearlyEqualTolerance(distance.GetCurrentLength(), 50, 1) {
+			t.Fatalf("distance joint length is %v, expected 50", distance.GetCurrentLength())
+		}
+	})
+	if bob.Position.Y < 10 {
+		t.Errorf("distance joint body did not swing down: %v", bob.Position)
+	}
+
+	// Ropes never stretch past their maximum length
+	w = NewWorld()
+	pivot = w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	pivot.Enabled = false
+	bob = w.NewBodyCircle(rl.NewVector2(20, 0), 10, 1)
+	rope := w.NewDistanceJoint(pivot, bob, pivot.Position, bob.Position)
+	rope.MinLength, rope.MaxLength = 0, 60
+	var longest float32
+	run(w, 600, func() {
+		longest = max(longest, rope.GetCurrentLength())
+	})
+	if longest > 61 || longest < 55 {
+		t.Errorf("rope longest length is %v, expected 60", longest)
+	}
+
+	// Revolute joint limits hold the swinging body
+	w = NewWorld()
+	pivot = w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	pivot.Enabled = false
+	bob = w.NewBodyCircle(rl.NewVector2(50, 0), 10, 1)
+	hinge := w.NewRevoluteJoint(pivot, bob, pivot.Position)
+	hinge.EnableLimit = true
+	hinge.LowerAngle, hinge.UpperAngle = -0.5, 0.5
+	run(w, 600, func() {
+		if angle := hinge.GetJointAngle(); angle < hinge.LowerAngle-0.05 || angle > hinge.UpperAngle+0.05 {
+			t.Fatalf("revolute joint angle %v is out of its limits", angle)
+		}
+	})
+	if !nearlyEqualTolerance(rl.Vector2Distance(pivot.Position, bob.Position), 50, 1) || !nearlyEqualTolerance(hinge.GetJointAngle(), 0.5, 0.05) {
+		t.Errorf("revolute joint body rests at %v with angle %v", bob.Position, hinge.GetJointAngle())
+	}
+
+	// Revolute joint motors reach their speed
+	w = NewWorld()
+	w.SetGravity(0, 0)
+	pivot = w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	pivot.Enabled = false
+	wheel := w.NewBodyCircle(rl.NewVector2(0, 0), 20, 1)
+	motor := w.NewRevoluteJoint(pivot, wheel, pivot.Position)
+	motor.EnableMotor = true
+	motor.MotorSpeed, motor.MaxMotorTorque = 0.01, 1000
+	run(w, 600, nil)
+	if !nearlyEqualTolerance(motor.GetJointSpeed(), 0.01, 1e-4) || wheel.Orient <= 0 {
+		t.Errorf("revolute joint motor speed is %v, expected 0.01", motor.GetJointSpeed())
+	}
+
+	// Prismatic joints slide along their axis only, up to their limits
+	w = NewWorld()
+	base := w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	base.Enabled = false
+	slider := w.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	axis := rl.Vector2Normalize(rl.NewVector2(1, 1))
+	prismatic := w.NewPrismaticJoint(base, slider, base.Position, axis)
+	prismatic.EnableLimit = true
+	prismatic.LowerTranslation, prismatic.UpperTranslation = -20, 30
+	run(w, 600, func() {
+		if side := rl.Vector2CrossProduct(axis, slider.Position); !nearlyEqualTolerance(side, 0, 0.5) {
+			t.Fatalf("prismatic joint body left its axis by %v", side)
+		}
+		if translation := prismatic.GetJointTranslation(); translation < -20.5 || translation > 30.5 {
+			t.Fatalf("prismatic joint translation %v is out of its limits", translation)
+		}
+	})
+	if !nearlyEqualTolerance(prismatic.GetJointTranslation(), 30, 0.5) || !nearlyEqualTolerance(slider.Orient, 0, 0.01) {
+		t.Errorf("prismatic joint body rests at %v with orient %v", slider.Position, slider.Orient)
+	}
+
+	// Weld joints keep the relative pose of spinning and falling bodies
+	w = NewWorld()
+	bodyA := w.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	bodyB := w.NewBodyCircle(rl.NewVector2(30, 0), 10, 1)
+	w.NewWeldJoint(bodyA, bodyB, rl.NewVector2(15, 0))
+	bodyA.AngularVelocity = 0.005
+	run(w, 600, nil)
+	if !nearlyEqualTolerance(rl.Vector2Distance(bodyA.Position, bodyB.Position), 30, 1) || !nearlyEqualTolerance(bodyB.Orient-bodyA.Orient, 0, 0.02) {
+		t.Errorf("welded bodies are %v apart with relative orient %v", rl.Vector2Distance(bodyA.Position, bodyB.Position), bodyB.Orient-bodyA.Orient)
+	}
+	if bodyA.Orient <= 0 || bodyA.Position.Y <= 0 {
+		t.Errorf("welded bodies did not spin and fall: orient %v, position %v", bodyA.Orient, bodyA.Position)
+	}
+
+	// Mouse joints pull the body to their target
+	w = NewWorld()
+	w.SetGravity(0, 0)
+	ball := w.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	mouse := w.NewMouseJoint(ball, ball.Position, 1000*ball.Mass)
+	mouse.SetTarget(rl.NewVector2(50, -50))
+	run(w, 1200, nil)
+	if rl.Vector2Distance(mouse.GetAnchorB(), mouse.Target) > 1 {
+		t.Errorf("mouse joint anchor is %v, expected its target %v", mouse.GetAnchorB(), mouse.Target)
+	}
+
+	// Destroyed joints leave the world and stop constraining their bodies
+	w = NewWorld()
+	pivot = w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	pivot.Enabled = false
+	bob = w.NewBodyCircle(rl.NewVector2(0, 50), 10, 1)
+	distance = w.NewDistanceJoint(pivot, bob, pivot.Position, bob.Position)
+	hinge = w.NewRevoluteJoint(pivot, bob, pivot.Position)
+	distance.Destroy()
+	if joints := w.GetJoints(); len(joints) != 1 || joints[0] != Joint(hinge) || len(bob.joints) != 1 || len(pivot.joints) != 1 {
+		t.Fatalf("destroyed joint is still in the world: %v", joints)
+	}
+	hinge.Destroy()
+	distance.Destroy()
+	if len(w.GetJoints()) != 0 || len(bob.joints) != 0 || len(pivot.joints) != 0 {
+		t.Fatalf("destroyed joints are still in the world: %v", w.GetJoints())
+	}
+	run(w, 600, nil)
+	if bob.Position.Y < 100 {
+		t.Errorf("body of destroyed joints did not fall: %v", bob.Position)
+	}
+
+	// Joints only constrain bodies of their own world
+	other := NewWorld().NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	if w.NewDistanceJoint(pivot, other, pivot.Position, other.Position) != nil || w.NewMouseJoint(other, other.Position, 1) != nil {
+		t.Error("joint created with a body of another world")
+	}
+	if weld := w.NewWeldJoint(pivot, bob, pivot.Position); weld.GetBodyA() != pivot || weld.GetBodyB() != bob {
+		t.Errorf("weld joint bodies are %v and %v", weld.GetBodyA(), weld.GetBodyB())
+	}
+	bob.Destroy()
+	if len(w.GetJoints()) != 0 || w.NewRevoluteJoint(pivot, bob, pivot.Position) != nil {
+		t.Error("joint kept or created with a destroyed body")
+	}
+}
+
+func TestCollisionFiltering(t *testing.T) {
+	w := NewWorld()
+	w.SetGravity(0, 0)
+
+	// overlapping creates two overlapping circles and reports whether they generated a manifold
+	overlapping := func(setup func(a, b *Body)) bool {
+		w.Reset()
+		a := w.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+		b := w.NewBodyCircle(rl.NewVector2(5, 0), 10, 1)
+		setup(a, b)
+		w.step(w.deltaTime)
+		return len(w.manifolds) > 0
+	}
+
+	if !overlapping(func(a, b *Body) {}) {
+		t.Error("default filter should collide")
+	}
+	if overlapping(func(a, b *Body) { b.MaskBits = DefaultMaskBits &^ a.CategoryBits }) {
+		t.Error("masked out category should not collide")
+	}
+	if overlapping(func(a, b *Body) { a.GroupIndex, b.GroupIndex = -1, -1 }) {
+		t.Error("negative group should never collide")
+	}
+	if !overlapping(func(a, b *Body) { a.SetFilter(0x2, 0x2, 3); b.SetFilter(0x4, 0x4, 3) }) {
+		t.Error("positive group should always collide")
+	}
+
+	vetoed := 0
+	w.SetContactFilter(func(a, b *Body) bool {
+		vetoed++
+		return false
+	})
+	if overlapping(func(a, b *Body) {}) || vetoed != 1 {
+		t.Errorf("contact filter should veto the pair, called %d times", vetoed)
+	}
+}
+
+func TestContactEvents(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 10)
+	floor.Enabled = false
+	ball := w.NewBodyCircle(rl.NewVector2(0, 60), 10, 1)
+
+	var begins, persists, ends, bodyBegins int
+	var impulse float32
+	w.SetContactListener(ContactListener{
+		Begin: func(c Contact) {
+			begins++
+			if c.GetOther(floor) != ball {
+				t.Errorf("unexpected contact bodies %v and %v", c.BodyA, c.BodyB)
+			}
+		},
+		Persist: func(c Contact) {
+			persists++
+			impulse = c.NormalImpulses[0]
+		},
+		End: func(c Contact) { ends++ },
+	})
+	ball.ContactListener.Begin = func(c Contact) { bodyBegins++ }
+
+	for i := 0; i < 500; i++ {
+		w.step(w.deltaTime)
+	}
+
+	if begins != 1 || bodyBegins != 1 {
+		t.Fatalf("expected one begin event, got %d world and %d body events", begins, bodyBegins)
+	}
+	if persists == 0 || impulse <= 0 {
+		t.Errorf("expected persist events with a normal impulse, got %d events and impulse %v", persists, impulse)
+	}
+
+	ball.Position.Y = -100
+	w.step(w.deltaTime)
+	if ends != 1 {
+		t.Fatalf("expected an end event after separating, got %d", ends)
+	}
+
+	ball.Position.Y = 80
+	ball.Velocity = rl.Vector2{}
+	w.step(w.deltaTime)
+	ball.Destroy()
+	if begins != 2 || ends != 2 {
+		t.Errorf("expected destroying a touching body to end its contact, got %d begins and %d ends", begins, ends)
+	}
+}
+
+func TestSensorBodies(t *testing.T) {
+	w := NewWorld()
+	zone := w.NewBodyRectangle(rl.NewVector2(0, 200), 400, 40, 10)
+	zone.Enabled = false
+	zone.IsSensor = true
+	ball := w.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+	free := NewWorld()
+	freeBall := free.NewBodyCircle(rl.NewVector2(0, 0), 10, 1)
+
+	var enters, exits, bodyEnters int
+	w.SetSensorListener(SensorListener{
+		Enter: func(sensor, visitor *Body) {
+			enters++
+			if sensor != zone || visitor != ball {
+				t.Errorf("unexpected sensor %v and visitor %v", sensor, visitor)
+			}
+		},
+		Exit: func(sensor, visitor *Body) { exits++ },
+	})
+	zone.SensorListener.Enter = func(sensor, visitor *Body) { bodyEnters++ }
+	w.SetContactListener(ContactListener{
+		Begin: func(c Contact) { t.Error("sensor overlap reported as a contact") },
+	})
+
+	for ball.Position.Y < 400 {
+		w.step(w.deltaTime)
+		free.step(free.deltaTime)
+		if ball.IsGrounded {
+			t.Fatal("sensor grounded the ball")
+		}
+	}
+
+	if enters != 1 || bodyEnters != 1 || exits != 1 {
+		t.Errorf("expected one enter and exit event, got %d (%d body) enters and %d exits", enters, bodyEnters, exits)
+	}
+	if ball.Position != freeBall.Position || ball.Velocity != freeBall.Velocity {
+		t.Errorf("sensor changed the ball motion: %v %v, want %v %v", ball.Position, ball.Velocity, freeBall.Position, freeBall.Velocity)
+	}
+}
+
+func TestWorldQueries(t *testing.T) {
+	w := NewWorld()
+
+	box := w.NewBodyRectangle(rl.NewVector2(100, 0), 20, 20, 1)
+	circle := w.NewBodyCircle(rl.NewVector2(200, 0), 10, 1)
+	sensor := w.NewBodyCircle(rl.NewVector2(50, 0), 10, 1)
+	sensor.IsSensor = true
+
+	hit, ok := w.Raycast(rl.NewVector2(0, 0), rl.NewVector2(300, 0), nil)
+	if !ok || hit.Body != box {
+		t.Fatalf("raycast hit %v, want the box", hit.Body)
+	}
+	if !nearlyEqual(hit.Point.X, 90) || !nearlyEqual(hit.Normal.X, -1) || !nearlyEqual(hit.Fraction, 0.3) {
+		t.Errorf("unexpected raycast hit %+v", hit)
+	}
+
+	hits := w.RaycastAll(rl.NewVector2(300, 0), rl.NewVector2(0, 0), nil)
+	if len(hits) != 2 || hits[0].Body != circle || hits[1].Body != box {
+		t.Fatalf("raycast all returned %d hits in the wrong order", len(hits))
+	}
+
+	if _, ok := w.Raycast(rl.NewVector2(0, 0), rl.NewVector2(300, 0), func(body *Body) bool { return body != box }); !ok {
+		t.Errorf("filtered raycast should hit the circle")
+	}
+
+	hit, ok = w.CircleCast(rl.NewVector2(200, -100), 5, rl.NewVector2(0, 200), nil)
+	if !ok || hit.Body != circle || !nearlyEqual(hit.Point.Y, -10) || !nearlyEqual(hit.Fraction, 0.425) {
+		t.Errorf("unexpected circle cast hit %+v", hit)
+	}
+
+	square := []rl.Vector2{{X: -5, Y: -5}, {X: 5, Y: -5}, {X: 5, Y: 5}, {X: -5, Y: 5}}
+	hit, ok = w.PolygonCast(square, rl.NewVector2(100, -100), 0, rl.NewVector2(0, 200), nil)
+	if !ok || hit.Body != box || !nearlyEqual(hit.Normal.Y, -1) || !nearlyEqual(hit.Fraction, 0.425) {
+		t.Errorf("unexpected polygon cast hit %+v", hit)
+	}
+
+	if bodies := w.QueryPoint(rl.NewVector2(52, 3), nil); len(bodies) != 1 || bodies[0] != sensor {
+		t.Errorf("point query returned %v, want the sensor", bodies)
+	}
+	if bodies := w.QueryAABB(AABB{Min: rl.NewVector2(105, -5), Max: rl.NewVector2(195, 5)}, nil); len(bodies) != 2 {
+		t.Errorf("aabb query returned %d bodies, want 2", len(bodies))
+	}
+}
+
+func nearlyEqual(a, b float32) bool {
+	return a-b < 0.001 && b-a < 0.001
+}
+
+func TestBodyTypes(t *testing.T) {
+	w := NewWorld()
+	w.SetGravity(0, 0)
+
+	static := w.NewBodyRectangle(rl.NewVector2(0, 0), 20, 20, 1)
+	static.SetType(StaticBody)
+	AddForce(static, rl.NewVector2(10, 0))
+
+	platform := w.NewBodyRectangle(rl.NewVector2(0, 100), 40, 10, 1)
+	platform.SetType(KinematicBody)
+	platform.Velocity = rl.NewVector2(0.05, 0)
+
+	box := w.NewBodyRectangle(rl.NewVector2(40, 100), 10, 10, 1)
+
+	for i := 0; i < 200; i++ {
+		w.step(w.deltaTime)
+	}
+
+	if static.Position != rl.NewVector2(0, 0) {
+		t.Errorf("static body moved to %v", static.Position)
+	}
+	if platform.Velocity != rl.NewVector2(0.05, 0) || platform.Position.Y != 100 {
+		t.Errorf("kinematic body was affected by collisions: velocity %v, position %v", platform.Velocity, platform.Position)
+	}
+	if box.Position.X <= 40 {
+		t.Errorf("kinematic body did not push the dynamic body, position %v", box.Position)
+	}
+}
+
+func TestStepIsDeterministic(t *testing.T) {
+	run := func() []*Body {
+		w := NewWorld()
+
+		floor := w.NewBodyRectangle(rl.NewVector2(400, 500), 800, 20, 1)
+		floor.SetType(StaticBody)
+
+		for i := 0; i < 30; i++ {
+			x := 200 + float32(i%6)*60
+			y := 100 + float32(i/6)*40
+			switch i % 3 {
+			case 0:
+				w.NewBodyCircle(rl.NewVector2(x, y), 12, 1)
+			case 1:
+				w.NewBodyRectangle(rl.NewVector2(x, y), 24, 18, 1)
+			default:
+				w.NewBodyPolygon(rl.NewVector2(x, y), 14, 5, 1)
+			}
+		}
+
+		pivot := w.NewBodyCircle(rl.NewVector2(100, 100), 5, 1)
+		pivot.SetType(StaticBody)
+		bob := w.NewBodyCircle(rl.NewVector2(160, 100), 10, 1)
+		w.NewRevoluteJoint(pivot, bob, pivot.Position)
+
+		w.SetContactListener(ContactListener{
+			Begin: func(contact Contact) {
+				AddTorque(contact.BodyB, 0.5)
+			},
+		})
+
+		for i := 0; i < 600; i++ {
+			w.Step(defaultTestStep)
+		}
+		return w.GetBodies()
+	}
+
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("runs ended with %d and %d bodies", len(first), len(second))
+	}
+
+	for i := range first {
+		a, b := first[i], second[i]
+		values := [][2]float32{
+			{a.Position.X, b.Position.X}, {a.Position.Y, b.Position.Y},
+			{a.Velocity.X, b.Velocity.X}, {a.Velocity.Y, b.Velocity.Y},
+			{a.Orient, b.Orient}, {a.AngularVelocity, b.AngularVelocity},
+		}
+		for _, v := range values {
+			if math.Float32bits(v[0]) != math.Float32bits(v[1]) {
+				t.Fatalf("body %d state differs between runs: %+v != %+v", i, a, b)
+			}
+		}
+		if a.IsGrounded != b.IsGrounded {
+			t.Fatalf("body %d grounded state differs between runs", i)
+		}
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(200, 300), 400, 20, 1)
+	floor.SetType(StaticBody)
+	for i := 0; i < 6; i++ {
+		w.NewBodyRectangle(rl.NewVector2(200, 280-float32(i)*21), 20, 20, 1)
+		w.NewBodyCircle(rl.NewVector2(100+float32(i)*30, 200), 8, 1)
+	}
+	if _, err := w.NewBodyCompound(rl.NewVector2(300, 250), []FixtureDef{
+		{Type: CircleShape, Offset: rl.NewVector2(-10, 0), Radius: 6, Density: 1},
+		{Type: CircleShape, Offset: rl.NewVector2(10, 0), Radius: 6, Density: 1, Restitution: 0.5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		w.Step(defaultTestStep)
+	}
+
+	snapshot := w.TakeSnapshot()
+	data, err := snapshot.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary := &Snapshot{}
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := &Snapshot{}
+	if err := json.Unmarshal(jsonData, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func() []BodyState {
+		for i := 0; i < 100; i++ {
+			w.Step(defaultTestStep)
+		}
+		return w.TakeSnapshot().Bodies
+	}
+	expected := run()
+
+	for _, restored := range []*Snapshot{snapshot, fromBinary, fromJSON} {
+		// Changes made after the snapshot must be undone by restoring it
+		w.GetBody(3).Destroy()
+		w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+
+		if err := w.RestoreSnapshot(restored); err != nil {
+			t.Fatal(err)
+		}
+		if w.GetBodiesCount() != len(snapshot.Bodies) {
+			t.Fatalf("restored %d bodies, want %d", w.GetBodiesCount(), len(snapshot.Bodies))
+		}
+
+		got := run()
+		for i := range expected {
+			if !reflect.DeepEqual(got[i], expected[i]) {
+				t.Fatalf("body %d differs after restore:\n%+v\n%+v", i, got[i], expected[i])
+			}
+		}
+	}
+
+	if err := fromBinary.UnmarshalBinary(data[:len(data)-3]); err == nil {
+		t.Errorf("truncated snapshot data decoded without error")
+	}
+}
+
+func TestSnapshotAfterDestroy(t *testing.T) {
+	w := NewWorld()
+	ground := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	ground.SetType(StaticBody)
+	box := w.NewBodyRectangle(rl.NewVector2(0, 70), 20, 20, 1)
+	for i := 0; i < 60; i++ {
+		w.Step(defaultTestStep)
+	}
+	if len(w.manifolds) == 0 {
+		t.Fatal("box is not touching the ground")
+	}
+
+	// Destroyed bodies leave no manifolds behind for snapshots
+	box.Destroy()
+	if len(w.manifolds) != 0 {
+		t.Errorf("destroyed body left %d manifolds", len(w.manifolds))
+	}
+	if err := w.RestoreSnapshot(w.TakeSnapshot()); err != nil {
+		t.Errorf("snapshot taken after destroying a body was not restored: %v", err)
+	}
+
+	// A new body reusing the destroyed body id does not inherit its collisions
+	reused := w.NewBodyCircle(rl.NewVector2(0, -200), 10, 1)
+	w.Step(defaultTestStep)
+	if reused.ID != box.ID || len(w.manifolds) != 0 {
+		t.Errorf("body reusing id %d has %d manifolds", reused.ID, len(w.manifolds))
+	}
+}
+
+func TestBulletsDoNotTunnel(t *testing.T) {
+	for _, bullet := range []bool{false, true} {
+		w := NewWorld()
+		w.SetGravity(0, 0)
+
+		wall := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 2, 1)
+		wall.SetType(StaticBody)
+
+		ball := w.NewBodyCircle(rl.NewVector2(0, 0), 2, 1)
+		ball.Velocity = rl.NewVector2(0, 20)
+		ball.IsBullet = bullet
+
+		box := w.NewBodyRectangle(rl.NewVector2(50, 0), 4, 4, 1)
+		box.Velocity = rl.NewVector2(0, 20)
+		box.IsBullet = bullet
+
+		for i := 0; i < 20; i++ {
+			w.Step(defaultTestStep)
+		}
+
+		for _, body := range []*Body{ball, box} {
+			if tunneled := body.Position.Y > 100; tunneled != !bullet {
+				t.Errorf("bullet %v: body %d ended at %v", bullet, body.ID, body.Position)
+			}
+		}
+	}
+}
+
+func TestBodiesFromVertices(t *testing.T) {
+	points := []rl.Vector2{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 4, Y: 6}}
+	if hull := ConvexHull(points); len(hull) != 4 {
+		t.Errorf("convex hull has %d vertices, want 4: %v", len(hull), hull)
+	}
+
+	w := NewWorld()
+	body, err := w.NewBodyFromVertices(rl.NewVector2(100, 100), points, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.Position != rl.NewVector2(105, 105) || !nearlyEqual(body.Mass, 100) {
+		t.Errorf("unexpected body position %v and mass %v", body.Position, body.Mass)
+	}
+	if !body.ContainsPoint(rl.NewVector2(101, 109)) || body.ContainsPoint(rl.NewVector2(99, 105)) {
+		t.Errorf("body shape does not match the given vertices")
+	}
+
+	if _, err := w.NewBodyFromVertices(rl.Vector2{}, points[:3], 1); err == nil {
+		t.Errorf("collinear vertices created a body")
+	}
+	circle := make([]rl.Vector2, maxVertices+1)
+	for i := range circle {
+		angle := float64(i) * 2 * math.Pi / float64(len(circle))
+		circle[i] = rl.NewVector2(float32(math.Cos(angle))*50, float32(math.Sin(angle))*50)
+	}
+	if _, err := w.NewBodyFromVertices(rl.Vector2{}, circle, 1); err == nil {
+		t.Errorf("polygon with more than %d vertices created a body", maxVertices)
+	}
+}
+
+func TestCompoundBodies(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+
+	// Dumbbell made of a bar and two heavy wheels
+	dumbbell, err := w.NewBodyCompound(rl.NewVector2(0, 0), []FixtureDef{
+		{Type: PolygonShape, Vertices: []rl.Vector2{{X: -40, Y: -2}, {X: 40, Y: -2}, {X: 40, Y: 2}, {X: -40, Y: 2}}, Density: 1},
+		{Type: CircleShape, Offset: rl.NewVector2(-40, 0), Radius: 10, Density: 2, StaticFriction: 0.5, DynamicFriction: 0.5},
+		{Type: CircleShape, Offset: rl.NewVector2(40, 0), Radius: 10, Density: 2, StaticFriction: 0.5, DynamicFriction: 0.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixtures := dumbbell.GetFixtures(); len(fixtures) != 3 || fixtures[0] != &dumbbell.Shape {
+		t.Fatalf("unexpected fixtures %v", fixtures)
+	}
+
+	for i := 0; i < 600; i++ {
+		w.Step(defaultTestStep)
+	}
+
+	// Both wheels rest on the floor, keeping the bar level above it
+	if !nearlyEqualTolerance(dumbbell.Position.Y, 80, 1) || !nearlyEqualTolerance(dumbbell.Orient, 0, 0.01) {
+		t.Errorf("dumbbell did not rest on both wheels: position %v, orient %v", dumbbell.Position, dumbbell.Orient)
+	}
+	if len(w.manifolds) != 2 {
+		t.Errorf("expected one manifold per wheel, got %d", len(w.manifolds))
+	}
+
+	hit, ok := w.Raycast(rl.NewVector2(40, 0), rl.NewVector2(40, 200), nil)
+	if !ok || hit.Body != dumbbell || hit.Shape != dumbbell.GetFixtures()[2] {
+		t.Errorf("raycast did not hit the right wheel: %+v", hit)
+	}
+
+	// Single circle compounds match circle bodies
+	wheel, err := w.NewBodyCompound(rl.NewVector2(200, 0), []FixtureDef{{Type: CircleShape, Radius: 10, Density: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	circle := w.NewBodyCircle(rl.NewVector2(300, 0), 10, 2)
+	if !nearlyEqualTolerance(wheel.Mass, circle.Mass, 0.01) || !nearlyEqualTolerance(wheel.Inertia, circle.Inertia, 1) {
+		t.Errorf("circle compound mass %v and inertia %v, expected %v and %v", wheel.Mass, wheel.Inertia, circle.Mass, circle.Inertia)
+	}
+}
+
+func TestConcaveBodies(t *testing.T) {
+	// L shaped polygon wound clockwise
+	outline := []rl.Vector2{{X: 0, Y: 0}, {X: 0, Y: 60}, {X: 20, Y: 60}, {X: 20, Y: 20}, {X: 60, Y: 20}, {X: 60, Y: 0}}
+
+	pieces, err := ConvexDecomposition(outline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pieces) != 2 {
+		t.Errorf("expected 2 convex pieces, got %d", len(pieces))
+	}
+
+	var area float32
+	for _, piece := range pieces {
+		for i := range piece {
+			a, b, c := piece[i], piece[(i+1)%len(piece)], piece[(i+2)%len(piece)]
+			if hullTurn(a, b, c) < 0 {
+				t.Errorf("piece %v is not convex", piece)
+			}
+			area += rl.Vector2CrossProduct(a, b) / 2
+		}
+	}
+	if !nearlyEqual(area, 2000) {
+		t.Errorf("pieces area is %v, expected 2000", area)
+	}
+
+	if _, err := ConvexDecomposition([]rl.Vector2{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}); err == nil {
+		t.Error("self intersecting polygon was decomposed")
+	}
+
+	w := NewWorld()
+	body, err := w.NewBodyFromConcave(rl.NewVector2(100, 100), outline, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nearlyEqual(body.Mass, 2000) {
+		t.Errorf("body mass is %v, expected 2000", body.Mass)
+	}
+	if !body.ContainsPoint(rl.NewVector2(110, 150)) || !body.ContainsPoint(rl.NewVector2(150, 110)) {
+		t.Error("body does not contain points inside both arms")
+	}
+	if body.ContainsPoint(rl.NewVector2(140, 140)) {
+		t.Error("body contains a point in its notch")
+	}
+}
+
+func TestEdgeShapes(t *testing.T) {
+	w := NewWorld()
+	ground, err := w.NewBodyChain(rl.NewVector2(0, 100), []rl.Vector2{
+		{X: -200, Y: 0}, {X: -100, Y: 0}, {X: 0, Y: 0}, {X: 100, Y: 0}, {X: 200, Y: 0},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ground.StaticFriction, ground.DynamicFriction = 0, 0
+	for _, shape := range ground.GetFixtures() {
+		shape.StaticFriction, shape.DynamicFriction = 0, 0
+	}
+	if len(ground.GetFixtures()) != 4 || ground.Type != StaticBody {
+		t.Fatalf("unexpected chain body %+v", ground)
+	}
+
+	ball := w.NewBodyCircle(rl.NewVector2(-150, 50), 10, 1)
+	box := w.NewBodyRectangle(rl.NewVector2(-150, 89), 20, 20, 1)
+	box.StaticFriction, box.DynamicFriction = 0, 0
+	box.FreezeOrient = true
+	box.Velocity.X = 0.4
+
+	// Edges only collide on their front side
+	riser := w.NewBodyCircle(rl.NewVector2(150, 150), 10, 1)
+	riser.UseGravity = false
+	riser.Velocity.Y = -0.2
+
+	for i := 0; i < 300; i++ {
+		w.Step(defaultTestStep)
+		if box.Velocity.Y < -0.01 {
+			t.Fatalf("box bumped on a chain joint at %v, velocity %v", box.Position, box.Velocity)
+		}
+	}
+
+	if !nearlyEqualTolerance(ball.Position.Y, 90, 1) {
+		t.Errorf("ball did not rest on the chain: %v", ball.Position)
+	}
+	if box.Position.X < 0 || !nearlyEqualTolerance(box.Position.Y, 90, 1) {
+		t.Errorf("box did not slide along the chain: %v", box.Position)
+	}
+	if riser.Position.Y > 50 {
+		t.Errorf("body rising from behind the chain was stopped at %v", riser.Position)
+	}
+
+	onlyGround := func(body *Body) bool { return body == ground }
+	hit, ok := w.Raycast(rl.NewVector2(50, 0), rl.NewVector2(50, 200), onlyGround)
+	if !ok || hit.Body != ground || !nearlyEqual(hit.Point.Y, 100) || !nearlyEqual(hit.Normal.Y, -1) {
+		t.Errorf("raycast did not hit the chain front side: %+v", hit)
+	}
+	if _, ok := w.Raycast(rl.NewVector2(50, 200), rl.NewVector2(50, 0), onlyGround); ok {
+		t.Error("raycast hit the chain back side")
+	}
+
+	data, err := w.TakeSnapshot().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot Snapshot
+	if err := snapshot.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&snapshot, w.TakeSnapshot()) {
+		t.Error("chain snapshot changed after a binary round trip")
+	}
+}
+
+func TestSleepingBodies(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+
+	var stack []*Body
+	for i := 0; i < 3; i++ {
+		stack = append(stack, w.NewBodyRectangle(rl.NewVector2(0, 80-float32(i)*20), 20, 20, 1))
+	}
+	lonely := w.NewBodyCircle(rl.NewVector2(100, 80), 10, 1)
+	lonely.AllowSleep = false
+
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	for i, box := range stack {
+		if !box.IsSleeping {
+			t.Fatalf("box %d did not fall asleep, velocity %v", i, box.Velocity)
+		}
+	}
+	if lonely.IsSleeping {
+		t.Error("body not allowed to sleep fell asleep")
+	}
+
+	// Sleeping bodies do not move and keep touching
+	position := stack[2].Position
+	w.Step(defaultTestStep)
+	if stack[2].Position != position || len(stack[2].GetContacts()) != 1 {
+		t.Errorf("sleeping box moved to %v or lost its contacts", stack[2].Position)
+	}
+
+	// Resting pairs skip collision detection and keep their manifolds
+	for _, manifold := range w.manifolds {
+		manifold.Penetration = -1
+	}
+	w.Step(defaultTestStep)
+	kept := 0
+	for _, manifold := range w.manifolds {
+		if manifold.BodyA != lonely && manifold.BodyB != lonely {
+			if manifold.Penetration != -1 {
+				t.Errorf("resting manifold between bodies %d and %d was solved again", manifold.BodyA.ID, manifold.BodyB.ID)
+			}
+			kept++
+		}
+	}
+	if kept != 3 {
+		t.Errorf("sleeping stack kept %d manifolds, want 3", kept)
+	}
+
+	// Forces wake a body and the rest of its island on the next step
+	AddForce(stack[2], rl.NewVector2(0.01, 0))
+	if stack[2].IsSleeping {
+		t.Fatal("adding a force did not wake the body")
+	}
+	w.Step(defaultTestStep)
+	if stack[0].IsSleeping || stack[1].IsSleeping {
+		t.Error("bodies touching a woken body kept sleeping")
+	}
+
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !stack[0].IsSleeping {
+		t.Fatal("stack did not fall asleep again")
+	}
+
+	// Bodies falling on a sleeping island wake it
+	w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	for i := 0; i < 100 && stack[0].IsSleeping; i++ {
+		w.Step(defaultTestStep)
+	}
+	if stack[0].IsSleeping {
+		t.Error("falling body did not wake the stack")
+	}
+
+	w.SetSleepEnabled(false)
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	for i, box := range stack {
+		if box.IsSleeping {
+			t.Errorf("box %d fell asleep with sleeping disabled", i)
+		}
+	}
+}
+
+func TestWarmStarting(t *testing.T) {
+	w := NewWorld()
+	w.SetSleepEnabled(false)
+	w.SetIterations(4, 1)
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+
+	var stack []*Body
+	for i := 0; i < 10; i++ {
+		stack = append(stack, w.NewBodyRectangle(rl.NewVector2(0, 80-float32(i)*20), 20, 20, 1))
+	}
+
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+
+	// Impulses carried over between steps keep a tall stack standing with few iterations
+	top := stack[len(stack)-1]
+	if !nearlyEqualTolerance(top.Position.X, 0, 1) || !nearlyEqualTolerance(top.Position.Y, -100, 1) {
+		t.Fatalf("stack did not stay standing, top box at %v", top.Position)
+	}
+
+	// Touching shapes keep their manifold and accumulated impulses
+	manifolds := append([]*Manifold(nil), w.manifolds...)
+	w.Step(defaultTestStep)
+	kept := make(map[*Manifold]bool, len(w.manifolds))
+	for _, manifold := range w.manifolds {
+		kept[manifold] = true
+	}
+	for _, manifold := range manifolds {
+		if !kept[manifold] {
+			t.Fatalf("manifold %d was not kept between steps", manifold.ID)
+		}
+		for i := 0; i < manifold.ContactsCount; i++ {
+			if manifold.NormalImpulses[i] <= 0 {
+				t.Errorf("manifold %d point %d has no normal impulse", manifold.ID, i)
+			}
+		}
+	}
+}
+
+type debugRecorder struct {
+	segments, circles, points int
+	colors                    map[rl.Color]int
+}
+
+func (r *debugRecorder) DrawSegment(start, end rl.Vector2, color rl.Color) {
+	r.segments++
+	r.colors[color]++
+}
+
+func (r *debugRecorder) DrawCircle(center rl.Vector2, radius float32, color rl.Color) {
+	r.circles++
+	r.colors[color]++
+}
+
+func (r *debugRecorder) DrawPoint(point rl.Vector2, radius float32, color rl.Color) {
+	r.points++
+	r.colors[color]++
+}
+
+func TestDebugDraw(t *testing.T) {
+	w := NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+	box := w.NewBodyRectangle(rl.NewVector2(0, 80), 20, 20, 1)
+	w.NewBodyCircle(rl.NewVector2(100, 80), 10, 1)
+
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !box.IsSleeping {
+		t.Fatal("box did not fall asleep")
+	}
+
+	draw := func(flags DebugDrawFlags) *debugRecorder {
+		recorder := &debugRecorder{colors: make(map[rl.Color]int)}
+		w.DebugDraw(recorder, flags)
+		return recorder
+	}
+
+	// Rectangles draw their four sides and circles their outline and radius
+	shapes := draw(DrawShapes)
+	if shapes.segments != 9 || shapes.circles != 1 || shapes.points != 0 {
+		t.Errorf("shapes drew %d segments, %d circles and %d points", shapes.segments, shapes.circles, shapes.points)
+	}
+	if shapes.colors[rl.Gray] != 4 || shapes.colors[rl.Green] != 6 {
+		t.Errorf("shapes drew with colors %v", shapes.colors)
+	}
+	if sleeping := draw(DrawShapes | DrawSleeping); sleeping.colors[rl.DarkGreen] != 6 {
+		t.Errorf("sleeping bodies drew with colors %v", sleeping.colors)
+	}
+
+	if boxes := draw(DrawAABBs); boxes.segments != 12 || boxes.colors[rl.Magenta] != 12 {
+		t.Errorf("bounding boxes drew %d segments", boxes.segments)
+	}
+
+	contacts := 0
+	for _, manifold := range w.manifolds {
+		contacts += manifold.ContactsCount
+	}
+	if contacts == 0 {
+		t.Fatal("resting bodies have no points of contact")
+	}
+	if points := draw(DrawContacts); points.points != contacts || points.segments != 0 {
+		t.Errorf("contacts drew %d points, want %d", points.points, contacts)
+	}
+	if normals := draw(DrawNormals); normals.segments != contacts || normals.points != 0 {
+		t.Errorf("normals drew %d segments, want %d", normals.segments, contacts)
+	}
+
+	if joints := draw(DrawJoints); joints.segments != 0 {
+		t.Errorf("world without joints drew %d segments", joints.segments)
+	}
+	w.NewDistanceJoint(floor, box, floor.Position, box.Position)
+	if joints := draw(DrawJoints); joints.segments != 3 || joints.points != 2 {
+		t.Errorf("joint drew %d segments and %d points", joints.segments, joints.points)
+	}
+
+	if none := draw(0); none.segments != 0 || none.circles != 0 || none.points != 0 {
+		t.Error("no flags drew something")
+	}
+
+	// Destroyed bodies points of contact are not drawn before the next step
+	remaining := 0
+	for _, manifold := range w.manifolds {
+		if manifold.BodyA != box && manifold.BodyB != box {
+			remaining += manifold.ContactsCount
+		}
+	}
+	box.Destroy()
+	if points := draw(DrawContacts); points.points != remaining {
+		t.Errorf("contacts drew %d points after destroying the box, want %d", points.points, remaining)
+	}
+}
+
+func TestPreSolve(t *testing.T) {
+	w := NewWorld()
+	w.SetSleepEnabled(false)
+	platform := w.NewBodyRectangle(rl.NewVector2(0, 0), 200, 10, 1)
+	platform.SetType(StaticBody)
+	ball := w.NewBodyCircle(rl.NewVector2(0, 30), 5, 1)
+	ball.Velocity = rl.NewVector2(0, -1)
+
+	// One-way platform, bodies below its top pass through
+	w.SetPreSolve(func(manifold *Manifold) {
+		other := manifold.GetOther(platform)
+		if other.Position.Y+5 > platform.Position.Y-5+1 {
+			manifold.Enabled = false
+		}
+	})
+
+	highest := ball.Position.Y
+	for i := 0; i < 3000; i++ {
+		w.Step(defaultTestStep)
+		highest = min(highest, ball.Position.Y)
+	}
+	if highest > -15 {
+		t.Fatalf("ball did not jump through the platform, highest position %v", highest)
+	}
+	if !nearlyEqualTolerance(ball.Position.Y, -10, 1) {
+		t.Errorf("ball did not land on the platform, position %v", ball.Position)
+	}
+
+	// Conveyor belt dragging bodies to the right
+	w = NewWorld()
+	w.SetSleepEnabled(false)
+	belt := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	belt.SetType(StaticBody)
+	box := w.NewBodyRectangle(rl.NewVector2(0, 80), 20, 20, 1)
+
+	const beltSpeed = 0.05
+	w.SetPreSolve(func(manifold *Manifold) {
+		speed := rl.Vector2DotProduct(rl.NewVector2(beltSpeed, 0), manifold.GetTangent())
+		if manifold.BodyA == box {
+			speed = -speed
+		}
+		manifold.TangentSpeed = speed
+	})
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !nearlyEqualTolerance(box.Velocity.X, beltSpeed, 0.005) || box.Position.X < 50 {
+		t.Errorf("belt did not drag the box, velocity %v and position %v", box.Velocity, box.Position)
+	}
+
+	// Material overrides
+	w = NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+	bouncy := w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	w.SetPreSolve(func(manifold *Manifold) {
+		manifold.Restitution = 1
+	})
+
+	bounced := false
+	for i := 0; i < 2000; i++ {
+		w.Step(defaultTestStep)
+		bounced = bounced || bouncy.Velocity.Y < -0.1
+	}
+	if !bounced {
+		t.Error("restitution override did not bounce the ball")
+	}
+}
+
+func TestCharacterController(t *testing.T) {
+	const (
+		gravity = 0.001
+		speed   = 0.2
+	)
+	run := func(w *World, c *CharacterController, walk float32, steps int, each func()) rl.Vector2 {
+		var velocity rl.Vector2
+		for i := 0; i < steps; i++ {
+			velocity.X = walk
+			velocity.Y += gravity * defaultTestStep
+			velocity = c.Move(velocity, defaultTestStep)
+			w.Step(defaultTestStep)
+			if each != nil {
+				each()
+			}
+		}
+		return velocity
+	}
+
+	// Landing, stepping up a low ledge, snapping down from it and stopping at a tall wall
+	w := NewWorld()
+	ground := w.NewBodyRectangle(rl.NewVector2(0, 100), 1000, 20, 1)
+	ground.SetType(StaticBody)
+	ledge := w.NewBodyRectangle(rl.NewVector2(80, 87), 40, 6, 1)
+	ledge.SetType(StaticBody)
+	wall := w.NewBodyRectangle(rl.NewVector2(160, 75), 20, 30, 1)
+	wall.SetType(StaticBody)
+	c := NewCharacterController(w.NewBodyRectangle(rl.NewVector2(0, 50), 20, 40, 1))
+	if c.Body.Type != KinematicBody || c.Up != rl.NewVector2(0, -1) {
+		t.Fatalf("controller body is %v with up %v", c.Body.Type, c.Up)
+	}
+
+	velocity := run(w, c, 0, 200, nil)
+	if !c.IsGrounded || c.GroundBody != ground || c.GroundNormal != rl.NewVector2(0, -1) {
+		t.Fatalf("character did not land on the ground, grounded %v on %v", c.IsGrounded, c.GroundBody)
+	}
+	if !nearlyEqualTolerance(c.Body.Position.Y, 70-c.SkinWidth, 0.01) || velocity.Y != 0 {
+		t.Errorf("landed character at %v with velocity %v", c.Body.Position, velocity)
+	}
+
+	airborne, highest, stopped := 0, c.Body.Position.Y, 0
+	run(w, c, speed, 480, func() {
+		if !c.IsGrounded {
+			airborne++
+		}
+		if c.Body.Velocity.X == 0 && c.Body.Position.X < 100 {
+			stopped++
+		}
+		highest = min(highest, c.Body.Position.Y)
+	})
+	if !nearlyEqualTolerance(highest, 64-c.SkinWidth, 0.01) || stopped > 0 {
+		t.Errorf("character did not step up the ledge, highest position %v and %d steps stopped", highest, stopped)
+	}
+	if airborne > 0 || !nearlyEqualTolerance(c.Body.Position.Y, 70-c.SkinWidth, 0.01) {
+		t.Errorf("character left the ground for %d steps walking off the ledge, position %v", airborne, c.Body.Position)
+	}
+	if !nearlyEqualTolerance(c.Body.Position.X, 140-c.SkinWidth, 0.01) || !c.IsTouchingWall {
+		t.Errorf("character did not stop at the wall, position %v", c.Body.Position)
+	}
+
+	// Walkable and too steep slopes
+	slope := func(angle float32) (*World, *CharacterController) {
+		w := NewWorld()
+		top := rl.NewVector2(100, -100*float32(math.Tan(float64(angle*degToRad))))
+		if _, err := w.NewBodyChain(rl.Vector2{}, []rl.Vector2{{X: -200, Y: 0}, {X: 0, Y: 0}, top, {X: top.X + 400, Y: top.Y}}, false); err != nil {
+			t.Fatal(err)
+		}
+		c := NewCharacterController(w.NewBodyRectangle(rl.NewVector2(-100, -30), 20, 40, 1))
+		run(w, c, 0, 100, nil)
+		return w, c
+	}
+
+	w, c = slope(30)
+	run(w, c, speed, 900, nil)
+	if c.Body.Position.X < 150 || !nearlyEqualTolerance(c.Body.Position.Y, -57.735-20-c.SkinWidth, 0.01) || !c.IsGrounded {
+		t.Errorf("character did not walk up the slope, position %v", c.Body.Position)
+	}
+	airborne = 0
+	run(w, c, -speed, 900, func() {
+		if !c.IsGrounded {
+			airborne++
+		}
+	})
+	if airborne > 0 || c.Body.Position.X > -50 {
+		t.Errorf("character left the ground for %d steps walking down the slope, position %v", airborne, c.Body.Position)
+	}
+
+	w, c = slope(60)
+	run(w, c, speed, 600, nil)
+	if c.Body.Position.X > -10 || c.Body.Position.Y < -20.5 || !c.IsTouchingWall {
+		t.Errorf("character climbed the steep slope, position %v", c.Body.Position)
+	}
+
+	// Moving platforms carry the characters standing on them
+	w = NewWorld()
+	platform := w.NewBodyRectangle(rl.NewVector2(0, 100), 200, 20, 1)
+	platform.SetType(KinematicBody)
+	platform.Velocity = rl.NewVector2(0.05, -0.02)
+	c = NewCharacterController(w.NewBodyRectangle(rl.NewVector2(0, 60), 20, 40, 1))
+	run(w, c, 0, 200, nil)
+	landed := rl.Vector2Subtract(c.Body.Position, platform.Position)
+
+	run(w, c, 0, 600, nil)
+	if c.GroundBody != platform || c.GroundVelocity != platform.Velocity {
+		t.Fatalf("character is not standing on the platform, ground velocity %v", c.GroundVelocity)
+	}
+	if offset := rl.Vector2Subtract(c.Body.Position, platform.Position); rl.Vector2Distance(offset, landed) > 0.01 {
+		t.Errorf("platform did not carry the character, offset %v after landing at %v", offset, landed)
+	}
+}
+
+func TestFracture(t *testing.T) {
+	momentum := func(bodies []*Body) (rl.Vector2, float32) {
+		var linear rl.Vector2
+		var angular float32
+		for _, body := range bodies {
+			linear = rl.Vector2Add(linear, rl.Vector2Scale(body.Velocity, body.Mass))
+			angular += body.Inertia*body.AngularVelocity + body.Mass*rl.Vector2CrossProduct(body.Position, body.Velocity)
+		}
+		return linear, angular
+	}
+
+	for _, pattern := range []FracturePattern{FractureRadial, FractureVoronoi} {
+		w := NewWorld()
+		// Rectangles compute their inertia around the world origin, only centered ones have the right inertia
+		box := w.NewBodyRectangle(rl.NewVector2(0, 0), 60, 40, 2)
+		box.Velocity = rl.NewVector2(0.1, -0.05)
+		box.AngularVelocity = 0.002
+		box.Restitution = 0.5
+		box.SetFilter(0x0002, 0x0004, -1)
+		linear, angular := momentum([]*Body{box})
+
+		fragments, err := w.Fracture(box, rl.NewVector2(10, -5), FractureDef{Pattern: pattern, Pieces: 12, Seed: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fragments) < 4 || w.GetBodiesCount() != len(fragments) || box.world != nil {
+			t.Fatalf("pattern %d made %d fragments in a world of %d bodies", pattern, len(fragments), w.GetBodiesCount())
+		}
+
+		var mass float32
+		for _, fragment := range fragments {
+			mass += fragment.Mass
+			if fragment.Restitution != 0.5 || fragment.CategoryBits != 0x0002 || fragment.MaskBits != 0x0004 || fragment.GroupIndex != -1 {
+				t.Fatalf("pattern %d fragment did not inherit the body material and filter", pattern)
+			}
+		}
+		if !nearlyEqualTolerance(mass, box.Mass, box.Mass*0.001) {
+			t.Errorf("pattern %d fragments weigh %v, the body weighed %v", pattern, mass, box.Mass)
+		}
+
+		fragmentsLinear, fragmentsAngular := momentum(fragments)
+		if rl.Vector2Distance(fragmentsLinear, linear) > 0.001*rl.Vector2Length(linear) ||
+			!nearlyEqualTolerance(fragmentsAngular, angular, 0.001*float32(math.Abs(float64(angular)))) {
+			t.Errorf("pattern %d changed the momentum from %v %v to %v %v", pattern, linear, angular, fragmentsLinear, fragmentsAngular)
+		}
+	}
+
+	// Voronoi fragments are smaller close to the impact point and the same seed breaks the same way
+	breakBox := func(seed int64) []*Body {
+		w := NewWorld()
+		box := w.NewBodyRectangle(rl.NewVector2(0, 0), 100, 100, 1)
+		fragments, err := w.Fracture(box, rl.NewVector2(-40, -40), FractureDef{Pattern: FractureVoronoi, Pieces: 16, Seed: seed})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fragments
+	}
+	fragments := breakBox(7)
+	var near, far []float32
+	for _, fragment := range fragments {
+		if rl.Vector2Distance(fragment.Position, rl.NewVector2(-40, -40)) < 40 {
+			near = append(near, fragment.Mass)
+		} else {
+			far = append(far, fragment.Mass)
+		}
+	}
+	average := func(values []float32) float32 {
+		var sum float32
+		for _, value := range values {
+			sum += value
+		}
+		return sum / float32(len(values))
+	}
+	if len(near) == 0 || len(far) == 0 || average(near) >= average(far) {
+		t.Errorf("fragments close to the impact weigh %v, far ones %v", near, far)
+	}
+	again := breakBox(7)
+	for i := range fragments {
+		if i >= len(again) || fragments[i].Position != again[i].Position {
+			t.Fatal("same seed broke the box differently")
+		}
+	}
+
+	// Circles break in as many pieces as asked, the fracture force pushes them away from the impact point
+	w := NewWorld()
+	w.SetGravity(0, 0)
+	ball := w.NewBodyCircle(rl.NewVector2(0, 0), 30, 1)
+	fragments, err := w.Fracture(ball, rl.NewVector2(0, 0), FractureDef{Pieces: 6, Force: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 6 {
+		t.Fatalf("circle broke in %d fragments", len(fragments))
+	}
+	w.Step(defaultTestStep)
+	for _, fragment := range fragments {
+		if rl.Vector2DotProduct(fragment.Velocity, fragment.Position) <= 0 {
+			t.Errorf("fragment at %v moves towards the impact point with velocity %v", fragment.Position, fragment.Velocity)
+		}
+	}
+
+	// Impacts outside the body still break it, shatter only breaks bodies containing the position
+	box := w.NewBodyRectangle(rl.NewVector2(200, 0), 20, 20, 1)
+	w.Shatter(box, rl.NewVector2(300, 0), 1)
+	if box.world == nil {
+		t.Error("shatter broke a body not containing the position")
+	}
+	if fragments, err := w.Fracture(box, rl.NewVector2(300, 0), FractureDef{}); err != nil || len(fragments) < 2 {
+		t.Errorf("impact outside the body made %d fragments: %v", len(fragments), err)
+	}
+
+	// Shatter breaks polygons in one fragment per side keeping their density
+	shattered := NewWorld()
+	square := shattered.NewBodyRectangle(rl.NewVector2(0, 0), 40, 40, 2)
+	shattered.Shatter(square, rl.NewVector2(5, 5), 1)
+	var mass float32
+	for _, fragment := range shattered.GetBodies() {
+		mass += fragment.Mass
+	}
+	if shattered.GetBodiesCount() != 4 || !nearlyEqualTolerance(mass, 3200, 0.1) {
+		t.Errorf("shatter made %d fragments with a mass of %v, want 4 and 3200", shattered.GetBodiesCount(), mass)
+	}
+
+	edge, _ := w.NewBodyEdge(rl.Vector2{}, rl.NewVector2(-10, 0), rl.NewVector2(10, 0))
+	if _, err := w.Fracture(edge, rl.Vector2{}, FractureDef{}); err == nil {
+		t.Error("edge body was fractured")
+	}
+}
+
+func TestForceFields(t *testing.T) {
+	// Uniform fields accelerate like gravity, only the bodies inside their region
+	w := NewWorld()
+	w.SetGravity(0, 0)
+	w.NewUniformField(AABB{Min: rl.NewVector2(-100, -100), Max: rl.NewVector2(100, 100)}, rl.NewVector2(0, 9.81))
+	inside := w.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	outside := w.NewBodyCircle(rl.NewVector2(300, 0), 5, 1)
+	falling := NewWorld()
+	reference := falling.NewBodyCircle(rl.NewVector2(0, 0), 5, 1)
+	for i := 0; i < 60; i++ {
+		w.Step(defaultTestStep)
+		falling.Step(defaultTestStep)
+	}
+	if !nearlyEqualTolerance(inside.Velocity.Y, reference.Velocity.Y, 1e-4) || outside.Velocity != (rl.Vector2{}) {
+		t.Errorf("uniform field velocities are %v inside and %v outside, gravity gives %v", inside.Velocity, outside.Velocity, reference.Velocity)
+	}
+
+	// Radial fields pull towards their center within their radius, masked categories are not pulled
+	w = NewWorld()
+	w.SetGravity(0, 0)
+	planet := w.NewRadialField(rl.NewVector2(0, 0), 200, 9.81)
+	planet.MaskBits = 0x0001
+	var moons []*Body
+	for _, position := range []rl.Vector2{{X: 100}, {Y: -150}, {X: -50, Y: 50}} {
+		moons = append(moons, w.NewBodyCircle(position, 5, 1))
+	}
+	far := w.NewBodyCircle(rl.NewVector2(0, 300), 5, 1)
+	masked := w.NewBodyCircle(rl.NewVector2(0, 100), 5, 1)
+	masked.SetFilter(0x0002, DefaultMaskBits, 0)
+	for i := 0; i < 10; i++ {
+		w.Step(defaultTestStep)
+	}
+	for _, moon := range moons {
+		if rl.Vector2DotProduct(moon.Velocity, moon.Position) >= 0 || math.Abs(float64(rl.Vector2CrossProduct(moon.Velocity, moon.Position))) > 1e-6 {
+			t.Errorf("moon at %v moves with %v", moon.Position, moon.Velocity)
+		}
+	}
+	if far.Velocity != (rl.Vector2{}) || masked.Velocity != (rl.Vector2{}) {
+		t.Errorf("radial field moved bodies out of reach %v or masked %v", far.Velocity, masked.Velocity)
+	}
+
+	// Drag fields slow bodies down towards their flow velocity
+	w = NewWorld()
+	w.SetGravity(0, 0)
+	wind := rl.NewVector2(0.05, 0)
+	w.NewDragField(AABB{}, 10, wind)
+	leaf := w.NewBodyRectangle(rl.NewVector2(0, 0), 10, 10, 1)
+	leaf.Velocity = rl.NewVector2(0, 0.1)
+	leaf.AngularVelocity = 0.01
+	for i := 0; i < 600; i++ {
+		w.Step(defaultTestStep)
+	}
+	if rl.Vector2Distance(leaf.Velocity, wind) > 0.001 || math.Abs(float64(leaf.AngularVelocity)) > 1e-4 {
+		t.Errorf("leaf moves with %v spinning at %v in the wind %v", leaf.Velocity, leaf.AngularVelocity, wind)
+	}
+
+	// Buoyancy fields float bodies lighter than the fluid with their submerged fraction matching the densities ratio
+	w = NewWorld()
+	water := w.NewBuoyancyField(AABB{Min: rl.NewVector2(-200, 0), Max: rl.NewVector2(200, 400)}, 1)
+	water.LinearDrag = 20
+	light := w.NewBodyRectangle(rl.NewVector2(-100, -50), 20, 20, 0.25)
+	ball := w.NewBodyCircle(rl.NewVector2(0, -50), 10, 0.5)
+	heavy := w.NewBodyRectangle(rl.NewVector2(100, -50), 20, 20, 2)
+	light.FreezeOrient = true
+	heavy.FreezeOrient = true
+	for i := 0; i < 3000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !nearlyEqualTolerance(light.Position.Y, -5, 0.5) {
+		t.Errorf("light box floats at %v instead of a quarter submerged", light.Position.Y)
+	}
+	if !nearlyEqualTolerance(ball.Position.Y, 0, 0.5) {
+		t.Errorf("ball floats at %v instead of half submerged", ball.Position.Y)
+	}
+	if heavy.Position.Y < 100 || heavy.Velocity.Y <= 0 {
+		t.Errorf("heavy box at %v moving with %v does not sink", heavy.Position, heavy.Velocity)
+	}
+
+	// Buoyancy pushes at the submerged centroid, straightening floating boards
+	w = NewWorld()
+	water = w.NewBuoyancyField(AABB{Min: rl.NewVector2(-200, 0), Max: rl.NewVector2(200, 400)}, 1)
+	board := w.NewBodyRectangle(rl.NewVector2(0, 0), 60, 10, 0.5)
+	board.SetRotation(0.3)
+	for i := 0; i < 6000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if orient := math.Remainder(float64(board.Orient), math.Pi); math.Abs(orient) > 0.05 {
+		t.Errorf("board floats tilted by %v", orient)
+	}
+
+	water.Destroy()
+	if len(w.GetForceFields()) != 0 || water.world != nil {
+		t.Error("destroyed field still in the world")
+	}
+
+	// Buoyancy does not lift bodies not using gravity
+	w = NewWorld()
+	w.NewBuoyancyField(AABB{Min: rl.NewVector2(-200, 0), Max: rl.NewVector2(200, 400)}, 1)
+	weightless := w.NewBodyRectangle(rl.NewVector2(0, 50), 20, 20, 0.5)
+	weightless.UseGravity = false
+	for i := 0; i < 600; i++ {
+		w.Step(defaultTestStep)
+	}
+	if weightless.Position != rl.NewVector2(0, 50) {
+		t.Errorf("weightless body moved to %v in the fluid", weightless.Position)
+	}
+
+	// New and enabled fields act on sleeping bodies
+	w = NewWorld()
+	floor := w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+	box := w.NewBodyRectangle(rl.NewVector2(0, 80), 20, 20, 1)
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !box.IsSleeping {
+		t.Fatalf("box did not fall asleep, velocity %v", box.Velocity)
+	}
+
+	rest := box.Position
+	w.NewUniformField(AABB{}, rl.NewVector2(0, -50))
+	for i := 0; i < 120; i++ {
+		w.Step(defaultTestStep)
+	}
+	if box.Position.Y >= rest.Y {
+		t.Errorf("new field did not lift the sleeping box from %v", rest)
+	}
+
+	w = NewWorld()
+	floor = w.NewBodyRectangle(rl.NewVector2(0, 100), 400, 20, 1)
+	floor.SetType(StaticBody)
+	box = w.NewBodyRectangle(rl.NewVector2(0, 80), 20, 20, 1)
+	breeze := w.NewUniformField(AABB{}, rl.NewVector2(50, 0))
+	breeze.Enabled = false
+	for i := 0; i < 1000; i++ {
+		w.Step(defaultTestStep)
+	}
+	rest = box.Position
+	breeze.Enabled = true
+	for i := 0; i < 120; i++ {
+		w.Step(defaultTestStep)
+	}
+	if !(box.Position.X > rest.X) {
+		t.Errorf("enabled field did not push the sleeping box from %v", rest)
+	}
+}
+
+func nearlyEqualTolerance(a, b, tolerance float32) bool {
+	return a-b <= tolerance && b-a <= tolerance
+}
@@ -0,0 +1,38 @@
+package physics
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// PreSolve - Called every step for every touching pair of shapes before solving their collision, after the
+// materials are mixed. It can disable the manifold for the step (one-way platforms) or override its friction,
+// restitution and tangent speed (conveyor belts)
+type PreSolve func(manifold *Manifold)
+
+// SetPreSolve - Sets the user callback called before solving every manifold (nil to disable)
+func SetPreSolve(preSolve PreSolve) {
+	defaultWorld.SetPreSolve(preSolve)
+}
+
+// SetPreSolve - Sets the user callback called before solving every manifold (nil to disable)
+func (w *World) SetPreSolve(preSolve PreSolve) {
+	w.preSolve = preSolve
+}
+
+// GetOther - Returns the manifold body touching the given one
+func (m *Manifold) GetOther(body *Body) *Body {
+	if m.BodyA == body {
+		return m.BodyB
+	}
+	return m.BodyA
+}
+
+// GetTangent - Returns the manifold tangent direction, the one TangentSpeed is measured along
+func (m *Manifold) GetTangent() rl.Vector2 {
+	return rl.NewVector2(m.Normal.Y, -m.Normal.X)
+}
+
+// isSolved - Checks if the manifold collision has to be solved in the current step
+func (m *Manifold) isSolved() bool {
+	return !m.IsSensor && m.Enabled && m.isAwake()
+}
@@ -0,0 +1,748 @@
+package physics
+
+import (
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaycastHit - Information about the first point a ray or a cast shape hits on a physics body
+type RaycastHit struct {
+	// Physics body hit
+	Body *Body
+	// Physics body shape hit
+	Shape *Shape
+	// World space hit position
+	Point rl.Vector2
+	// Hit body surface normal at the hit position
+	Normal rl.Vector2
+	// Fraction of the ray or cast translation where the hit happens (0 to 1)
+	Fraction float32
+}
+
+// QueryFilter - Decides if a physics body is considered by a world query, nil accepts every body
+type QueryFilter func(body *Body) bool
+
+// worldPolygon - Polygon shape vertices and normals transformed to world space
+type worldPolygon struct {
+	count     int
+	positions [maxVertices]rl.Vector2
+	normals   [maxVertices]rl.Vector2
+}
+
+// Raycast - Returns the closest body hit by a ray going from start to end
+func Raycast(start, end rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	return defaultWorld.Raycast(start, end, filter)
+}
+
+// RaycastAll - Returns every body hit by a ray going from start to end, sorted by distance
+func RaycastAll(start, end rl.Vector2, filter QueryFilter) []RaycastHit {
+	return defaultWorld.RaycastAll(start, end, filter)
+}
+
+// CircleCast - Returns the first body hit by a circle moved from center by translation
+func CircleCast(center rl.Vector2, radius float32, translation rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	return defaultWorld.CircleCast(center, radius, translation, filter)
+}
+
+// PolygonCast - Returns the first body hit by a convex polygon moved from position by translation
+func PolygonCast(vertices []rl.Vector2, position rl.Vector2, orient float32, translation rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	return defaultWorld.PolygonCast(vertices, position, orient, translation, filter)
+}
+
+// QueryPoint - Returns the bodies whose shape contains a point
+func QueryPoint(point rl.Vector2, filter QueryFilter) []*Body {
+	return defaultWorld.QueryPoint(point, filter)
+}
+
+// QueryAABB - Returns the bodies whose shape overlaps an axis aligned bounding box
+func QueryAABB(box AABB, filter QueryFilter) []*Body {
+	return defaultWorld.QueryAABB(box, filter)
+}
+
+// Raycast - Returns the closest body hit by a ray going from start to end. Sensors are ignored
+func (w *World) Raycast(start, end rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	var closest RaycastHit
+	found := false
+
+	w.raycast(start, end, filter, func(hit RaycastHit) {
+		if !found || hit.Fraction < closest.Fraction {
+			closest = hit
+			found = true
+		}
+	})
+
+	return closest, found
+}
+
+// RaycastAll - Returns every body hit by a ray going from start to end, sorted by distance. Sensors are ignored
+func (w *World) RaycastAll(start, end rl.Vector2, filter QueryFilter) []RaycastHit {
+	var hits []RaycastHit
+
+	w.raycast(start, end, filter, func(hit RaycastHit) {
+		hits = append(hits, hit)
+	})
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Fraction < hits[j].Fraction
+	})
+	return hits
+}
+
+// CircleCast - Returns the first body hit by a circle moved from center by translation. Sensors are ignored.
+// Bodies already overlapping the circle are reported with a zero fraction
+func (w *World) CircleCast(center rl.Vector2, radius float32, translation rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	caster := shapeCaster{shapeType: CircleShape, center: center, radius: radius}
+	return w.shapeCast(caster, translation, filter)
+}
+
+// PolygonCast - Returns the first body hit by a convex polygon, given by its local vertices, rotated by orient
+// and moved from position by translation. Sensors are ignored. Bodies already overlapping the polygon are reported
+// with a zero fraction
+func (w *World) PolygonCast(vertices []rl.Vector2, position rl.Vector2, orient float32, translation rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	data, ok := newPolygonData(vertices)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	caster := shapeCaster{shapeType: PolygonShape, polygon: transformPolygon(data, position, rl.Mat2Radians(orient))}
+	return w.shapeCast(caster, translation, filter)
+}
+
+// QueryPoint - Returns the bodies whose shape contains a point
+func (w *World) QueryPoint(point rl.Vector2, filter QueryFilter) []*Body {
+	var result []*Body
+
+	for _, body := range w.bodies {
+		if filter != nil && !filter(body) {
+			continue
+		}
+		if !body.GetAABB().Contains(point) {
+			continue
+		}
+
+		if body.ContainsPoint(point) {
+			result = append(result, body)
+		}
+	}
+
+	return result
+}
+
+// QueryAABB - Returns the bodies whose shape overlaps an axis aligned bounding box
+func (w *World) QueryAABB(box AABB, filter QueryFilter) []*Body {
+	var result []*Body
+
+	for _, body := range w.bodies {
+		if filter != nil && !filter(body) {
+			continue
+		}
+		if !body.GetAABB().Overlaps(box) {
+			continue
+		}
+
+		for _, shape := range body.GetFixtures() {
+			if shapeOverlapsAABB(shape, box) {
+				result = append(result, body)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// ContainsPoint - Checks if a world space point is inside any of the physics body shapes
+func (b *Body) ContainsPoint(point rl.Vector2) bool {
+	for _, shape := range b.GetFixtures() {
+		if shapeContainsPoint(shape, point) {
+			return true
+		}
+	}
+	return false
+}
+
+// raycast - Calls fn for every body hit by a ray going from start to end
+func (w *World) raycast(start, end rl.Vector2, filter QueryFilter, fn func(hit RaycastHit)) {
+	sweep := sweptAABB(AABB{Min: start, Max: start}, rl.Vector2Subtract(end, start))
+
+	for _, body := range w.bodies {
+		if !w.castCandidate(body, sweep, filter) {
+			continue
+		}
+
+		// Compound bodies report the closest of their shapes
+		var closest RaycastHit
+		found := false
+		for _, shape := range body.GetFixtures() {
+			if hit, ok := raycastShape(shape, start, end); ok && (!found || hit.Fraction < closest.Fraction) {
+				hit.Body, hit.Shape = body, shape
+				closest = hit
+				found = true
+			}
+		}
+
+		if found {
+			fn(closest)
+		}
+	}
+}
+
+// shapeCast - Returns the first body hit by a shape moved by translation
+func (w *World) shapeCast(caster shapeCaster, translation rl.Vector2, filter QueryFilter) (RaycastHit, bool) {
+	sweep := sweptAABB(caster.bounds(), translation)
+
+	var closest RaycastHit
+	found := false
+
+	for _, body := range w.bodies {
+		if !w.castCandidate(body, sweep, filter) {
+			continue
+		}
+
+		for _, shape := range body.GetFixtures() {
+			if hit, ok := caster.cast(translation, shape); ok && (!found || hit.Fraction < closest.Fraction) {
+				hit.Body, hit.Shape = body, shape
+				closest = hit
+				found = true
+			}
+		}
+	}
+
+	return closest, found
+}
+
+// castCandidate - Checks if a body may be hit by a ray or a cast shape covering the swept bounds
+func (w *World) castCandidate(body *Body, sweep AABB, filter QueryFilter) bool {
+	if body.IsSensor || filter != nil && !filter(body) {
+		return false
+	}
+	return body.GetAABB().Overlaps(sweep)
+}
+
+// raycastShape - Returns the first point a ray going from start to end hits on a shape
+func raycastShape(shape *Shape, start, end rl.Vector2) (RaycastHit, bool) {
+	switch shape.Type {
+	case CircleShape:
+		return raycastCircle(start, end, shape.GetPosition(), shape.Radius)
+	case PolygonShape:
+		return raycastPolygon(start, end, getWorldPolygon(shape))
+	case EdgeShape:
+		return raycastSegment(start, end, shape.GetVertex(0), shape.GetVertex(1), shape.getEdgeNormal())
+	}
+	return RaycastHit{}, false
+}
+
+// raycastCircle - Returns the first point a ray going from start to end hits on a circle
+func raycastCircle(start, end, center rl.Vector2, radius float32) (RaycastHit, bool) {
+	d := rl.Vector2Subtract(end, start)
+	s := rl.Vector2Subtract(start, center)
+
+	// Rays starting inside the circle do not hit it
+	c := rl.Vector2DotProduct(s, s) - radius*radius
+	if c < 0 {
+		return RaycastHit{}, false
+	}
+
+	rr := rl.Vector2DotProduct(d, d)
+	if rr < epsilon {
+		return RaycastHit{}, false
+	}
+
+	b := rl.Vector2DotProduct(s, d)
+	sigma := b*b - rr*c
+	if sigma < 0 {
+		return RaycastHit{}, false
+	}
+
+	fraction := (-b - float32(math.Sqrt(float64(sigma)))) / rr
+	if fraction < 0 || fraction > 1 {
+		return RaycastHit{}, false
+	}
+
+	point := rl.Vector2Add(start, rl.Vector2Scale(d, fraction))
+	normal := rl.Vector2Subtract(point, center)
+	normalize(&normal)
+
+	return RaycastHit{Point: point, Normal: normal, Fraction: fraction}, true
+}
+
+// raycastPolygon - Returns the first point a ray going from start to end hits on a world space polygon
+func raycastPolygon(start, end rl.Vector2, polygon worldPolygon) (RaycastHit, bool) {
+	d := rl.Vector2Subtract(end, start)
+	lower, upper := float32(0), float32(1)
+	index := -1
+
+	for i := 0; i < polygon.count; i++ {
+		numerator := rl.Vector2DotProduct(polygon.normals[i], rl.Vector2Subtract(polygon.positions[i], start))
+		denominator := rl.Vector2DotProduct(polygon.normals[i], d)
+
+		if denominator == 0 {
+			// Parallel to the face and outside of it
+			if numerator < 0 {
+				return RaycastHit{}, false
+			}
+			continue
+		}
+
+		if denominator < 0 && numerator < lower*denominator {
+			// Entering the face half plane
+			lower = numerator / denominator
+			index = i
+		} else if denominator > 0 && numerator < upper*denominator {
+			// Leaving the face half plane
+			upper = numerator / denominator
+		}
+
+		if upper < lower {
+			return RaycastHit{}, false
+		}
+	}
+
+	// Rays starting inside the polygon do not hit it
+	if index < 0 {
+		return RaycastHit{}, false
+	}
+
+	return RaycastHit{
+		Point:    rl.Vector2Add(start, rl.Vector2Scale(d, lower)),
+		Normal:   polygon.normals[index],
+		Fraction: lower,
+	}, true
+}
+
+// shapeCaster - Circle or world space polygon moved by a shape cast
+type shapeCaster struct {
+	shapeType ShapeType
+	// Circle center and radius (used for circle shapes)
+	center rl.Vector2
+	radius float32
+	// World space polygon (used for polygon shapes)
+	polygon worldPolygon
+}
+
+// newShapeCaster - Returns a caster for a physics body shape placed at a position
+func newShapeCaster(shape *Shape, position rl.Vector2) shapeCaster {
+	if shape.Type == CircleShape {
+		return shapeCaster{shapeType: CircleShape, center: position, radius: shape.Radius}
+	}
+	return shapeCaster{shapeType: PolygonShape, polygon: transformPolygon(shape.VertexData, position, shape.Transform)}
+}
+
+// bounds - Returns the caster axis aligned bounding box before moving
+func (c shapeCaster) bounds() AABB {
+	if c.shapeType == CircleShape {
+		return AABB{
+			Min: rl.NewVector2(c.center.X-c.radius, c.center.Y-c.radius),
+			Max: rl.NewVector2(c.center.X+c.radius, c.center.Y+c.radius),
+		}
+	}
+	return c.polygon.bounds()
+}
+
+// cast - Returns the first point the caster moved by translation hits on a static shape, edges are only hit on
+// their front side
+func (c shapeCaster) cast(translation rl.Vector2, target *Shape) (RaycastHit, bool) {
+	hit, ok := c.castShape(translation, target)
+	if ok && target.Type == EdgeShape && rl.Vector2DotProduct(hit.Normal, target.getEdgeNormal()) < 0 {
+		return RaycastHit{}, false
+	}
+	return hit, ok
+}
+
+// castShape - Returns the first point the caster moved by translation hits on a static shape
+func (c shapeCaster) castShape(translation rl.Vector2, target *Shape) (RaycastHit, bool) {
+	switch {
+	case c.shapeType == CircleShape && target.Type == CircleShape:
+		return castCircleCircle(c.center, c.radius, translation, target.GetPosition(), target.Radius)
+	case c.shapeType == CircleShape:
+		return castCirclePolygon(c.center, c.radius, translation, getWorldPolygon(target))
+	case target.Type == CircleShape:
+		// Cast the circle against the static polygon using the opposite translation
+		hit, ok := castCirclePolygon(target.GetPosition(), target.Radius, rl.Vector2Negate(translation), c.polygon)
+		if ok {
+			hit.Point = rl.Vector2Add(hit.Point, rl.Vector2Scale(translation, hit.Fraction))
+			hit.Normal = rl.Vector2Negate(hit.Normal)
+		}
+		return hit, ok
+	default:
+		return castPolygonPolygon(c.polygon, translation, getWorldPolygon(target))
+	}
+}
+
+// castCircleCircle - Returns the first point a moving circle hits on a static circle
+func castCircleCircle(center rl.Vector2, radius float32, translation, target rl.Vector2, targetRadius float32) (RaycastHit, bool) {
+	// Already overlapping
+	offset := rl.Vector2Subtract(center, target)
+	if rl.Vector2LengthSqr(offset) <= (radius+targetRadius)*(radius+targetRadius) {
+		normal := offset
+		if rl.Vector2LengthSqr(normal) < epsilon {
+			normal = rl.Vector2Negate(translation)
+		}
+		normalize(&normal)
+		point := rl.Vector2Add(target, rl.Vector2Scale(normal, targetRadius))
+		return RaycastHit{Point: point, Normal: normal, Fraction: 0}, true
+	}
+
+	hit, ok := raycastCircle(center, rl.Vector2Add(center, translation), target, radius+targetRadius)
+	if !ok {
+		return RaycastHit{}, false
+	}
+
+	hit.Point = rl.Vector2Add(target, rl.Vector2Scale(hit.Normal, targetRadius))
+	return hit, true
+}
+
+// castCirclePolygon - Returns the first point a moving circle hits on a static world space polygon
+func castCirclePolygon(center rl.Vector2, radius float32, translation rl.Vector2, polygon worldPolygon) (RaycastHit, bool) {
+	// Already overlapping
+	if point, normal, distance := closestPointOnPolygon(polygon, center); distance <= radius {
+		return RaycastHit{Point: point, Normal: normal, Fraction: 0}, true
+	}
+
+	end := rl.Vector2Add(center, translation)
+	var closest RaycastHit
+	found := false
+
+	// The swept circle center hits the polygon inflated by the circle radius: faces moved along
+	// their normals and rounded corners at each vertex
+	for i := 0; i < polygon.count; i++ {
+		next := getNextIndex(i, polygon.count)
+		normal := polygon.normals[i]
+		offset := rl.Vector2Scale(normal, radius)
+
+		if hit, ok := raycastSegment(center, end, rl.Vector2Add(polygon.positions[i], offset), rl.Vector2Add(polygon.positions[next], offset), normal); ok {
+			if !found || hit.Fraction < closest.Fraction {
+				hit.Point = rl.Vector2Subtract(hit.Point, offset)
+				closest = hit
+				found = true
+			}
+		}
+
+		if hit, ok := raycastCircle(center, end, polygon.positions[i], radius); ok {
+			if !found || hit.Fraction < closest.Fraction {
+				hit.Point = polygon.positions[i]
+				closest = hit
+				found = true
+			}
+		}
+	}
+
+	return closest, found
+}
+
+// castPolygonPolygon - Returns the first point a moving polygon hits on a static polygon, using a swept
+// separating axis test on both polygons face normals
+func castPolygonPolygon(caster worldPolygon, translation rl.Vector2, target worldPolygon) (RaycastHit, bool) {
+	enter, exit := float32(-math.MaxFloat32), float32(math.MaxFloat32)
+	var normal rl.Vector2
+	casterAxis := false
+
+	// Axis of least penetration in case the polygons already overlap
+	leastPenetration := float32(math.MaxFloat32)
+	var overlapNormal rl.Vector2
+	overlapCasterAxis := false
+
+	testAxis := func(axis rl.Vector2, fromCaster bool) bool {
+		minA, maxA := caster.project(axis)
+		minB, maxB := target.project(axis)
+		speed := rl.Vector2DotProduct(translation, axis)
+
+		var axisEnter, axisExit float32
+		switch {
+		case maxA < minB:
+			// Caster is behind the target along the axis
+			if speed <= 0 {
+				return false
+			}
+			axisEnter, axisExit = (minB-maxA)/speed, (maxB-minA)/speed
+		case minA > maxB:
+			// Caster is ahead of the target along the axis
+			if speed >= 0 {
+				return false
+			}
+			axisEnter, axisExit = (maxB-minA)/speed, (minB-maxA)/speed
+		default:
+			// Already overlapping along the axis
+			axisEnter = float32(-math.MaxFloat32)
+			switch {
+			case speed > 0:
+				axisExit = (maxB - minA) / speed
+			case speed < 0:
+				axisExit = (minB - maxA) / speed
+			default:
+				axisExit = float32(math.MaxFloat32)
+			}
+
+			if penetration := min(maxA-minB, maxB-minA); penetration < leastPenetration {
+				leastPenetration = penetration
+				overlapNormal = axis
+				if maxA-minB < maxB-minA {
+					overlapNormal = rl.Vector2Negate(axis)
+				}
+				overlapCasterAxis = fromCaster
+			}
+		}
+
+		if axisEnter > enter {
+			enter = axisEnter
+			normal = axis
+			if speed > 0 {
+				normal = rl.Vector2Negate(axis)
+			}
+			casterAxis = fromCaster
+		}
+		exit = min(exit, axisExit)
+		return enter <= exit
+	}
+
+	for i := 0; i < caster.count; i++ {
+		if !testAxis(caster.normals[i], true) {
+			return RaycastHit{}, false
+		}
+	}
+	for i := 0; i < target.count; i++ {
+		if !testAxis(target.normals[i], false) {
+			return RaycastHit{}, false
+		}
+	}
+
+	fraction := enter
+	if fraction == float32(-math.MaxFloat32) {
+		// Polygons already overlap, report the least penetration axis
+		fraction = 0
+		normal = overlapNormal
+		casterAxis = overlapCasterAxis
+	}
+	if fraction > 1 || exit < 0 {
+		return RaycastHit{}, false
+	}
+
+	// Contact point is the target vertex touching a caster face or the caster vertex touching a target face
+	moved := rl.Vector2Scale(translation, fraction)
+	var point rl.Vector2
+	if casterAxis {
+		point = target.support(normal)
+	} else {
+		point = rl.Vector2Add(caster.support(rl.Vector2Negate(normal)), moved)
+	}
+
+	return RaycastHit{Point: point, Normal: normal, Fraction: fraction}, true
+}
+
+// raycastSegment - Returns the point a ray going from start to end crosses a segment from its front side
+func raycastSegment(start, end, v1, v2, normal rl.Vector2) (RaycastHit, bool) {
+	d := rl.Vector2Subtract(end, start)
+	denominator := rl.Vector2DotProduct(normal, d)
+	if denominator >= 0 {
+		return RaycastHit{}, false
+	}
+
+	fraction := rl.Vector2DotProduct(normal, rl.Vector2Subtract(v1, start)) / denominator
+	if fraction < 0 || fraction > 1 {
+		return RaycastHit{}, false
+	}
+
+	point := rl.Vector2Add(start, rl.Vector2Scale(d, fraction))
+	edge := rl.Vector2Subtract(v2, v1)
+	s := rl.Vector2DotProduct(rl.Vector2Subtract(point, v1), edge)
+	if s < 0 || s > rl.Vector2DotProduct(edge, edge) {
+		return RaycastHit{}, false
+	}
+
+	return RaycastHit{Point: point, Normal: normal, Fraction: fraction}, true
+}
+
+// closestPointOnPolygon - Returns the closest polygon surface point to a position, the surface normal pointing
+// towards the position and the distance to it (zero or negative if the position is inside the polygon)
+func closestPointOnPolygon(polygon worldPolygon, position rl.Vector2) (rl.Vector2, rl.Vector2, float32) {
+	// Find face with the greatest separation
+	separation := float32(-math.MaxFloat32)
+	face := 0
+	for i := 0; i < polygon.count; i++ {
+		s := rl.Vector2DotProduct(polygon.normals[i], rl.Vector2Subtract(position, polygon.positions[i]))
+		if s > separation {
+			separation = s
+			face = i
+		}
+	}
+
+	// Inside the polygon, closest point is on the least penetrated face
+	if separation <= 0 {
+		normal := polygon.normals[face]
+		point := rl.Vector2Subtract(position, rl.Vector2Scale(normal, separation))
+		return point, normal, separation
+	}
+
+	// Outside, check every face segment
+	bestDistance := float32(math.MaxFloat32)
+	var bestPoint rl.Vector2
+	for i := 0; i < polygon.count; i++ {
+		point := closestPointOnSegment(polygon.positions[i], polygon.positions[getNextIndex(i, polygon.count)], position)
+		if distance := rl.Vector2DistanceSqr(point, position); distance < bestDistance {
+			bestDistance = distance
+			bestPoint = point
+		}
+	}
+
+	normal := rl.Vector2Subtract(position, bestPoint)
+	normalize(&normal)
+	return bestPoint, normal, float32(math.Sqrt(float64(bestDistance)))
+}
+
+// closestPointOnSegment - Returns the closest point of a segment to a position
+func closestPointOnSegment(v1, v2, position rl.Vector2) rl.Vector2 {
+	edge := rl.Vector2Subtract(v2, v1)
+	length := rl.Vector2DotProduct(edge, edge)
+	if length < epsilon {
+		return v1
+	}
+
+	t := clamp(rl.Vector2DotProduct(rl.Vector2Subtract(position, v1), edge)/length, 0, 1)
+	return rl.Vector2Add(v1, rl.Vector2Scale(edge, t))
+}
+
+// shapeContainsPoint - Checks if a world space point is inside a shape
+func shapeContainsPoint(shape *Shape, point rl.Vector2) bool {
+	switch shape.Type {
+	case CircleShape:
+		return rl.Vector2DistanceSqr(point, shape.GetPosition()) <= shape.Radius*shape.Radius
+	case PolygonShape:
+		polygon := getWorldPolygon(shape)
+		for i := 0; i < polygon.count; i++ {
+			if rl.Vector2DotProduct(polygon.normals[i], rl.Vector2Subtract(point, polygon.positions[i])) > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// shapeOverlapsAABB - Checks if a shape overlaps a world space axis aligned bounding box
+func shapeOverlapsAABB(shape *Shape, box AABB) bool {
+	switch shape.Type {
+	case CircleShape:
+		center := shape.GetPosition()
+		closest := rl.NewVector2(clamp(center.X, box.Min.X, box.Max.X), clamp(center.Y, box.Min.Y, box.Max.Y))
+		return rl.Vector2DistanceSqr(center, closest) <= shape.Radius*shape.Radius
+	case PolygonShape, EdgeShape:
+		polygon := getWorldPolygon(shape)
+		if !polygon.bounds().Overlaps(box) {
+			return false
+		}
+
+		// Box axes are covered by the bounds test, check the polygon face normals
+		corners := [4]rl.Vector2{box.Min, {X: box.Max.X, Y: box.Min.Y}, box.Max, {X: box.Min.X, Y: box.Max.Y}}
+		for i := 0; i < polygon.count; i++ {
+			minP, maxP := polygon.project(polygon.normals[i])
+			minB, maxB := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+			for _, corner := range corners {
+				projection := rl.Vector2DotProduct(corner, polygon.normals[i])
+				minB, maxB = min(minB, projection), max(maxB, projection)
+			}
+			if maxP < minB || maxB < minP {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// getWorldPolygon - Returns a polygon shape vertices and normals transformed to world space
+func getWorldPolygon(shape *Shape) worldPolygon {
+	return transformPolygon(shape.VertexData, shape.GetPosition(), shape.Transform)
+}
+
+// transformPolygon - Returns polygon vertices and normals transformed to world space
+func transformPolygon(data Polygon, position rl.Vector2, transform rl.Mat2) worldPolygon {
+	polygon := worldPolygon{count: data.VertexCount}
+	for i := 0; i < data.VertexCount; i++ {
+		polygon.positions[i] = rl.Vector2Add(position, rl.Mat2MultiplyVector2(transform, data.Positions[i]))
+		polygon.normals[i] = rl.Mat2MultiplyVector2(transform, data.Normals[i])
+	}
+	return polygon
+}
+
+// project - Returns the polygon projection interval on an axis
+func (p worldPolygon) project(axis rl.Vector2) (float32, float32) {
+	minP, maxP := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+	for i := 0; i < p.count; i++ {
+		projection := rl.Vector2DotProduct(p.positions[i], axis)
+		minP, maxP = min(minP, projection), max(maxP, projection)
+	}
+	return minP, maxP
+}
+
+// support - Returns the polygon vertex furthest along a direction
+func (p worldPolygon) support(dir rl.Vector2) rl.Vector2 {
+	best := p.positions[0]
+	bestProjection := rl.Vector2DotProduct(best, dir)
+	for i := 1; i < p.count; i++ {
+		if projection := rl.Vector2DotProduct(p.positions[i], dir); projection > bestProjection {
+			best, bestProjection = p.positions[i], projection
+		}
+	}
+	return best
+}
+
+// bounds - Returns the polygon axis aligned bounding box
+func (p worldPolygon) bounds() AABB {
+	box := AABB{
+		Min: rl.NewVector2(math.MaxFloat32, math.MaxFloat32),
+		Max: rl.NewVector2(-math.MaxFloat32, -math.MaxFloat32),
+	}
+	for i := 0; i < p.count; i++ {
+		box.Min.X, box.Min.Y = min(box.Min.X, p.positions[i].X), min(box.Min.Y, p.positions[i].Y)
+		box.Max.X, box.Max.Y = max(box.Max.X, p.positions[i].X), max(box.Max.Y, p.positions[i].Y)
+	}
+	return box
+}
+
+// sweptAABB - Returns the bounding box covering a box moved by a translation
+func sweptAABB(box AABB, translation rl.Vector2) AABB {
+	moved := AABB{Min: rl.Vector2Add(box.Min, translation), Max: rl.Vector2Add(box.Max, translation)}
+	return AABB{
+		Min: rl.NewVector2(min(box.Min.X, moved.Min.X), min(box.Min.Y, moved.Min.Y)),
+		Max: rl.NewVector2(max(box.Max.X, moved.Max.X), max(box.Max.Y, moved.Max.Y)),
+	}
+}
+
+// newPolygonData - Creates polygon vertex data from convex local vertices in any winding order
+func newPolygonData(vertices []rl.Vector2) (Polygon, bool) {
+	var data Polygon
+	if len(vertices) < 3 || len(vertices) > maxVertices {
+		return data, false
+	}
+
+	// Physics polygons are wound counter clockwise, reverse clockwise input
+	area := float32(0)
+	for i := range vertices {
+		area += rl.Vector2CrossProduct(vertices[i], vertices[getNextIndex(i, len(vertices))])
+	}
+	if math.Abs(float64(area)) < epsilon {
+		return data, false
+	}
+
+	data.VertexCount = len(vertices)
+	for i := range vertices {
+		if area > 0 {
+			data.Positions[i] = vertices[i]
+		} else {
+			data.Positions[i] = vertices[len(vertices)-1-i]
+		}
+	}
+
+	// Calculate polygon faces normals
+	for i := 0; i < data.VertexCount; i++ {
+		nextIndex := getNextIndex(i, data.VertexCount)
+		face := rl.Vector2Subtract(data.Positions[nextIndex], data.Positions[i])
+
+		data.Normals[i] = rl.NewVector2(face.Y, -face.X)
+		normalize(&data.Normals[i])
+	}
+
+	return data, true
+}
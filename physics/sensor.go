@@ -0,0 +1,45 @@
+package physics
+
+// SensorListener - Callbacks called when bodies enter and exit sensor bodies. Nil callbacks are ignored
+type SensorListener struct {
+	// Called on the first step a body overlaps the sensor
+	Enter func(sensor, visitor *Body)
+	// Called on the first step a body stops overlapping the sensor or when one of them is destroyed
+	Exit func(sensor, visitor *Body)
+}
+
+// SetSensorListener - Sets the world callbacks called when bodies enter and exit sensor bodies
+func SetSensorListener(listener SensorListener) {
+	defaultWorld.SetSensorListener(listener)
+}
+
+// SetSensorListener - Sets the world callbacks called when bodies enter and exit sensor bodies
+func (w *World) SetSensorListener(listener SensorListener) {
+	w.sensorListener = listener
+}
+
+// dispatchSensorEvent - Calls the world and sensor body listeners of a sensor contact event
+func (w *World) dispatchSensorEvent(event contactEvent) {
+	sensor, visitor := event.contact.BodyA, event.contact.BodyB
+	if !sensor.IsSensor {
+		sensor, visitor = visitor, sensor
+	}
+
+	w.sensorListener.call(event.kind, sensor, visitor)
+	sensor.SensorListener.call(event.kind, sensor, visitor)
+}
+
+// call - Calls the listener callback matching the event type, sensors do not report persisting overlaps
+func (l SensorListener) call(kind contactEventType, sensor, visitor *Body) {
+	var callback func(sensor, visitor *Body)
+	switch kind {
+	case contactBegin:
+		callback = l.Enter
+	case contactEnd:
+		callback = l.Exit
+	}
+
+	if callback != nil {
+		callback(sensor, visitor)
+	}
+}
@@ -0,0 +1,179 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Sleep thresholds
+const (
+	// Linear speed below which a body is resting, in pixels per millisecond
+	sleepLinearTolerance = 0.02
+	// Angular speed below which a body is resting, in radians per millisecond
+	sleepAngularTolerance = 0.0005
+	// Time every body of an island must be resting before the island falls asleep, in milliseconds
+	timeToSleep = 500.0
+)
+
+// SetSleepEnabled - Enables or disables sleeping of resting bodies, disabling it wakes every body
+func SetSleepEnabled(enabled bool) {
+	defaultWorld.SetSleepEnabled(enabled)
+}
+
+// SetSleepEnabled - Enables or disables sleeping of resting bodies, disabling it wakes every body
+func (w *World) SetSleepEnabled(enabled bool) {
+	w.sleepDisabled = !enabled
+	if !enabled {
+		for _, body := range w.bodies {
+			body.Wake()
+		}
+	}
+}
+
+// Wake - Wakes up a sleeping physics body, bodies touching or jointed to it wake up on the next step
+func (b *Body) Wake() {
+	b.IsSleeping = false
+	b.sleepTime = 0
+}
+
+// isAwake - Checks if the body is simulated, static, kinematic, disabled and sleeping bodies are not
+func (b *Body) isAwake() bool {
+	return !b.IsSleeping && b.invMass() != 0
+}
+
+// isResting - Checks if the body can not move into other bodies, sleeping and static bodies can not
+func (b *Body) isResting() bool {
+	return b.IsSleeping || b.Type == StaticBody
+}
+
+// keepManifolds - Keeps the previous step manifolds of two resting bodies without solving their collisions again
+func (w *World) keepManifolds(bodyA, bodyB *Body) {
+	for _, shapeA := range bodyA.GetFixtures() {
+		for _, shapeB := range bodyB.GetFixtures() {
+			key := manifoldKey{shapeA, shapeB}
+			if manifold, ok := w.manifoldCache[key]; ok {
+				delete(w.manifoldCache, key)
+				w.manifolds = append(w.manifolds, manifold)
+			}
+		}
+	}
+}
+
+// isMoving - Checks if the body has any linear or angular velocity
+func (b *Body) isMoving() bool {
+	return b.Velocity != (rl.Vector2{}) || b.AngularVelocity != 0
+}
+
+// isAwake - Checks if the manifold has to be solved, manifolds between sleeping and static bodies are not
+func (m *Manifold) isAwake() bool {
+	return m.BodyA.isAwake() || m.BodyB.isAwake()
+}
+
+// isJointAwake - Checks if the joint has to be solved, joints between sleeping and static bodies are not
+func isJointAwake(joint Joint) bool {
+	base := joint.base()
+	return base.bodyA != nil && base.bodyA.isAwake() || base.bodyB != nil && base.bodyB.isAwake()
+}
+
+// updateIslands - Groups the dynamic bodies touching or jointed together in islands and wakes every island with an
+// awake body. Static, kinematic and disabled bodies do not join islands, but moving ones wake the bodies they touch
+func (w *World) updateIslands() {
+	w.islandParents = w.islandParents[:0]
+	for i, body := range w.bodies {
+		body.islandIndex = i
+		w.islandParents = append(w.islandParents, i)
+	}
+
+	for _, manifold := range w.manifolds {
+		if manifold != nil && !manifold.IsSensor && manifold.ContactsCount > 0 {
+			w.linkIslands(manifold.BodyA, manifold.BodyB)
+		}
+	}
+	for _, joint := range w.joints {
+		if base := joint.base(); base.bodyA != nil && base.bodyB != nil {
+			w.linkIslands(base.bodyA, base.bodyB)
+		}
+	}
+
+	w.islandAwake = w.islandAwake[:0]
+	for range w.bodies {
+		w.islandAwake = append(w.islandAwake, false)
+	}
+	for _, body := range w.bodies {
+		if body.isAwake() {
+			w.islandAwake[w.findIsland(body.islandIndex)] = true
+		}
+	}
+	for _, body := range w.bodies {
+		if body.IsSleeping && w.islandAwake[w.findIsland(body.islandIndex)] {
+			body.Wake()
+		}
+	}
+}
+
+// linkIslands - Joins the islands of two bodies touching or jointed together
+func (w *World) linkIslands(bodyA, bodyB *Body) {
+	dynamicA, dynamicB := bodyA.invMass() != 0, bodyB.invMass() != 0
+
+	switch {
+	case dynamicA && dynamicB:
+		rootA, rootB := w.findIsland(bodyA.islandIndex), w.findIsland(bodyB.islandIndex)
+		if rootA != rootB {
+			w.islandParents[max(rootA, rootB)] = min(rootA, rootB)
+		}
+	case dynamicA && bodyB.isMoving():
+		bodyA.Wake()
+	case dynamicB && bodyA.isMoving():
+		bodyB.Wake()
+	}
+}
+
+// findIsland - Returns the root index of the island of a body index, compressing the path to it
+func (w *World) findIsland(index int) int {
+	for w.islandParents[index] != index {
+		w.islandParents[index] = w.islandParents[w.islandParents[index]]
+		index = w.islandParents[index]
+	}
+	return index
+}
+
+// updateSleep - Updates the bodies resting time and puts to sleep the islands whose bodies have all been resting
+// long enough
+func (w *World) updateSleep(dt float32) {
+	if w.sleepDisabled {
+		return
+	}
+
+	w.islandSleepTimes = w.islandSleepTimes[:0]
+	for range w.bodies {
+		w.islandSleepTimes = append(w.islandSleepTimes, math.MaxFloat32)
+	}
+
+	linearTolerance := float32(sleepLinearTolerance * sleepLinearTolerance)
+	for _, body := range w.bodies {
+		if !body.isAwake() {
+			continue
+		}
+
+		if !body.AllowSleep || rl.Vector2LengthSqr(body.Velocity) > linearTolerance ||
+			float32(math.Abs(float64(body.AngularVelocity))) > sleepAngularTolerance {
+			body.sleepTime = 0
+		} else {
+			body.sleepTime += dt
+		}
+
+		root := w.findIsland(body.islandIndex)
+		w.islandSleepTimes[root] = min(w.islandSleepTimes[root], body.sleepTime)
+	}
+
+	for _, body := range w.bodies {
+		if body.isAwake() && w.islandSleepTimes[w.findIsland(body.islandIndex)] >= timeToSleep {
+			body.IsSleeping = true
+			body.Velocity = rl.Vector2{}
+			body.AngularVelocity = 0
+			body.Force = rl.Vector2{}
+			body.Torque = 0
+		}
+	}
+}
@@ -0,0 +1,754 @@
+package physics
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Snapshot format constants
+const (
+	// Current snapshot format version, increased on every format change
+	snapshotVersion = 1
+	// Magic bytes starting the binary encoding
+	snapshotMagic = "RLPS"
+)
+
+// Snapshot - Complete state of a physics world bodies and manifolds that can be restored later.
+// Snapshots encode to binary with MarshalBinary and to JSON with the encoding/json package
+type Snapshot struct {
+	// Snapshot format version
+	Version int
+	// Physics world gravity force
+	Gravity rl.Vector2
+	// Fixed time step in milliseconds
+	TimeStep float32
+	// Time accumulated by Update not simulated yet, in milliseconds
+	Accumulator float32
+	// Physics bodies state in world order
+	Bodies []BodyState
+	// Physics manifolds state in world order
+	Manifolds []ManifoldState
+}
+
+// BodyState - Physics body state stored in a snapshot, see Body for fields description. SleepTime is the time the
+// body has been resting, in milliseconds
+type BodyState struct {
+	ID              int
+	Type            BodyType
+	Enabled         bool
+	Position        rl.Vector2
+	Velocity        rl.Vector2
+	Force           rl.Vector2
+	AngularVelocity float32
+	Torque          float32
+	Orient          float32
+	Inertia         float32
+	InverseInertia  float32
+	Mass            float32
+	InverseMass     float32
+	StaticFriction  float32
+	DynamicFriction float32
+	Restitution     float32
+	UseGravity      bool
+	IsGrounded      bool
+	FreezeOrient    bool
+	IsBullet        bool
+	AllowSleep      bool
+	IsSleeping      bool
+	SleepTime       float32
+	CategoryBits    uint16
+	MaskBits        uint16
+	GroupIndex      int16
+	IsSensor        bool
+	Shape           ShapeState
+	// Additional shapes of compound bodies
+	Fixtures []ShapeState
+}
+
+// ShapeState - Physics shape state stored in a snapshot, see Shape and Polygon for fields description
+type ShapeState struct {
+	Type            ShapeType
+	Radius          float32
+	Transform       rl.Mat2
+	Offset          rl.Vector2
+	StaticFriction  float32
+	DynamicFriction float32
+	Restitution     float32
+	SmoothJoints    [2]bool
+	// Polygon or edge vertex positions, empty for circle shapes
+	Positions []rl.Vector2
+	// Polygon or edge vertex normals, empty for circle shapes
+	Normals []rl.Vector2
+}
+
+// ManifoldState - Physics manifold state stored in a snapshot, bodies are referenced by identifier and shapes by
+// their index in the body fixtures
+type ManifoldState struct {
+	ID              int
+	BodyA           int
+	BodyB           int
+	ShapeA          int
+	ShapeB          int
+	Penetration     float32
+	Normal          rl.Vector2
+	Contacts        [2]rl.Vector2
+	ContactsCount   int
+	Restitution     float32
+	DynamicFriction float32
+	StaticFriction  float32
+	NormalImpulses  [2]float32
+	TangentImpulses [2]float32
+	IsSensor        bool
+	Enabled         bool
+	TangentSpeed    float32
+}
+
+// TakeSnapshot - Returns the current state of the physics bodies and manifolds
+func TakeSnapshot() *Snapshot {
+	return defaultWorld.TakeSnapshot()
+}
+
+// RestoreSnapshot - Restores the physics bodies and manifolds state from a snapshot
+func RestoreSnapshot(snapshot *Snapshot) error {
+	return defaultWorld.RestoreSnapshot(snapshot)
+}
+
+// TakeSnapshot - Returns the current state of the physics bodies and manifolds
+func (w *World) TakeSnapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Version:     snapshotVersion,
+		Gravity:     w.gravityForce,
+		TimeStep:    w.deltaTime,
+		Accumulator: w.accumulator,
+		Bodies:      make([]BodyState, 0, len(w.bodies)),
+		Manifolds:   make([]ManifoldState, 0, len(w.manifolds)),
+	}
+
+	for _, body := range w.bodies {
+		snapshot.Bodies = append(snapshot.Bodies, newBodyState(body))
+	}
+
+	for _, manifold := range w.manifolds {
+		snapshot.Manifolds = append(snapshot.Manifolds, ManifoldState{
+			ID:              manifold.ID,
+			BodyA:           manifold.BodyA.ID,
+			BodyB:           manifold.BodyB.ID,
+			ShapeA:          fixtureIndex(manifold.ShapeA),
+			ShapeB:          fixtureIndex(manifold.ShapeB),
+			Penetration:     manifold.Penetration,
+			Normal:          manifold.Normal,
+			Contacts:        manifold.Contacts,
+			ContactsCount:   manifold.ContactsCount,
+			Restitution:     manifold.Restitution,
+			DynamicFriction: manifold.DynamicFriction,
+			StaticFriction:  manifold.StaticFriction,
+			NormalImpulses:  manifold.NormalImpulses,
+			TangentImpulses: manifold.TangentImpulses,
+			IsSensor:        manifold.IsSensor,
+			Enabled:         manifold.Enabled,
+			TangentSpeed:    manifold.TangentSpeed,
+		})
+	}
+
+	return snapshot
+}
+
+// RestoreSnapshot - Restores the physics bodies and manifolds state from a snapshot. Bodies still in the world
+// keep their pointers, listeners and joints, missing bodies are created and bodies not in the snapshot are
+// destroyed. No contact events are dispatched while restoring
+func (w *World) RestoreSnapshot(snapshot *Snapshot) error {
+	if err := snapshot.validate(); err != nil {
+		return err
+	}
+
+	// Forget current touching pairs and manifolds without notifying listeners
+	w.clearContacts()
+	for i := len(w.manifolds) - 1; i >= 0; i-- {
+		w.destroyManifold(w.manifolds[i])
+	}
+
+	// Destroy bodies not in the snapshot
+	restored := make(map[int]bool, len(snapshot.Bodies))
+	for _, state := range snapshot.Bodies {
+		restored[state.ID] = true
+	}
+	existing := make(map[int]*Body, len(w.bodies))
+	for i := len(w.bodies) - 1; i >= 0; i-- {
+		body := w.bodies[i]
+		if !restored[body.ID] {
+			body.Destroy()
+			continue
+		}
+		existing[body.ID] = body
+	}
+
+	// Restore bodies state in snapshot order
+	bodies := make([]*Body, 0, len(snapshot.Bodies))
+	ids := make([]int, 0, len(snapshot.Bodies))
+	for _, state := range snapshot.Bodies {
+		body, ok := existing[state.ID]
+		if !ok {
+			body = &Body{world: w}
+		}
+		state.apply(body)
+
+		bodies = append(bodies, body)
+		ids = append(ids, state.ID)
+	}
+	w.bodies = bodies
+	w.bodyIDs.rebuild(ids)
+
+	// Restore manifolds referencing the restored bodies
+	ids = ids[:0]
+	for _, state := range snapshot.Manifolds {
+		bodyA, bodyB := w.GetBodyByID(state.BodyA), w.GetBodyByID(state.BodyB)
+		w.manifolds = append(w.manifolds, &Manifold{
+			ID:              state.ID,
+			BodyA:           bodyA,
+			BodyB:           bodyB,
+			ShapeA:          bodyA.GetFixtures()[state.ShapeA],
+			ShapeB:          bodyB.GetFixtures()[state.ShapeB],
+			Penetration:     state.Penetration,
+			Normal:          state.Normal,
+			Contacts:        state.Contacts,
+			ContactsCount:   state.ContactsCount,
+			Restitution:     state.Restitution,
+			DynamicFriction: state.DynamicFriction,
+			StaticFriction:  state.StaticFriction,
+			NormalImpulses:  state.NormalImpulses,
+			TangentImpulses: state.TangentImpulses,
+			IsSensor:        state.IsSensor,
+			Enabled:         state.Enabled,
+			TangentSpeed:    state.TangentSpeed,
+		})
+		ids = append(ids, state.ID)
+	}
+	w.manifoldIDs.rebuild(ids)
+
+	// Touching pairs are derived from manifolds, so the next step dispatches the right events
+	w.contactIndex = make(map[contactKey]int, len(w.manifolds))
+	for _, manifold := range w.manifolds {
+		key := newContactKey(manifold.BodyA, manifold.BodyB)
+		if _, ok := w.contactIndex[key]; ok || manifold.ContactsCount == 0 {
+			continue
+		}
+		w.contactIndex[key] = len(w.contacts)
+		w.contacts = append(w.contacts, newContact(manifold))
+	}
+
+	w.gravityForce = snapshot.Gravity
+	w.deltaTime = snapshot.TimeStep
+	w.accumulator = snapshot.Accumulator
+
+	return nil
+}
+
+// newBodyState - Returns the snapshot state of a physics body
+func newBodyState(body *Body) BodyState {
+	state := BodyState{
+		ID:              body.ID,
+		Type:            body.Type,
+		Enabled:         body.Enabled,
+		Position:        body.Position,
+		Velocity:        body.Velocity,
+		Force:           body.Force,
+		AngularVelocity: body.AngularVelocity,
+		Torque:          body.Torque,
+		Orient:          body.Orient,
+		Inertia:         body.Inertia,
+		InverseInertia:  body.InverseInertia,
+		Mass:            body.Mass,
+		InverseMass:     body.InverseMass,
+		StaticFriction:  body.StaticFriction,
+		DynamicFriction: body.DynamicFriction,
+		Restitution:     body.Restitution,
+		UseGravity:      body.UseGravity,
+		IsGrounded:      body.IsGrounded,
+		FreezeOrient:    body.FreezeOrient,
+		IsBullet:        body.IsBullet,
+		AllowSleep:      body.AllowSleep,
+		IsSleeping:      body.IsSleeping,
+		SleepTime:       body.sleepTime,
+		CategoryBits:    body.CategoryBits,
+		MaskBits:        body.MaskBits,
+		GroupIndex:      body.GroupIndex,
+		IsSensor:        body.IsSensor,
+		Shape:           newShapeState(&body.Shape),
+	}
+
+	for _, shape := range body.GetFixtures()[1:] {
+		state.Fixtures = append(state.Fixtures, newShapeState(shape))
+	}
+
+	return state
+}
+
+// newShapeState - Returns the snapshot state of a physics shape
+func newShapeState(shape *Shape) ShapeState {
+	state := ShapeState{
+		Type:            shape.Type,
+		Radius:          shape.Radius,
+		Transform:       shape.Transform,
+		Offset:          shape.Offset,
+		StaticFriction:  shape.StaticFriction,
+		DynamicFriction: shape.DynamicFriction,
+		Restitution:     shape.Restitution,
+		SmoothJoints:    shape.SmoothJoints,
+	}
+
+	if shape.Type != CircleShape {
+		count := shape.VertexData.VertexCount
+		state.Positions = append([]rl.Vector2(nil), shape.VertexData.Positions[:count]...)
+		state.Normals = append([]rl.Vector2(nil), shape.VertexData.Normals[:count]...)
+	}
+
+	return state
+}
+
+// fixtureIndex - Returns the index of a shape in its body fixtures
+func fixtureIndex(shape *Shape) int {
+	for i, fixture := range shape.Body.GetFixtures() {
+		if fixture == shape {
+			return i
+		}
+	}
+	return 0
+}
+
+// apply - Sets the physics body state, keeping its listeners and joints
+func (s BodyState) apply(body *Body) {
+	body.ID = s.ID
+	body.Type = s.Type
+	body.Enabled = s.Enabled
+	body.Position = s.Position
+	body.Velocity = s.Velocity
+	body.Force = s.Force
+	body.AngularVelocity = s.AngularVelocity
+	body.Torque = s.Torque
+	body.Orient = s.Orient
+	body.Inertia = s.Inertia
+	body.InverseInertia = s.InverseInertia
+	body.Mass = s.Mass
+	body.InverseMass = s.InverseMass
+	body.StaticFriction = s.StaticFriction
+	body.DynamicFriction = s.DynamicFriction
+	body.Restitution = s.Restitution
+	body.UseGravity = s.UseGravity
+	body.IsGrounded = s.IsGrounded
+	body.FreezeOrient = s.FreezeOrient
+	body.IsBullet = s.IsBullet
+	body.AllowSleep = s.AllowSleep
+	body.IsSleeping = s.IsSleeping
+	body.sleepTime = s.SleepTime
+	body.CategoryBits = s.CategoryBits
+	body.MaskBits = s.MaskBits
+	body.GroupIndex = s.GroupIndex
+	body.IsSensor = s.IsSensor
+
+	body.Shape = s.Shape.toShape(body)
+	body.fixtures = append(body.fixtures[:0], &body.Shape)
+	for _, fixture := range s.Fixtures {
+		shape := fixture.toShape(body)
+		body.fixtures = append(body.fixtures, &shape)
+	}
+}
+
+// toShape - Returns the physics shape of a body described by the shape state
+func (s ShapeState) toShape(body *Body) Shape {
+	shape := Shape{
+		Type:            s.Type,
+		Body:            body,
+		Radius:          s.Radius,
+		Transform:       s.Transform,
+		Offset:          s.Offset,
+		StaticFriction:  s.StaticFriction,
+		DynamicFriction: s.DynamicFriction,
+		Restitution:     s.Restitution,
+		SmoothJoints:    s.SmoothJoints,
+	}
+	shape.VertexData.VertexCount = len(s.Positions)
+	copy(shape.VertexData.Positions[:], s.Positions)
+	copy(shape.VertexData.Normals[:], s.Normals)
+	return shape
+}
+
+// validate - Checks that a shape state can be restored
+func (s ShapeState) validate() error {
+	if len(s.Positions) > maxVertices || len(s.Positions) != len(s.Normals) {
+		return errors.New("invalid shape vertices")
+	}
+	if s.Type == PolygonShape && len(s.Positions) < 3 {
+		return errors.New("polygon shape has less than 3 vertices")
+	}
+	if s.Type == EdgeShape && len(s.Positions) != 2 {
+		return errors.New("edge shape does not have 2 vertices")
+	}
+	return nil
+}
+
+// validate - Checks that a snapshot can be restored
+func (s *Snapshot) validate() error {
+	if s == nil {
+		return errors.New("physics: nil snapshot")
+	}
+	if s.Version != snapshotVersion {
+		return fmt.Errorf("physics: unsupported snapshot version %d", s.Version)
+	}
+
+	// Number of shapes of each body
+	bodies := make(map[int]int, len(s.Bodies))
+	for _, body := range s.Bodies {
+		if _, ok := bodies[body.ID]; ok || body.ID < 0 {
+			return fmt.Errorf("physics: invalid or duplicated body id %d in snapshot", body.ID)
+		}
+		bodies[body.ID] = 1 + len(body.Fixtures)
+
+		if err := body.Shape.validate(); err != nil {
+			return fmt.Errorf("physics: body %d in snapshot: %w", body.ID, err)
+		}
+		for _, fixture := range body.Fixtures {
+			if err := fixture.validate(); err != nil {
+				return fmt.Errorf("physics: body %d in snapshot: %w", body.ID, err)
+			}
+		}
+	}
+
+	manifolds := make(map[int]bool, len(s.Manifolds))
+	for _, manifold := range s.Manifolds {
+		if manifold.ID < 0 || manifolds[manifold.ID] {
+			return fmt.Errorf("physics: invalid or duplicated manifold id %d in snapshot", manifold.ID)
+		}
+		manifolds[manifold.ID] = true
+
+		shapesA, okA := bodies[manifold.BodyA]
+		shapesB, okB := bodies[manifold.BodyB]
+		if !okA || !okB {
+			return fmt.Errorf("physics: manifold %d references unknown bodies in snapshot", manifold.ID)
+		}
+		if manifold.ShapeA < 0 || manifold.ShapeA >= shapesA || manifold.ShapeB < 0 || manifold.ShapeB >= shapesB {
+			return fmt.Errorf("physics: manifold %d references unknown shapes in snapshot", manifold.ID)
+		}
+		if manifold.ContactsCount < 0 || manifold.ContactsCount > len(manifold.Contacts) {
+			return fmt.Errorf("physics: invalid contacts count of manifold %d in snapshot", manifold.ID)
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary - Encodes the snapshot into a stable little endian binary format
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	e := &snapshotEncoder{}
+	e.buf = append(e.buf, snapshotMagic...)
+	e.uint32(uint32(s.Version))
+	e.vector2(s.Gravity)
+	e.float32(s.TimeStep)
+	e.float32(s.Accumulator)
+
+	e.uint32(uint32(len(s.Bodies)))
+	for _, body := range s.Bodies {
+		e.uint32(uint32(body.ID))
+		e.uint32(uint32(body.Type))
+		e.bool(body.Enabled)
+		e.vector2(body.Position)
+		e.vector2(body.Velocity)
+		e.vector2(body.Force)
+		e.float32(body.AngularVelocity)
+		e.float32(body.Torque)
+		e.float32(body.Orient)
+		e.float32(body.Inertia)
+		e.float32(body.InverseInertia)
+		e.float32(body.Mass)
+		e.float32(body.InverseMass)
+		e.float32(body.StaticFriction)
+		e.float32(body.DynamicFriction)
+		e.float32(body.Restitution)
+		e.bool(body.UseGravity)
+		e.bool(body.IsGrounded)
+		e.bool(body.FreezeOrient)
+		e.bool(body.IsBullet)
+		e.bool(body.AllowSleep)
+		e.bool(body.IsSleeping)
+		e.float32(body.SleepTime)
+		e.uint16(body.CategoryBits)
+		e.uint16(body.MaskBits)
+		e.uint16(uint16(body.GroupIndex))
+		e.bool(body.IsSensor)
+
+		if err := e.shape(body.Shape); err != nil {
+			return nil, fmt.Errorf("physics: body %d in snapshot: %w", body.ID, err)
+		}
+		e.uint32(uint32(len(body.Fixtures)))
+		for _, fixture := range body.Fixtures {
+			if err := e.shape(fixture); err != nil {
+				return nil, fmt.Errorf("physics: body %d in snapshot: %w", body.ID, err)
+			}
+		}
+	}
+
+	e.uint32(uint32(len(s.Manifolds)))
+	for _, manifold := range s.Manifolds {
+		e.uint32(uint32(manifold.ID))
+		e.uint32(uint32(manifold.BodyA))
+		e.uint32(uint32(manifold.BodyB))
+		e.uint32(uint32(manifold.ShapeA))
+		e.uint32(uint32(manifold.ShapeB))
+		e.float32(manifold.Penetration)
+		e.vector2(manifold.Normal)
+		e.vector2(manifold.Contacts[0])
+		e.vector2(manifold.Contacts[1])
+		e.uint32(uint32(manifold.ContactsCount))
+		e.float32(manifold.Restitution)
+		e.float32(manifold.DynamicFriction)
+		e.float32(manifold.StaticFriction)
+		e.float32(manifold.NormalImpulses[0])
+		e.float32(manifold.NormalImpulses[1])
+		e.float32(manifold.TangentImpulses[0])
+		e.float32(manifold.TangentImpulses[1])
+		e.bool(manifold.IsSensor)
+		e.bool(manifold.Enabled)
+		e.float32(manifold.TangentSpeed)
+	}
+
+	return e.buf, nil
+}
+
+// UnmarshalBinary - Decodes a snapshot encoded with MarshalBinary
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	if len(data) < len(snapshotMagic) || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return errors.New("physics: invalid snapshot data")
+	}
+
+	d := &snapshotDecoder{data: data[len(snapshotMagic):]}
+	snapshot := Snapshot{}
+	snapshot.Version = int(d.uint32())
+	if d.err == nil && snapshot.Version != snapshotVersion {
+		return fmt.Errorf("physics: unsupported snapshot version %d", snapshot.Version)
+	}
+	snapshot.Gravity = d.vector2()
+	snapshot.TimeStep = d.float32()
+	snapshot.Accumulator = d.float32()
+
+	count := d.count()
+	snapshot.Bodies = make([]BodyState, count)
+	for i := range snapshot.Bodies {
+		body := &snapshot.Bodies[i]
+		body.ID = int(d.uint32())
+		body.Type = BodyType(d.uint32())
+		body.Enabled = d.bool()
+		body.Position = d.vector2()
+		body.Velocity = d.vector2()
+		body.Force = d.vector2()
+		body.AngularVelocity = d.float32()
+		body.Torque = d.float32()
+		body.Orient = d.float32()
+		body.Inertia = d.float32()
+		body.InverseInertia = d.float32()
+		body.Mass = d.float32()
+		body.InverseMass = d.float32()
+		body.StaticFriction = d.float32()
+		body.DynamicFriction = d.float32()
+		body.Restitution = d.float32()
+		body.UseGravity = d.bool()
+		body.IsGrounded = d.bool()
+		body.FreezeOrient = d.bool()
+		body.IsBullet = d.bool()
+		body.AllowSleep = d.bool()
+		body.IsSleeping = d.bool()
+		body.SleepTime = d.float32()
+		body.CategoryBits = d.uint16()
+		body.MaskBits = d.uint16()
+		body.GroupIndex = int16(d.uint16())
+		body.IsSensor = d.bool()
+
+		body.Shape = d.shape()
+		if fixtures := d.count(); fixtures > 0 {
+			body.Fixtures = make([]ShapeState, fixtures)
+			for j := range body.Fixtures {
+				body.Fixtures[j] = d.shape()
+			}
+		}
+	}
+
+	count = d.count()
+	snapshot.Manifolds = make([]ManifoldState, count)
+	for i := range snapshot.Manifolds {
+		manifold := &snapshot.Manifolds[i]
+		manifold.ID = int(d.uint32())
+		manifold.BodyA = int(d.uint32())
+		manifold.BodyB = int(d.uint32())
+		manifold.ShapeA = int(d.uint32())
+		manifold.ShapeB = int(d.uint32())
+		manifold.Penetration = d.float32()
+		manifold.Normal = d.vector2()
+		manifold.Contacts[0] = d.vector2()
+		manifold.Contacts[1] = d.vector2()
+		manifold.ContactsCount = int(d.uint32())
+		manifold.Restitution = d.float32()
+		manifold.DynamicFriction = d.float32()
+		manifold.StaticFriction = d.float32()
+		manifold.NormalImpulses[0] = d.float32()
+		manifold.NormalImpulses[1] = d.float32()
+		manifold.TangentImpulses[0] = d.float32()
+		manifold.TangentImpulses[1] = d.float32()
+		manifold.IsSensor = d.bool()
+		manifold.Enabled = d.bool()
+		manifold.TangentSpeed = d.float32()
+	}
+
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) != 0 {
+		return errors.New("physics: unexpected trailing snapshot data")
+	}
+
+	*s = snapshot
+	return nil
+}
+
+// snapshotEncoder - Appends little endian values to a buffer
+type snapshotEncoder struct {
+	buf []byte
+}
+
+func (e *snapshotEncoder) uint16(v uint16) {
+	e.buf = binary.LittleEndian.AppendUint16(e.buf, v)
+}
+
+func (e *snapshotEncoder) uint32(v uint32) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *snapshotEncoder) float32(v float32) {
+	e.uint32(math.Float32bits(v))
+}
+
+func (e *snapshotEncoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *snapshotEncoder) vector2(v rl.Vector2) {
+	e.float32(v.X)
+	e.float32(v.Y)
+}
+
+func (e *snapshotEncoder) shape(s ShapeState) error {
+	if len(s.Positions) != len(s.Normals) {
+		return errors.New("invalid shape vertices")
+	}
+
+	e.uint32(uint32(s.Type))
+	e.float32(s.Radius)
+	e.float32(s.Transform.M00)
+	e.float32(s.Transform.M01)
+	e.float32(s.Transform.M10)
+	e.float32(s.Transform.M11)
+	e.vector2(s.Offset)
+	e.float32(s.StaticFriction)
+	e.float32(s.DynamicFriction)
+	e.float32(s.Restitution)
+	e.bool(s.SmoothJoints[0])
+	e.bool(s.SmoothJoints[1])
+	e.uint32(uint32(len(s.Positions)))
+	for i := range s.Positions {
+		e.vector2(s.Positions[i])
+		e.vector2(s.Normals[i])
+	}
+	return nil
+}
+
+// snapshotDecoder - Reads little endian values from a buffer, remembering the first error
+type snapshotDecoder struct {
+	data []byte
+	err  error
+}
+
+// next - Returns the next n bytes, or nil if the data is too short
+func (d *snapshotDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = errors.New("physics: truncated snapshot data")
+		d.data = nil
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *snapshotDecoder) uint16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *snapshotDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *snapshotDecoder) float32() float32 {
+	return math.Float32frombits(d.uint32())
+}
+
+func (d *snapshotDecoder) bool() bool {
+	if b := d.next(1); b != nil {
+		return b[0] != 0
+	}
+	return false
+}
+
+func (d *snapshotDecoder) vector2() rl.Vector2 {
+	return rl.NewVector2(d.float32(), d.float32())
+}
+
+func (d *snapshotDecoder) shape() ShapeState {
+	var s ShapeState
+	s.Type = ShapeType(d.uint32())
+	s.Radius = d.float32()
+	s.Transform.M00 = d.float32()
+	s.Transform.M01 = d.float32()
+	s.Transform.M10 = d.float32()
+	s.Transform.M11 = d.float32()
+	s.Offset = d.vector2()
+	s.StaticFriction = d.float32()
+	s.DynamicFriction = d.float32()
+	s.Restitution = d.float32()
+	s.SmoothJoints[0] = d.bool()
+	s.SmoothJoints[1] = d.bool()
+	if vertices := d.count(); vertices > 0 {
+		s.Positions = make([]rl.Vector2, vertices)
+		s.Normals = make([]rl.Vector2, vertices)
+		for i := 0; i < vertices; i++ {
+			s.Positions[i] = d.vector2()
+			s.Normals[i] = d.vector2()
+		}
+	}
+	return s
+}
+
+// count - Reads an elements count, failing if the remaining data can not hold that many elements
+func (d *snapshotDecoder) count() int {
+	n := int(d.uint32())
+	if d.err == nil && n > len(d.data) {
+		d.err = errors.New("physics: invalid element count in snapshot data")
+	}
+	if d.err != nil {
+		return 0
+	}
+	return n
+}